@@ -5,8 +5,11 @@ import (
 	"fmt"
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/python"
 	"io/ioutil"
+	"os"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/buildinfo"
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/container"
@@ -19,6 +22,7 @@ import (
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/replication"
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/repository"
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/usersmanagement"
+	commandsUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
 	containerutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/utils/container"
 	"github.com/jfrog/jfrog-cli-core/v2/common/commands"
@@ -28,8 +32,21 @@ import (
 	coreConfig "github.com/jfrog/jfrog-cli-core/v2/utils/config"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/ioutils"
+	accesscommand "github.com/jfrog/jfrog-cli/artifactory/commands/access"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/cleanup"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/diskusage"
+	listcommand "github.com/jfrog/jfrog-cli/artifactory/commands/list"
+	replicationcommand "github.com/jfrog/jfrog-cli/artifactory/commands/replication"
+	repocommand "github.com/jfrog/jfrog-cli/artifactory/commands/repository"
+	searchcommand "github.com/jfrog/jfrog-cli/artifactory/commands/search"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/transfer"
 	"github.com/jfrog/jfrog-cli/buildtools"
+	"github.com/jfrog/jfrog-cli/docs/artifactory/accessdiff"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/accesstokencreate"
+	"github.com/jfrog/jfrog-cli/docs/artifactory/accesstokenlist"
+	"github.com/jfrog/jfrog-cli/docs/artifactory/accesstokenrevoke"
+	"github.com/jfrog/jfrog-cli/docs/artifactory/accesstokenrotate"
+	"github.com/jfrog/jfrog-cli/docs/artifactory/applyplan"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/buildadddependencies"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/buildaddgit"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/buildappend"
@@ -40,6 +57,9 @@ import (
 	"github.com/jfrog/jfrog-cli/docs/artifactory/buildpromote"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/buildpublish"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/buildscan"
+	"github.com/jfrog/jfrog-cli/docs/artifactory/bundleexport"
+	"github.com/jfrog/jfrog-cli/docs/artifactory/bundleimport"
+	cleanupdocs "github.com/jfrog/jfrog-cli/docs/artifactory/cleanup"
 	copydocs "github.com/jfrog/jfrog-cli/docs/artifactory/copy"
 	curldocs "github.com/jfrog/jfrog-cli/docs/artifactory/curl"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/delete"
@@ -50,6 +70,7 @@ import (
 	dotnetdocs "github.com/jfrog/jfrog-cli/docs/artifactory/dotnet"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/dotnetconfig"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/download"
+	"github.com/jfrog/jfrog-cli/docs/artifactory/du"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/gitlfsclean"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/gocommand"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/goconfig"
@@ -59,6 +80,8 @@ import (
 	"github.com/jfrog/jfrog-cli/docs/artifactory/groupaddusers"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/groupcreate"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/groupdelete"
+	"github.com/jfrog/jfrog-cli/docs/artifactory/groupsexport"
+	"github.com/jfrog/jfrog-cli/docs/artifactory/ls"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/move"
 	mvndoc "github.com/jfrog/jfrog-cli/docs/artifactory/mvn"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/mvnconfig"
@@ -72,6 +95,7 @@ import (
 	"github.com/jfrog/jfrog-cli/docs/artifactory/ocstartbuild"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/permissiontargetcreate"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/permissiontargetdelete"
+	"github.com/jfrog/jfrog-cli/docs/artifactory/permissiontargetexport"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/permissiontargettemplate"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/permissiontargetupdate"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/ping"
@@ -81,26 +105,41 @@ import (
 	"github.com/jfrog/jfrog-cli/docs/artifactory/podmanpush"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/replicationcreate"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/replicationdelete"
+	"github.com/jfrog/jfrog-cli/docs/artifactory/replicationlist"
+	"github.com/jfrog/jfrog-cli/docs/artifactory/replicationstatus"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/replicationtemplate"
+	"github.com/jfrog/jfrog-cli/docs/artifactory/repoapply"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/repocreate"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/repodelete"
+	"github.com/jfrog/jfrog-cli/docs/artifactory/repolist"
+	"github.com/jfrog/jfrog-cli/docs/artifactory/reposhow"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/repotemplate"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/repoupdate"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/search"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/setprops"
+	"github.com/jfrog/jfrog-cli/docs/artifactory/sync"
+	"github.com/jfrog/jfrog-cli/docs/artifactory/trashempty"
+	"github.com/jfrog/jfrog-cli/docs/artifactory/trashlist"
+	"github.com/jfrog/jfrog-cli/docs/artifactory/trashrestore"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/upload"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/usercreate"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/userscreate"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/usersdelete"
+	"github.com/jfrog/jfrog-cli/docs/artifactory/usersexport"
+	"github.com/jfrog/jfrog-cli/docs/artifactory/verify"
 	yarndocs "github.com/jfrog/jfrog-cli/docs/artifactory/yarn"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/yarnconfig"
 	"github.com/jfrog/jfrog-cli/docs/common"
+	"github.com/jfrog/jfrog-cli/utils/cache"
 	"github.com/jfrog/jfrog-cli/utils/cliutils"
 	"github.com/jfrog/jfrog-cli/utils/progressbar"
+	"github.com/jfrog/jfrog-cli/utils/ratelimit"
+	"github.com/jfrog/jfrog-cli/utils/summary"
 	buildinfocmd "github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
 	"github.com/jfrog/jfrog-client-go/artifactory/services"
 	clientutils "github.com/jfrog/jfrog-client-go/utils"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
 	"github.com/jfrog/jfrog-client-go/utils/log"
 	"github.com/jszwec/csvutil"
 	"github.com/urfave/cli"
@@ -186,6 +225,143 @@ func GetCommands() []cli.Command {
 				return searchCmd(c)
 			},
 		},
+		{
+			Name:         "ls",
+			Flags:        cliutils.GetCommandFlags(cliutils.Ls),
+			Description:  ls.GetDescription(),
+			HelpName:     corecommon.CreateUsage("rt ls", ls.GetDescription(), ls.Usage),
+			UsageText:    ls.GetArguments(),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return lsCmd(c)
+			},
+		},
+		{
+			Name:         "du",
+			Flags:        cliutils.GetCommandFlags(cliutils.Du),
+			Description:  du.GetDescription(),
+			HelpName:     corecommon.CreateUsage("rt du", du.GetDescription(), du.Usage),
+			UsageText:    du.GetArguments(),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return duCmd(c)
+			},
+		},
+		{
+			Name:         "verify",
+			Flags:        cliutils.GetCommandFlags(cliutils.Verify),
+			Description:  verify.GetDescription(),
+			HelpName:     corecommon.CreateUsage("rt verify", verify.GetDescription(), verify.Usage),
+			UsageText:    verify.GetArguments(),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return verifyCmd(c)
+			},
+		},
+		{
+			Name:         "bundle-export",
+			Flags:        cliutils.GetCommandFlags(cliutils.BundleExport),
+			Description:  bundleexport.GetDescription(),
+			HelpName:     corecommon.CreateUsage("rt bundle-export", bundleexport.GetDescription(), bundleexport.Usage),
+			UsageText:    bundleexport.GetArguments(),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return bundleExportCmd(c)
+			},
+		},
+		{
+			Name:         "bundle-import",
+			Flags:        cliutils.GetCommandFlags(cliutils.BundleImport),
+			Description:  bundleimport.GetDescription(),
+			HelpName:     corecommon.CreateUsage("rt bundle-import", bundleimport.GetDescription(), bundleimport.Usage),
+			UsageText:    bundleimport.GetArguments(),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return bundleImportCmd(c)
+			},
+		},
+		{
+			Name:         "apply-plan",
+			Flags:        cliutils.GetCommandFlags(cliutils.ApplyPlan),
+			Description:  applyplan.GetDescription(),
+			HelpName:     corecommon.CreateUsage("rt apply-plan", applyplan.GetDescription(), applyplan.Usage),
+			UsageText:    applyplan.GetArguments(),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return applyPlanCmd(c)
+			},
+		},
+		{
+			Name:         "cleanup",
+			Flags:        cliutils.GetCommandFlags(cliutils.Cleanup),
+			Description:  cleanupdocs.GetDescription(),
+			HelpName:     corecommon.CreateUsage("rt cleanup", cleanupdocs.GetDescription(), cleanupdocs.Usage),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return cleanupCmd(c)
+			},
+		},
+		{
+			Name:         "sync",
+			Flags:        cliutils.GetCommandFlags(cliutils.Sync),
+			Description:  sync.GetDescription(),
+			HelpName:     corecommon.CreateUsage("rt sync", sync.GetDescription(), sync.Usage),
+			UsageText:    sync.GetArguments(),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return syncCmd(c)
+			},
+		},
+		{
+			Name:        "trash",
+			Description: "Trash can commands.",
+			Subcommands: []cli.Command{
+				{
+					Name:         "list",
+					Flags:        cliutils.GetCommandFlags(cliutils.TrashList),
+					Description:  trashlist.GetDescription(),
+					HelpName:     corecommon.CreateUsage("rt trash list", trashlist.GetDescription(), trashlist.Usage),
+					UsageText:    trashlist.GetArguments(),
+					ArgsUsage:    common.CreateEnvVars(),
+					BashComplete: corecommon.CreateBashCompletionFunc(),
+					Action: func(c *cli.Context) error {
+						return trashListCmd(c)
+					},
+				},
+				{
+					Name:         "restore",
+					Flags:        cliutils.GetCommandFlags(cliutils.TrashRestore),
+					Description:  trashrestore.GetDescription(),
+					HelpName:     corecommon.CreateUsage("rt trash restore", trashrestore.GetDescription(), trashrestore.Usage),
+					UsageText:    trashrestore.GetArguments(),
+					ArgsUsage:    common.CreateEnvVars(),
+					BashComplete: corecommon.CreateBashCompletionFunc(),
+					Action: func(c *cli.Context) error {
+						return trashRestoreCmd(c)
+					},
+				},
+				{
+					Name:         "empty",
+					Flags:        cliutils.GetCommandFlags(cliutils.TrashEmpty),
+					Description:  trashempty.GetDescription(),
+					HelpName:     corecommon.CreateUsage("rt trash empty", trashempty.GetDescription(), trashempty.Usage),
+					UsageText:    trashempty.GetArguments(),
+					ArgsUsage:    common.CreateEnvVars(),
+					BashComplete: corecommon.CreateBashCompletionFunc(),
+					Action: func(c *cli.Context) error {
+						return trashEmptyCmd(c)
+					},
+				},
+			},
+		},
 		{
 			Name:         "set-props",
 			Flags:        cliutils.GetCommandFlags(cliutils.Properties),
@@ -761,6 +937,41 @@ func GetCommands() []cli.Command {
 				return repoDeleteCmd(c)
 			},
 		},
+		{
+			Name:         "repo-list",
+			Flags:        cliutils.GetCommandFlags(cliutils.RepoList),
+			Description:  repolist.GetDescription(),
+			HelpName:     corecommon.CreateUsage("rt repo-list", repolist.GetDescription(), repolist.Usage),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return repoListCmd(c)
+			},
+		},
+		{
+			Name:         "repo-show",
+			Flags:        cliutils.GetCommandFlags(cliutils.RepoShow),
+			Description:  reposhow.GetDescription(),
+			HelpName:     corecommon.CreateUsage("rt repo-show", reposhow.GetDescription(), reposhow.Usage),
+			UsageText:    reposhow.GetArguments(),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return repoShowCmd(c)
+			},
+		},
+		{
+			Name:         "repo-apply",
+			Flags:        cliutils.GetCommandFlags(cliutils.RepoApply),
+			Description:  repoapply.GetDescription(),
+			HelpName:     corecommon.CreateUsage("rt repo-apply", repoapply.GetDescription(), repoapply.Usage),
+			UsageText:    repoapply.GetArguments(),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return repoApplyCmd(c)
+			},
+		},
 		{
 			Name:         "replication-template",
 			Aliases:      []string{"rplt"},
@@ -800,6 +1011,31 @@ func GetCommands() []cli.Command {
 				return replicationDeleteCmd(c)
 			},
 		},
+		{
+			Name:         "replication-list",
+			Aliases:      []string{"rpll"},
+			Flags:        cliutils.GetCommandFlags(cliutils.ReplicationList),
+			Description:  replicationlist.GetDescription(),
+			HelpName:     corecommon.CreateUsage("rt rpll", replicationlist.GetDescription(), replicationlist.Usage),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return replicationListCmd(c)
+			},
+		},
+		{
+			Name:         "replication-status",
+			Aliases:      []string{"rpls"},
+			Flags:        cliutils.GetCommandFlags(cliutils.ReplicationStatus),
+			Description:  replicationstatus.GetDescription(),
+			HelpName:     corecommon.CreateUsage("rt rpls", replicationstatus.GetDescription(), replicationstatus.Usage),
+			UsageText:    replicationstatus.GetArguments(),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return replicationStatusCmd(c)
+			},
+		},
 		{
 			Name:         "permission-target-template",
 			Aliases:      []string{"ptt"},
@@ -851,6 +1087,19 @@ func GetCommands() []cli.Command {
 				return permissionTargetDeleteCmd(c)
 			},
 		},
+		{
+			Name:         "permission-target-export",
+			Aliases:      []string{"pte"},
+			Flags:        cliutils.GetCommandFlags(cliutils.PermissionTargetExport),
+			Description:  permissiontargetexport.GetDescription(),
+			HelpName:     corecommon.CreateUsage("rt pte", permissiontargetexport.GetDescription(), permissiontargetexport.Usage),
+			UsageText:    permissiontargetexport.GetArguments(),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return permissionTargetExportCmd(c)
+			},
+		},
 		{
 			Name:         "user-create",
 			Flags:        cliutils.GetCommandFlags(cliutils.UserCreate),
@@ -926,6 +1175,43 @@ func GetCommands() []cli.Command {
 				return groupDeleteCmd(c)
 			},
 		},
+		{
+			Name:         "users-export",
+			Aliases:      []string{"ue"},
+			Flags:        cliutils.GetCommandFlags(cliutils.UsersExport),
+			Description:  usersexport.GetDescription(),
+			HelpName:     corecommon.CreateUsage("rt ue", usersexport.GetDescription(), usersexport.Usage),
+			UsageText:    usersexport.GetArguments(),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return usersExportCmd(c)
+			},
+		},
+		{
+			Name:         "groups-export",
+			Aliases:      []string{"ge"},
+			Flags:        cliutils.GetCommandFlags(cliutils.GroupsExport),
+			Description:  groupsexport.GetDescription(),
+			HelpName:     corecommon.CreateUsage("rt ge", groupsexport.GetDescription(), groupsexport.Usage),
+			UsageText:    groupsexport.GetArguments(),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return groupsExportCmd(c)
+			},
+		},
+		{
+			Name:         "access-diff",
+			Flags:        cliutils.GetCommandFlags(cliutils.AccessDiff),
+			Description:  accessdiff.GetDescription(),
+			HelpName:     corecommon.CreateUsage("rt access-diff", accessdiff.GetDescription(), accessdiff.Usage),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return accessDiffCmd(c)
+			},
+		},
 		{
 			Name:         "access-token-create",
 			Aliases:      []string{"atc"},
@@ -939,6 +1225,41 @@ func GetCommands() []cli.Command {
 				return accessTokenCreateCmd(c)
 			},
 		},
+		{
+			Name:         "access-token-list",
+			Aliases:      []string{"atl"},
+			Flags:        cliutils.GetCommandFlags(cliutils.AccessTokenList),
+			Description:  accesstokenlist.GetDescription(),
+			HelpName:     corecommon.CreateUsage("rt atl", accesstokenlist.GetDescription(), accesstokenlist.Usage),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return accessTokenListCmd(c)
+			},
+		},
+		{
+			Name:         "access-token-revoke",
+			Flags:        cliutils.GetCommandFlags(cliutils.AccessTokenRevoke),
+			Description:  accesstokenrevoke.GetDescription(),
+			HelpName:     corecommon.CreateUsage("rt access-token-revoke", accesstokenrevoke.GetDescription(), accesstokenrevoke.Usage),
+			UsageText:    accesstokenrevoke.GetArguments(),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return accessTokenRevokeCmd(c)
+			},
+		},
+		{
+			Name:         "access-token-rotate",
+			Flags:        cliutils.GetCommandFlags(cliutils.AccessTokenRotate),
+			Description:  accesstokenrotate.GetDescription(),
+			HelpName:     corecommon.CreateUsage("rt access-token-rotate", accesstokenrotate.GetDescription(), accesstokenrotate.Usage),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return accessTokenRotateCmd(c)
+			},
+		},
 	})
 }
 
@@ -1028,6 +1349,43 @@ func getRetryWaitTime(c *cli.Context) (waitMilliSecs int, err error) {
 	return
 }
 
+// getRateLimiter returns a limiter of the '--limit-rate' and '--limit-schedule' values, or nil if the transfer rate isn't limited.
+func getRateLimiter(c *cli.Context) (*ratelimit.Limiter, error) {
+	if c.String("limit-rate") == "" && c.String("limit-schedule") == "" {
+		return nil, nil
+	}
+	rate := int64(ratelimit.Unlimited)
+	var err error
+	if c.String("limit-rate") != "" {
+		if rate, err = ratelimit.ParseRate(c.String("limit-rate")); err != nil {
+			return nil, err
+		}
+	}
+	schedule, err := ratelimit.ParseSchedule(c.String("limit-schedule"))
+	if err != nil {
+		return nil, err
+	}
+	if rate == ratelimit.Unlimited && len(schedule) == 0 {
+		return nil, nil
+	}
+	return ratelimit.NewLimiter(rate, schedule), nil
+}
+
+// getDownloadCache returns the download cache if it's enabled by the '--cache' option or the JFROG_CLI_DOWNLOAD_CACHE_DIR environment variable, or nil otherwise.
+func getDownloadCache(c *cli.Context) (*cache.Cache, error) {
+	if !c.Bool("cache") && !cache.IsDownloadCacheEnabledByEnv() {
+		return nil, nil
+	}
+	if c.Bool("dry-run") || c.IsSet("sync-deletes") {
+		if c.Bool("cache") {
+			return nil, cliutils.PrintHelpAndReturnError("The --cache option cannot be used together with --dry-run or --sync-deletes.", c)
+		}
+		log.Debug("The download cache isn't used together with --dry-run or --sync-deletes.")
+		return nil, nil
+	}
+	return cache.GetDownloadCache()
+}
+
 func getRetryWaitTimeVerificationError() error {
 	return errorutils.CheckError(errors.New("The '--retry-wait-time' option should have a numeric value with 's'/'ms' suffix. " + cliutils.GetDocumentationMessage()))
 }
@@ -1286,6 +1644,9 @@ func prepareDownloadCommand(c *cli.Context) (*spec.SpecFiles, error) {
 }
 
 func downloadCmd(c *cli.Context) error {
+	if c.NArg() == 2 && c.Args().Get(1) == transfer.StdioPath {
+		return streamDownloadCmd(c)
+	}
 	downloadSpec, err := prepareDownloadCommand(c)
 	if err != nil {
 		return err
@@ -1311,21 +1672,65 @@ func downloadCmd(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	summaryFormat, err := cliutils.GetSummaryFormat(c)
+	if err != nil {
+		return err
+	}
+	events, err := getEventsWriter(c, "download")
+	if err != nil {
+		return err
+	}
+	limiter, err := getRateLimiter(c)
+	if err != nil {
+		return err
+	}
+	downloadCache, err := getDownloadCache(c)
+	if err != nil {
+		return err
+	}
+	if c.IsSet("plan-file") {
+		downloadPlanCommand := transfer.NewDownloadCommand()
+		downloadPlanCommand.SetConfiguration(configuration).SetSpec(downloadSpec).SetServerDetails(serverDetails).SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime)
+		return exportPlanCmd(c, downloadPlanCommand)
+	}
+	// The archives are extracted by the transfer package, which inspects them before extracting.
+	explode := isExplode(downloadSpec) && !c.Bool("dry-run")
+	if explode && c.IsSet("sync-deletes") {
+		return cliutils.PrintHelpAndReturnError("The --explode option cannot be used together with --sync-deletes.", c)
+	}
+	// The signatures are verified after the download, so the archives should be kept, and the files of previous runs should be included.
+	if c.IsSet("verify-signature") && (isExplode(downloadSpec) || c.Bool("resume")) {
+		return cliutils.PrintHelpAndReturnError("The --verify-signature option cannot be used together with --explode or --resume.", c)
+	}
+	if c.Bool("resume") || events != nil || downloadCache != nil || explode {
+		return transferDownloadCmd(c, downloadSpec, configuration, serverDetails, buildConfiguration, retries, retryWaitTime, events, summaryFormat, limiter, downloadCache)
+	}
 	downloadCommand := generic.NewDownloadCommand()
-	downloadCommand.SetConfiguration(configuration).SetBuildConfiguration(buildConfiguration).SetSpec(downloadSpec).SetServerDetails(serverDetails).SetDryRun(c.Bool("dry-run")).SetSyncDeletesPath(c.String("sync-deletes")).SetQuiet(cliutils.GetQuietValue(c)).SetDetailedSummary(c.Bool("detailed-summary")).SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime)
+	downloadCommand.SetConfiguration(configuration).SetBuildConfiguration(buildConfiguration).SetSpec(downloadSpec).SetServerDetails(serverDetails).SetDryRun(c.Bool("dry-run")).SetSyncDeletesPath(c.String("sync-deletes")).SetQuiet(cliutils.GetQuietValue(c)).SetDetailedSummary(c.Bool("detailed-summary") || c.IsSet("verify-signature")).SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime)
 
 	if downloadCommand.ShouldPrompt() && !coreutils.AskYesNo("Sync-deletes may delete some files in your local file system. Are you sure you want to continue?\n"+
 		"You can avoid this confirmation message by adding --quiet to the command.", false) {
 		return nil
 	}
 	// This error is being checked latter on because we need to generate sammery report before return.
-	err = progressbar.ExecWithProgress(downloadCommand)
+	err = progressbar.ExecWithProgressAndRateLimit(downloadCommand, limiter)
 	result := downloadCommand.Result()
-	err = cliutils.PrintDetailedSummaryReport(result.SuccessCount(), result.FailCount(), result.Reader(), false, isFailNoOp(c), err)
+	err = verifyDownloadSignatures(c, serverDetails, result, retries, retryWaitTime, err)
+	err = cliutils.PrintSummaryReportInFormat(summaryFormat, downloadCommand.CommandName(), result.SuccessCount(), result.FailCount(), getSummaryReader(c, result), false, isFailNoOp(c), err)
 
 	return cliutils.GetCliError(err, result.SuccessCount(), result.FailCount(), isFailNoOp(c))
 }
 
+// Returns true if any of the spec groups extracts the downloaded archives.
+func isExplode(downloadSpec *spec.SpecFiles) bool {
+	for _, file := range downloadSpec.Files {
+		if explode, err := file.IsExplode(false); err == nil && explode {
+			return true
+		}
+	}
+	return false
+}
+
 func uploadCmd(c *cli.Context) error {
 	if c.NArg() > 0 && c.IsSet("spec") {
 		return cliutils.PrintHelpAndReturnError("No arguments should be sent when the spec option is used.", c)
@@ -1333,6 +1738,9 @@ func uploadCmd(c *cli.Context) error {
 	if !(c.NArg() == 2 || (c.NArg() == 0 && c.IsSet("spec"))) {
 		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
 	}
+	if c.NArg() == 2 && c.Args().Get(0) == transfer.StdioPath {
+		return streamUploadCmd(c)
+	}
 
 	var uploadSpec *spec.SpecFiles
 	var err error
@@ -1344,10 +1752,13 @@ func uploadCmd(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	err = spec.ValidateSpec(uploadSpec.Files, true, false, true)
+	err = transfer.ValidateUploadSpec(uploadSpec)
 	if err != nil {
 		return err
 	}
+	if err = validateUploadSidecarFlags(c, uploadSpec); err != nil {
+		return err
+	}
 	cliutils.FixWinPathsForFileSystemSourcedCmds(uploadSpec, c)
 	configuration, err := createUploadConfiguration(c)
 	if err != nil {
@@ -1370,85 +1781,324 @@ func uploadCmd(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	uploadCmd.SetUploadConfiguration(configuration).SetBuildConfiguration(buildConfiguration).SetSpec(uploadSpec).SetServerDetails(rtDetails).SetDryRun(c.Bool("dry-run")).SetSyncDeletesPath(c.String("sync-deletes")).SetQuiet(cliutils.GetQuietValue(c)).SetDetailedSummary(c.Bool("detailed-summary")).SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime)
+	summaryFormat, err := cliutils.GetSummaryFormat(c)
+	if err != nil {
+		return err
+	}
+	events, err := getEventsWriter(c, "upload")
+	if err != nil {
+		return err
+	}
+	limiter, err := getRateLimiter(c)
+	if err != nil {
+		return err
+	}
+	multipartConfiguration, err := createMultipartConfiguration(c)
+	if err != nil {
+		return err
+	}
+	if c.IsSet("plan-file") {
+		uploadPlanCommand := transfer.NewUploadCommand()
+		uploadPlanCommand.SetUploadConfiguration(configuration).SetSpec(uploadSpec).SetServerDetails(rtDetails).SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime)
+		return exportPlanCmd(c, uploadPlanCommand)
+	}
+	if c.Bool("watch") {
+		return watchUploadCmd(c, uploadSpec, configuration, multipartConfiguration, rtDetails, buildConfiguration, retries, retryWaitTime, events, summaryFormat, limiter)
+	}
+	// Large files are uploaded in parts by the transfer package, unless --min-split is -1.
+	multipartUpload := (c.IsSet("min-split") || c.IsSet("split-count") || c.IsSet("chunk-size")) && multipartConfiguration.MinSplitSize >= 0
+	if multipartUpload && c.IsSet("sync-deletes") {
+		return cliutils.PrintHelpAndReturnError("The --min-split, --split-count and --chunk-size options cannot be used together with --sync-deletes.", c)
+	}
+	if c.Bool("resume") || events != nil || (multipartUpload && !c.Bool("dry-run")) {
+		return transferUploadCmd(c, uploadSpec, configuration, multipartConfiguration, rtDetails, buildConfiguration, retries, retryWaitTime, events, summaryFormat, limiter)
+	}
+	// The tar archives are packed before the upload. In a dry run, the files which would be packed are listed instead.
+	if c.Bool("dry-run") {
+		uploadSpec = transfer.ToZipArchivesSpec(uploadSpec)
+	} else {
+		packedSpec, removeArchives, err := transfer.PackArchives(uploadSpec, configuration, rtDetails)
+		defer func() {
+			if e := removeArchives(); e != nil {
+				log.Warn("Failed removing the packed archives:", e.Error())
+			}
+		}()
+		if err != nil {
+			return err
+		}
+		uploadSpec = packedSpec
+	}
+	uploadCmd.SetUploadConfiguration(configuration).SetBuildConfiguration(buildConfiguration).SetSpec(uploadSpec).SetServerDetails(rtDetails).SetDryRun(c.Bool("dry-run")).SetSyncDeletesPath(c.String("sync-deletes")).SetQuiet(cliutils.GetQuietValue(c)).SetDetailedSummary(c.Bool("detailed-summary") || hasUploadSidecars(c)).SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime)
 
 	if uploadCmd.ShouldPrompt() && !coreutils.AskYesNo("Sync-deletes may delete some artifacts in Artifactory. Are you sure you want to continue?\n"+
 		"You can avoid this confirmation message by adding --quiet to the command.", false) {
 		return nil
 	}
 	// This error is being checked latter on because we need to generate sammery report before return.
-	err = progressbar.ExecWithProgress(uploadCmd)
+	err = progressbar.ExecWithProgressAndRateLimit(uploadCmd, limiter)
 	result := uploadCmd.Result()
-	err = cliutils.PrintDetailedSummaryReport(result.SuccessCount(), result.FailCount(), result.Reader(), true, isFailNoOp(c), err)
+	err = deployUploadSidecars(c, rtDetails, result, retries, retryWaitTime, err)
+	err = cliutils.PrintSummaryReportInFormat(summaryFormat, uploadCmd.CommandName(), result.SuccessCount(), result.FailCount(), getSummaryReader(c, result), true, isFailNoOp(c), err)
 
 	return cliutils.GetCliError(err, result.SuccessCount(), result.FailCount(), isFailNoOp(c))
 }
 
-func prepareCopyMoveCommand(c *cli.Context) (*spec.SpecFiles, error) {
-	if c.NArg() > 0 && c.IsSet("spec") {
-		return nil, cliutils.PrintHelpAndReturnError("No arguments should be sent when the spec option is used.", c)
+// Returns true if checksum files or signatures are deployed next to the uploaded files.
+func hasUploadSidecars(c *cli.Context) bool {
+	return c.IsSet("checksum-files") || c.IsSet("sign-key")
+}
+
+// Checksum files and signatures are created from the local files of the upload, so they can't be used with options which upload other content or upload later.
+// The signing key is read before the upload, so that an invalid key or passphrase fails the command before any file is uploaded.
+func validateUploadSidecarFlags(c *cli.Context, uploadSpec *spec.SpecFiles) error {
+	if !hasUploadSidecars(c) {
+		return nil
 	}
-	if !(c.NArg() == 2 || (c.NArg() == 0 && (c.IsSet("spec")))) {
-		return nil, cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
+	if c.IsSet("checksum-files") {
+		if err := transfer.ValidateChecksumFilesMode(c.String("checksum-files")); err != nil {
+			return err
+		}
+	}
+	if c.IsSet("sign-key") {
+		if _, err := createSigner(c); err != nil {
+			return err
+		}
+	}
+	for _, flag := range []string{"watch", "plan-file", "symlinks"} {
+		if c.IsSet(flag) {
+			return cliutils.PrintHelpAndReturnError("The --checksum-files and --sign-key options cannot be used together with --"+flag+".", c)
+		}
+	}
+	for _, file := range uploadSpec.Files {
+		if file.Archive != "" || file.Explode == "true" {
+			return cliutils.PrintHelpAndReturnError("The --checksum-files and --sign-key options cannot be used when uploading archives which are packed or extracted.", c)
+		}
 	}
+	return nil
+}
 
-	var copyMoveSpec *spec.SpecFiles
-	var err error
-	if c.IsSet("spec") {
-		copyMoveSpec, err = cliutils.GetSpec(c, false)
-	} else {
-		copyMoveSpec, err = createDefaultCopyMoveSpec(c)
+func createSigner(c *cli.Context) (*transfer.Signer, error) {
+	format := c.String("signature-format")
+	if format == "" {
+		format = transfer.SignatureArmored
 	}
-	if err != nil {
+	if err := transfer.ValidateSignatureFormat(format); err != nil {
 		return nil, err
 	}
-	err = spec.ValidateSpec(copyMoveSpec.Files, true, true, false)
-	if err != nil {
-		return nil, err
+	return transfer.NewSigner(c.String("sign-key"), c.String("sign-passphrase"), format)
+}
+
+// Deploys the checksum files and signatures of the uploaded files, if the --checksum-files or --sign-key options are set.
+// The sidecars of the files which were uploaded are deployed even if the upload of other files failed.
+func deployUploadSidecars(c *cli.Context, serverDetails *coreConfig.ServerDetails, result *commandsUtils.Result, retries, retryWaitTime int, uploadErr error) error {
+	if !hasUploadSidecars(c) || c.Bool("dry-run") {
+		return uploadErr
 	}
-	return copyMoveSpec, nil
+	var errs []error
+	if c.IsSet("checksum-files") {
+		errs = append(errs, transfer.DeployChecksumFiles(serverDetails, c.String("checksum-files"), result.Reader(), retries, retryWaitTime))
+	}
+	if c.IsSet("sign-key") {
+		signer, err := createSigner(c)
+		if err == nil {
+			err = transfer.DeploySignatures(serverDetails, signer, result.Reader(), retries, retryWaitTime)
+		}
+		errs = append(errs, err)
+	}
+	return getFirstError(uploadErr, errs...)
 }
 
-func moveCmd(c *cli.Context) error {
-	moveSpec, err := prepareCopyMoveCommand(c)
-	if err != nil {
-		return err
+// Verifies the signatures of the downloaded files, if the --verify-signature option is set.
+// The files whose signatures can't be verified are removed once they are downloaded.
+func verifyDownloadSignatures(c *cli.Context, serverDetails *coreConfig.ServerDetails, result *commandsUtils.Result, retries, retryWaitTime int, downloadErr error) error {
+	if !c.IsSet("verify-signature") || c.Bool("dry-run") {
+		return downloadErr
 	}
-	moveCmd := generic.NewMoveCommand()
-	rtDetails, err := createArtifactoryDetailsByFlags(c)
-	if err != nil {
-		return err
+	err := transfer.VerifyDownloadedSignatures(serverDetails, c.String("verify-signature"), result, retries, retryWaitTime)
+	return getFirstError(downloadErr, err)
+}
+
+// Returns the error of the command, or the first of the errors of the steps which followed it. The other errors are logged.
+func getFirstError(commandErr error, errs ...error) error {
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+		if commandErr == nil {
+			commandErr = err
+		} else {
+			log.Error(err.Error())
+		}
 	}
-	threads, err := cliutils.GetThreadsCount(c)
+	return commandErr
+}
+
+// The detailed summary of a command may be collected for options which process the transferred files, such as --checksum-files.
+// It's printed only if it was requested.
+func getSummaryReader(c *cli.Context, result *commandsUtils.Result) *content.ContentReader {
+	if c.Bool("detailed-summary") || result.Reader() == nil {
+		return result.Reader()
+	}
+	result.Reader().Close()
+	return nil
+}
+
+// Uploads the standard input to a single file in Artifactory.
+func streamUploadCmd(c *cli.Context) error {
+	for _, flag := range []string{"dry-run", "sync-deletes", "archive", "explode", "symlinks", "deb", "build-name", "build-number", "module", "watch", "resume", "events", "plan-file", "checksum-files", "sign-key"} {
+		if c.IsSet(flag) {
+			return cliutils.PrintHelpAndReturnError("The --"+flag+" option cannot be used when uploading from the standard input.", c)
+		}
+	}
+	size := int64(-1)
+	if c.IsSet("size") {
+		var err error
+		if size, err = strconv.ParseInt(c.String("size"), 10, 64); err != nil || size < 0 {
+			return cliutils.PrintHelpAndReturnError("The --size option should be a non-negative number of bytes.", c)
+		}
+	}
+	rtDetails, err := createArtifactoryDetailsByFlags(c)
 	if err != nil {
 		return err
 	}
-	retries, err := getRetries(c)
+	summaryFormat, err := cliutils.GetSummaryFormat(c)
 	if err != nil {
 		return err
 	}
-	retryWaitTime, err := getRetryWaitTime(c)
+	limiter, err := getRateLimiter(c)
 	if err != nil {
 		return err
 	}
-	moveCmd.SetThreads(threads).SetDryRun(c.Bool("dry-run")).SetServerDetails(rtDetails).SetSpec(moveSpec).SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime)
-	err = commands.Exec(moveCmd)
-	result := moveCmd.Result()
-	return printBriefSummaryAndGetError(result.SuccessCount(), result.FailCount(), isFailNoOp(c), err)
+	streamUploadCommand := transfer.NewStreamUploadCommand()
+	streamUploadCommand.SetServerDetails(rtDetails).SetReader(os.Stdin).SetTarget(c.Args().Get(1)).SetSize(size).SetTargetProps(c.String("target-props"))
+	// This error is being checked latter on because we need to generate summary report before return.
+	err = progressbar.ExecWithProgressAndRateLimit(streamUploadCommand, limiter)
+	result := streamUploadCommand.Result()
+	err = cliutils.PrintSummaryReportInFormat(summaryFormat, streamUploadCommand.CommandName(), result.SuccessCount(), result.FailCount(), result.Reader(), true, isFailNoOp(c), err)
+
+	return cliutils.GetCliError(err, result.SuccessCount(), result.FailCount(), isFailNoOp(c))
 }
 
-func copyCmd(c *cli.Context) error {
-	copySpec, err := prepareCopyMoveCommand(c)
-	if err != nil {
-		return err
+// Downloads a single file from Artifactory to the standard output.
+// The summary isn't printed, since the standard output includes only the content of the file.
+func streamDownloadCmd(c *cli.Context) error {
+	for _, flag := range []string{"spec", "build", "bundle", "dry-run", "sync-deletes", "explode", "cache", "resume", "events", "plan-file", "verify-signature"} {
+		if c.IsSet(flag) {
+			return cliutils.PrintHelpAndReturnError("The --"+flag+" option cannot be used when downloading to the standard output.", c)
+		}
 	}
-
-	copyCommand := generic.NewCopyCommand()
-	rtDetails, err := createArtifactoryDetailsByFlags(c)
+	serverDetails, err := createArtifactoryDetailsByFlags(c)
 	if err != nil {
 		return err
 	}
-	threads, err := cliutils.GetThreadsCount(c)
+	limiter, err := getRateLimiter(c)
+	if err != nil {
+		return err
+	}
+	streamDownloadCommand := transfer.NewStreamDownloadCommand()
+	streamDownloadCommand.SetServerDetails(serverDetails).SetSource(strings.TrimPrefix(c.Args().Get(0), "/")).SetWriter(os.Stdout)
+	err = progressbar.ExecWithProgressAndRateLimit(streamDownloadCommand, limiter)
+	result := streamDownloadCommand.Result()
+	return cliutils.GetCliError(err, result.SuccessCount(), result.FailCount(), isFailNoOp(c))
+}
+
+// Downloads the files one batch at a time using the transfer package, which allows resuming the download and reporting its events.
+func transferDownloadCmd(c *cli.Context, downloadSpec *spec.SpecFiles, configuration *utils.DownloadConfiguration, serverDetails *coreConfig.ServerDetails,
+	buildConfiguration *utils.BuildConfiguration, retries, retryWaitTime int, events *transfer.EventsWriter, summaryFormat summary.Format, limiter *ratelimit.Limiter,
+	downloadCache *cache.Cache) error {
+	if err := validateTransferFlags(c); err != nil {
+		return err
+	}
+	downloadCommand := transfer.NewDownloadCommand()
+	downloadCommand.SetConfiguration(configuration).SetBuildConfiguration(buildConfiguration).SetSpec(downloadSpec).SetSpecPath(c.String("spec")).SetServerDetails(serverDetails).
+		SetDetailedSummary(c.Bool("detailed-summary") || c.IsSet("verify-signature")).SetResume(c.Bool("resume")).SetEvents(events).SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime)
+	downloadCommand.SetCache(downloadCache)
+	if events != nil {
+		if limiter != nil {
+			downloadCommand.SetProgress(ratelimit.NewProgressMgr(nil, limiter))
+		}
+		err := commands.Exec(downloadCommand)
+		result := downloadCommand.Result()
+		err = verifyDownloadSignatures(c, serverDetails, result, retries, retryWaitTime, err)
+		if result.Reader() != nil {
+			result.Reader().Close()
+		}
+		return printEventsSummaryAndGetError(events, result.SuccessCount(), result.FailCount(), isFailNoOp(c), err)
+	}
+	// This error is being checked latter on because we need to generate summary report before return.
+	err := progressbar.ExecWithProgressAndRateLimit(downloadCommand, limiter)
+	result := downloadCommand.Result()
+	err = verifyDownloadSignatures(c, serverDetails, result, retries, retryWaitTime, err)
+	err = cliutils.PrintSummaryReportInFormat(summaryFormat, downloadCommand.CommandName(), result.SuccessCount(), result.FailCount(), getSummaryReader(c, result), false, isFailNoOp(c), err)
+
+	return cliutils.GetCliError(err, result.SuccessCount(), result.FailCount(), isFailNoOp(c))
+}
+
+// Uploads the files one batch at a time using the transfer package, which allows resuming the upload and reporting its events.
+func transferUploadCmd(c *cli.Context, uploadSpec *spec.SpecFiles, configuration *utils.UploadConfiguration, multipartConfiguration *transfer.MultipartConfiguration, serverDetails *coreConfig.ServerDetails,
+	buildConfiguration *utils.BuildConfiguration, retries, retryWaitTime int, events *transfer.EventsWriter, summaryFormat summary.Format, limiter *ratelimit.Limiter) error {
+	if err := validateTransferFlags(c); err != nil {
+		return err
+	}
+	uploadCommand := transfer.NewUploadCommand()
+	uploadCommand.SetUploadConfiguration(configuration).SetBuildConfiguration(buildConfiguration).SetSpec(uploadSpec).SetSpecPath(c.String("spec")).SetServerDetails(serverDetails).
+		SetDetailedSummary(c.Bool("detailed-summary") || hasUploadSidecars(c)).SetResume(c.Bool("resume")).SetEvents(events).SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime)
+	uploadCommand.SetMultipartConfiguration(multipartConfiguration)
+	if events != nil {
+		if limiter != nil {
+			uploadCommand.SetProgress(ratelimit.NewProgressMgr(nil, limiter))
+		}
+		err := commands.Exec(uploadCommand)
+		result := uploadCommand.Result()
+		err = deployUploadSidecars(c, serverDetails, result, retries, retryWaitTime, err)
+		if result.Reader() != nil {
+			result.Reader().Close()
+		}
+		return printEventsSummaryAndGetError(events, result.SuccessCount(), result.FailCount(), isFailNoOp(c), err)
+	}
+	// This error is being checked latter on because we need to generate summary report before return.
+	err := progressbar.ExecWithProgressAndRateLimit(uploadCommand, limiter)
+	result := uploadCommand.Result()
+	err = deployUploadSidecars(c, serverDetails, result, retries, retryWaitTime, err)
+	err = cliutils.PrintSummaryReportInFormat(summaryFormat, uploadCommand.CommandName(), result.SuccessCount(), result.FailCount(), getSummaryReader(c, result), true, isFailNoOp(c), err)
+
+	return cliutils.GetCliError(err, result.SuccessCount(), result.FailCount(), isFailNoOp(c))
+}
+
+// Writes the plan of a dry run to the path set by the --plan-file option, so that it can be applied later by the apply-plan command.
+func exportPlanCmd(c *cli.Context, planCreator transfer.PlanCreator) error {
+	if !c.Bool("dry-run") {
+		return cliutils.PrintHelpAndReturnError("The --plan-file option can only be used together with --dry-run.", c)
+	}
+	plan, err := planCreator.CreatePlan()
+	if err != nil {
+		return err
+	}
+	planPath := c.String("plan-file")
+	if err = plan.Save(planPath); err != nil {
+		return err
+	}
+	log.Info(fmt.Sprintf("The plan of %d files was written to %s. It can be applied by running 'jfrog rt apply-plan %s'.", len(plan.Items), planPath, planPath))
+	return nil
+}
+
+func applyPlanCmd(c *cli.Context) error {
+	if c.NArg() != 1 {
+		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
+	}
+	plan, err := transfer.LoadPlan(c.Args().Get(0))
+	if err != nil {
+		return err
+	}
+	rtDetails, err := createArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	uploadConfiguration, err := createUploadConfiguration(c)
+	if err != nil {
+		return err
+	}
+	downloadConfiguration, err := createDownloadConfiguration(c)
 	if err != nil {
 		return err
 	}
@@ -1460,18 +2110,252 @@ func copyCmd(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	copyCommand.SetThreads(threads).SetSpec(copySpec).SetDryRun(c.Bool("dry-run")).SetServerDetails(rtDetails).SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime)
-	err = commands.Exec(copyCommand)
-	result := copyCommand.Result()
+	applyPlanCommand := transfer.NewApplyPlanCommand()
+	applyPlanCommand.SetPlan(plan).SetServerDetails(rtDetails).SetUploadConfiguration(uploadConfiguration).SetDownloadConfiguration(downloadConfiguration).
+		SetThreads(uploadConfiguration.Threads).SetQuiet(cliutils.GetQuietValue(c)).SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime)
+	err = commands.Exec(applyPlanCommand)
+	result := applyPlanCommand.Result()
 	return printBriefSummaryAndGetError(result.SuccessCount(), result.FailCount(), isFailNoOp(c), err)
 }
 
+// Uploads the files and keeps uploading new or changed files until interrupted.
+func watchUploadCmd(c *cli.Context, uploadSpec *spec.SpecFiles, configuration *utils.UploadConfiguration, multipartConfiguration *transfer.MultipartConfiguration, serverDetails *coreConfig.ServerDetails,
+	buildConfiguration *utils.BuildConfiguration, retries, retryWaitTime int, events *transfer.EventsWriter, summaryFormat summary.Format, limiter *ratelimit.Limiter) error {
+	for _, flag := range []string{"dry-run", "sync-deletes", "detailed-summary"} {
+		if c.IsSet(flag) {
+			return cliutils.PrintHelpAndReturnError("The --watch option cannot be used together with --"+flag+".", c)
+		}
+	}
+	interval, err := cliutils.GetIntFlagValue(c, "watch-interval", 5)
+	if err != nil {
+		return err
+	}
+	if interval <= 0 {
+		return cliutils.PrintHelpAndReturnError("The --watch-interval option should be a positive number of seconds.", c)
+	}
+	uploadCommand := transfer.NewUploadCommand()
+	uploadCommand.SetUploadConfiguration(configuration).SetBuildConfiguration(buildConfiguration).SetSpec(uploadSpec).SetSpecPath(c.String("spec")).SetServerDetails(serverDetails).
+		SetResume(c.Bool("resume")).SetEvents(events).SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime)
+	uploadCommand.SetMultipartConfiguration(multipartConfiguration)
+	if limiter != nil {
+		uploadCommand.SetProgress(ratelimit.NewProgressMgr(nil, limiter))
+	}
+	watchCommand := transfer.NewWatchUploadCommand(uploadCommand).SetInterval(time.Duration(interval) * time.Second)
+	err = commands.Exec(watchCommand)
+	result := uploadCommand.Result()
+	if events != nil {
+		return printEventsSummaryAndGetError(events, result.SuccessCount(), result.FailCount(), isFailNoOp(c), err)
+	}
+	return printSummaryInFormatAndGetError(summaryFormat, uploadCommand.CommandName(), result.SuccessCount(), result.FailCount(), isFailNoOp(c), err)
+}
+
+func validateTransferFlags(c *cli.Context) error {
+	if c.Bool("dry-run") {
+		return cliutils.PrintHelpAndReturnError("The --resume and --events options cannot be used together with --dry-run.", c)
+	}
+	if c.IsSet("sync-deletes") {
+		return cliutils.PrintHelpAndReturnError("The --resume and --events options cannot be used together with --sync-deletes.", c)
+	}
+	return nil
+}
+
+// Returns a writer for the events of the command if the --events option is used, or nil otherwise.
+func getEventsWriter(c *cli.Context, command string) (*transfer.EventsWriter, error) {
+	if !c.IsSet("events") {
+		return nil, nil
+	}
+	if c.String("events") != transfer.EventsFormatNdjson {
+		return nil, cliutils.PrintHelpAndReturnError("The --events option accepts only the '"+transfer.EventsFormatNdjson+"' value.", c)
+	}
+	if c.IsSet("summary-format") {
+		return nil, cliutils.PrintHelpAndReturnError("The --events option cannot be used together with --summary-format, since the summary is printed as the last event.", c)
+	}
+	return transfer.NewEventsWriter(command), nil
+}
+
+// Prints the summary as the last event of the command and returns the appropriate exit error.
+func printEventsSummaryAndGetError(events *transfer.EventsWriter, succeeded, failed int, failNoOp bool, originalErr error) error {
+	events.Summary(succeeded, failed, failNoOp, originalErr)
+	return cliutils.GetCliError(originalErr, succeeded, failed, failNoOp)
+}
+
+func prepareCopyMoveCommand(c *cli.Context) (*spec.SpecFiles, error) {
+	if c.NArg() > 0 && c.IsSet("spec") {
+		return nil, cliutils.PrintHelpAndReturnError("No arguments should be sent when the spec option is used.", c)
+	}
+	if !(c.NArg() == 2 || (c.NArg() == 0 && (c.IsSet("spec")))) {
+		return nil, cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
+	}
+
+	var copyMoveSpec *spec.SpecFiles
+	var err error
+	if c.IsSet("spec") {
+		copyMoveSpec, err = cliutils.GetSpec(c, false)
+	} else {
+		copyMoveSpec, err = createDefaultCopyMoveSpec(c)
+	}
+	if err != nil {
+		return nil, err
+	}
+	err = spec.ValidateSpec(copyMoveSpec.Files, true, true, false)
+	if err != nil {
+		return nil, err
+	}
+	return copyMoveSpec, nil
+}
+
+func moveCmd(c *cli.Context) error {
+	moveSpec, err := prepareCopyMoveCommand(c)
+	if err != nil {
+		return err
+	}
+	rtDetails, err := createArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	threads, err := cliutils.GetThreadsCount(c)
+	if err != nil {
+		return err
+	}
+	retries, err := getRetries(c)
+	if err != nil {
+		return err
+	}
+	retryWaitTime, err := getRetryWaitTime(c)
+	if err != nil {
+		return err
+	}
+	summaryFormat, err := cliutils.GetSummaryFormat(c)
+	if err != nil {
+		return err
+	}
+	events, err := getEventsWriter(c, "move")
+	if err != nil {
+		return err
+	}
+	if c.IsSet("plan-file") {
+		movePlanCommand := transfer.NewMoveCommand()
+		movePlanCommand.SetServerDetails(rtDetails).SetSpec(moveSpec).SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime)
+		return exportPlanCmd(c, movePlanCommand)
+	}
+	useTransferCmd, err := shouldUseTransferRemoteCmd(c, events)
+	if err != nil {
+		return err
+	}
+	if !useTransferCmd {
+		moveCmd := generic.NewMoveCommand()
+		moveCmd.SetThreads(threads).SetDryRun(c.Bool("dry-run")).SetServerDetails(rtDetails).SetSpec(moveSpec).SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime)
+		return execGenericRemoteCmd(c, moveCmd, summaryFormat)
+	}
+	moveCmd := transfer.NewMoveCommand()
+	moveCmd.SetThreads(threads).SetDryRun(c.Bool("dry-run")).SetServerDetails(rtDetails).SetSpec(moveSpec).SetDetailedSummary(c.Bool("detailed-summary")).SetEvents(events).SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime)
+	return execRemoteCmd(c, moveCmd, events, summaryFormat)
+}
+
+func copyCmd(c *cli.Context) error {
+	copySpec, err := prepareCopyMoveCommand(c)
+	if err != nil {
+		return err
+	}
+
+	rtDetails, err := createArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	threads, err := cliutils.GetThreadsCount(c)
+	if err != nil {
+		return err
+	}
+	retries, err := getRetries(c)
+	if err != nil {
+		return err
+	}
+	retryWaitTime, err := getRetryWaitTime(c)
+	if err != nil {
+		return err
+	}
+	summaryFormat, err := cliutils.GetSummaryFormat(c)
+	if err != nil {
+		return err
+	}
+	events, err := getEventsWriter(c, "copy")
+	if err != nil {
+		return err
+	}
+	if c.IsSet("plan-file") {
+		copyPlanCommand := transfer.NewCopyCommand()
+		copyPlanCommand.SetServerDetails(rtDetails).SetSpec(copySpec).SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime)
+		return exportPlanCmd(c, copyPlanCommand)
+	}
+	useTransferCmd, err := shouldUseTransferRemoteCmd(c, events)
+	if err != nil {
+		return err
+	}
+	if !useTransferCmd {
+		copyCommand := generic.NewCopyCommand()
+		copyCommand.SetThreads(threads).SetSpec(copySpec).SetDryRun(c.Bool("dry-run")).SetServerDetails(rtDetails).SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime)
+		return execGenericRemoteCmd(c, copyCommand, summaryFormat)
+	}
+	copyCommand := transfer.NewCopyCommand()
+	copyCommand.SetThreads(threads).SetSpec(copySpec).SetDryRun(c.Bool("dry-run")).SetServerDetails(rtDetails).SetDetailedSummary(c.Bool("detailed-summary")).SetEvents(events).SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime)
+	return execRemoteCmd(c, copyCommand, events, summaryFormat)
+}
+
+// A command of the transfer package which operates on artifacts in Artifactory, such as copy, move and delete.
+type remoteCmd interface {
+	progressbar.CommandWithProgress
+	Result() *commandsUtils.Result
+}
+
+// Runs a copy, move, delete or properties command with a progress bar, and prints its summary.
+// If events are reported, the summary is printed as the last event instead.
+func execRemoteCmd(c *cli.Context, cmd remoteCmd, events *transfer.EventsWriter, summaryFormat summary.Format) error {
+	if events != nil {
+		err := commands.Exec(cmd)
+		result := cmd.Result()
+		return printEventsSummaryAndGetError(events, result.SuccessCount(), result.FailCount(), isFailNoOp(c), err)
+	}
+	// This error is being checked latter on because we need to generate summary report before return.
+	err := progressbar.ExecWithProgress(cmd)
+	result := cmd.Result()
+	err = cliutils.PrintSummaryReportInFormat(summaryFormat, cmd.CommandName(), result.SuccessCount(), result.FailCount(), result.Reader(), false, isFailNoOp(c), err)
+	return cliutils.GetCliError(err, result.SuccessCount(), result.FailCount(), isFailNoOp(c))
+}
+
+// The commands of the transfer package report each artifact they handle, which the commands of jfrog-cli-core don't.
+// They are therefore used only when the detailed summary, the events or the progress bar require these reports.
+func shouldUseTransferRemoteCmd(c *cli.Context, events *transfer.EventsWriter) (bool, error) {
+	if c.Bool("detailed-summary") || events != nil {
+		return true, nil
+	}
+	return progressbar.ShouldInitProgressBar()
+}
+
+// A copy, move, delete or properties command of jfrog-cli-core.
+type genericRemoteCmd interface {
+	commands.Command
+	Result() *commandsUtils.Result
+}
+
+// Runs a copy, move, delete or properties command of jfrog-cli-core, and prints its brief summary.
+func execGenericRemoteCmd(c *cli.Context, cmd genericRemoteCmd, summaryFormat summary.Format) error {
+	err := commands.Exec(cmd)
+	result := cmd.Result()
+	return printSummaryInFormatAndGetError(summaryFormat, cmd.CommandName(), result.SuccessCount(), result.FailCount(), isFailNoOp(c), err)
+}
+
 // Prints a 'brief' (not detailed) summary and returns the appropriate exit error.
 func printBriefSummaryAndGetError(succeeded, failed int, failNoOp bool, originalErr error) error {
 	err := cliutils.PrintBriefSummaryReport(succeeded, failed, failNoOp, originalErr)
 	return cliutils.GetCliError(err, succeeded, failed, failNoOp)
 }
 
+// Prints a 'brief' summary in the format set by the --summary-format option and returns the appropriate exit error.
+func printSummaryInFormatAndGetError(format summary.Format, commandName string, succeeded, failed int, failNoOp bool, originalErr error) error {
+	err := cliutils.PrintSummaryReportInFormat(format, commandName, succeeded, failed, nil, false, failNoOp, originalErr)
+	return cliutils.GetCliError(err, succeeded, failed, failNoOp)
+}
+
 func prepareDeleteCommand(c *cli.Context) (*spec.SpecFiles, error) {
 	if c.NArg() > 0 && c.IsSet("spec") {
 		return nil, cliutils.PrintHelpAndReturnError("No arguments should be sent when the spec option is used.", c)
@@ -1488,28 +2372,421 @@ func prepareDeleteCommand(c *cli.Context) (*spec.SpecFiles, error) {
 		deleteSpec, err = createDefaultDeleteSpec(c)
 	}
 	if err != nil {
-		return nil, err
+		return nil, err
+	}
+	err = spec.ValidateSpec(deleteSpec.Files, false, true, false)
+	if err != nil {
+		return nil, err
+	}
+	return deleteSpec, nil
+}
+
+func deleteCmd(c *cli.Context) error {
+	deleteSpec, err := prepareDeleteCommand(c)
+	if err != nil {
+		return err
+	}
+
+	rtDetails, err := createArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+
+	threads, err := cliutils.GetThreadsCount(c)
+	if err != nil {
+		return err
+	}
+	retries, err := getRetries(c)
+	if err != nil {
+		return err
+	}
+	retryWaitTime, err := getRetryWaitTime(c)
+	if err != nil {
+		return err
+	}
+	summaryFormat, err := cliutils.GetSummaryFormat(c)
+	if err != nil {
+		return err
+	}
+	events, err := getEventsWriter(c, "delete")
+	if err != nil {
+		return err
+	}
+	if c.IsSet("plan-file") {
+		deletePlanCommand := transfer.NewDeleteCommand()
+		deletePlanCommand.SetServerDetails(rtDetails).SetSpec(deleteSpec).SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime)
+		return exportPlanCmd(c, deletePlanCommand)
+	}
+	useTransferCmd, err := shouldUseTransferRemoteCmd(c, events)
+	if err != nil {
+		return err
+	}
+	if !useTransferCmd {
+		deleteCommand := generic.NewDeleteCommand()
+		deleteCommand.SetThreads(threads).SetQuiet(cliutils.GetQuietValue(c)).SetDryRun(c.Bool("dry-run")).SetServerDetails(rtDetails).SetSpec(deleteSpec).SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime)
+		return execGenericRemoteCmd(c, deleteCommand, summaryFormat)
+	}
+	deleteCommand := transfer.NewDeleteCommand()
+	deleteCommand.SetThreads(threads).SetDryRun(c.Bool("dry-run")).SetServerDetails(rtDetails).SetSpec(deleteSpec).SetDetailedSummary(c.Bool("detailed-summary")).SetEvents(events).SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime)
+	if !cliutils.GetQuietValue(c) {
+		// The paths are confirmed before the progress bar is displayed, so that the prompt isn't hidden by it.
+		pathsToDelete, err := deleteCommand.GetPathsToDelete()
+		if err != nil {
+			return err
+		}
+		defer pathsToDelete.Close()
+		allowDelete, err := utils.ConfirmDelete(pathsToDelete)
+		if err != nil || !allowDelete {
+			return err
+		}
+		deleteCommand.SetItems(pathsToDelete)
+	}
+	return execRemoteCmd(c, deleteCommand, events, summaryFormat)
+}
+
+// Returns true if the trash command is sent without any of the filters which select the artifacts it handles.
+func isTrashFiltersEmpty(c *cli.Context) bool {
+	return c.NArg() == 0 && !c.IsSet("spec") && !c.IsSet("build") && !c.IsSet("bundle")
+}
+
+func trashListCmd(c *cli.Context) error {
+	var trashSpec *spec.SpecFiles
+	var err error
+	if isTrashFiltersEmpty(c) {
+		trashSpec, err = createDefaultDeleteSpec(c)
+		if err == nil {
+			trashSpec.Get(0).Pattern = "*"
+		}
+	} else {
+		trashSpec, err = prepareDeleteCommand(c)
+	}
+	if err != nil {
+		return err
+	}
+	rtDetails, err := createArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	retries, err := getRetries(c)
+	if err != nil {
+		return err
+	}
+	retryWaitTime, err := getRetryWaitTime(c)
+	if err != nil {
+		return err
+	}
+	listCmd := transfer.NewTrashListCommand()
+	listCmd.SetServerDetails(rtDetails).SetSpec(trashSpec).SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime)
+	err = commands.Exec(listCmd)
+	return cliutils.GetCliError(err, listCmd.Count(), 0, isFailNoOp(c))
+}
+
+func trashRestoreCmd(c *cli.Context) error {
+	trashSpec, err := prepareDeleteCommand(c)
+	if err != nil {
+		return err
+	}
+	rtDetails, err := createArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	threads, err := cliutils.GetThreadsCount(c)
+	if err != nil {
+		return err
+	}
+	retries, err := getRetries(c)
+	if err != nil {
+		return err
+	}
+	retryWaitTime, err := getRetryWaitTime(c)
+	if err != nil {
+		return err
+	}
+	summaryFormat, err := cliutils.GetSummaryFormat(c)
+	if err != nil {
+		return err
+	}
+	restoreCmd := transfer.NewTrashRestoreCommand()
+	restoreCmd.SetThreads(threads).SetDryRun(c.Bool("dry-run")).SetServerDetails(rtDetails).SetSpec(trashSpec).SetDetailedSummary(c.Bool("detailed-summary")).SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime)
+	return execRemoteCmd(c, restoreCmd, nil, summaryFormat)
+}
+
+// Empties the entire trash can if no filters are sent, and otherwise deletes only the matching artifacts from it.
+func trashEmptyCmd(c *cli.Context) error {
+	rtDetails, err := createArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	emptyCmd := transfer.NewTrashEmptyCommand()
+	emptyCmd.SetDryRun(c.Bool("dry-run")).SetServerDetails(rtDetails)
+	if isTrashFiltersEmpty(c) {
+		if !cliutils.GetQuietValue(c) && !coreutils.AskYesNo("Are you sure you want to permanently delete all the artifacts in the trash can?", false) {
+			return nil
+		}
+		return commands.Exec(emptyCmd)
+	}
+	trashSpec, err := prepareDeleteCommand(c)
+	if err != nil {
+		return err
+	}
+	threads, err := cliutils.GetThreadsCount(c)
+	if err != nil {
+		return err
+	}
+	retries, err := getRetries(c)
+	if err != nil {
+		return err
+	}
+	retryWaitTime, err := getRetryWaitTime(c)
+	if err != nil {
+		return err
+	}
+	summaryFormat, err := cliutils.GetSummaryFormat(c)
+	if err != nil {
+		return err
+	}
+	emptyCmd.SetThreads(threads).SetQuiet(cliutils.GetQuietValue(c)).SetSpec(trashSpec).SetDetailedSummary(c.Bool("detailed-summary")).SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime)
+	return execRemoteCmd(c, emptyCmd, nil, summaryFormat)
+}
+
+func cleanupCmd(c *cli.Context) error {
+	if c.NArg() != 0 {
+		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
+	}
+	if c.String("policy") == "" {
+		return cliutils.PrintHelpAndReturnError("The --policy option is mandatory.", c)
+	}
+	policy, err := cleanup.LoadPolicy(c.String("policy"))
+	if err != nil {
+		return err
+	}
+	rtDetails, err := createArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	threads, err := cliutils.GetThreadsCount(c)
+	if err != nil {
+		return err
+	}
+	retries, err := getRetries(c)
+	if err != nil {
+		return err
+	}
+	retryWaitTime, err := getRetryWaitTime(c)
+	if err != nil {
+		return err
+	}
+	summaryFormat, err := cliutils.GetSummaryFormat(c)
+	if err != nil {
+		return err
+	}
+	cleanupCommand := cleanup.NewCleanupCommand()
+	cleanupCommand.SetServerDetails(rtDetails).SetPolicy(policy).SetThreads(threads).SetDryRun(c.Bool("dry-run")).
+		SetDetailedSummary(c.Bool("detailed-summary")).SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime)
+	// The report is printed and confirmed before the progress bar of the deletion is displayed.
+	count, err := cleanupCommand.Report()
+	if err != nil {
+		return err
+	}
+	if count > 0 && !c.Bool("dry-run") && !cliutils.GetQuietValue(c) &&
+		!coreutils.AskYesNo(fmt.Sprintf("Are you sure you want to delete the above %d artifacts?", count), false) {
+		return nil
+	}
+	return execRemoteCmd(c, cleanupCommand, nil, summaryFormat)
+}
+
+func syncCmd(c *cli.Context) error {
+	if c.NArg() != 2 {
+		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
+	}
+	conflictRule := transfer.NewerWins
+	if c.IsSet("conflict") {
+		conflictRule = transfer.ConflictRule(c.String("conflict"))
+		switch conflictRule {
+		case transfer.NewerWins, transfer.LocalWins, transfer.RemoteWins:
+		default:
+			return cliutils.PrintHelpAndReturnError("The --conflict option accepts one of the following values: "+strings.Join(transfer.ConflictRules, ", ")+".", c)
+		}
+	}
+	rtDetails, err := createArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	uploadConfiguration, err := createUploadConfiguration(c)
+	if err != nil {
+		return err
+	}
+	downloadConfiguration, err := createDownloadConfiguration(c)
+	if err != nil {
+		return err
+	}
+	retries, err := getRetries(c)
+	if err != nil {
+		return err
+	}
+	retryWaitTime, err := getRetryWaitTime(c)
+	if err != nil {
+		return err
+	}
+	syncCommand := transfer.NewSyncCommand()
+	syncCommand.SetLocalDir(c.Args().Get(0)).SetRemotePath(c.Args().Get(1)).SetConflictRule(conflictRule).SetServerDetails(rtDetails).
+		SetUploadConfiguration(uploadConfiguration).SetDownloadConfiguration(downloadConfiguration).SetThreads(uploadConfiguration.Threads).
+		SetDryRun(c.Bool("dry-run")).SetQuiet(cliutils.GetQuietValue(c)).SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime)
+	err = commands.Exec(syncCommand)
+	result := syncCommand.Result()
+	return printBriefSummaryAndGetError(result.SuccessCount(), result.FailCount(), isFailNoOp(c), err)
+}
+
+func prepareSearchCommand(c *cli.Context) (*spec.SpecFiles, error) {
+	if c.NArg() > 0 && c.IsSet("spec") {
+		return nil, cliutils.PrintHelpAndReturnError("No arguments should be sent when the spec option is used.", c)
+	}
+	if !(c.NArg() == 1 || (c.NArg() == 0 && (c.IsSet("spec") || c.IsSet("build") || c.IsSet("bundle")))) {
+		return nil, cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
+	}
+
+	var searchSpec *spec.SpecFiles
+	var err error
+	if c.IsSet("spec") {
+		searchSpec, err = cliutils.GetSpec(c, false)
+	} else {
+		searchSpec, err = createDefaultSearchSpec(c)
+	}
+	if err != nil {
+		return nil, err
+	}
+	err = spec.ValidateSpec(searchSpec.Files, false, true, false)
+	if err != nil {
+		return nil, err
+	}
+	return searchSpec, err
+}
+
+func lsCmd(c *cli.Context) error {
+	if c.NArg() != 1 {
+		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
+	}
+	depth, err := cliutils.GetIntFlagValue(c, "depth", 0)
+	if err != nil {
+		return err
+	}
+	if depth < 0 {
+		return cliutils.PrintHelpAndReturnError("The --depth option must be a positive number.", c)
+	}
+	rtDetails, err := createArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	lsCmd := listcommand.NewListCommand()
+	lsCmd.SetServerDetails(rtDetails).SetTarget(c.Args().Get(0)).SetRecursive(c.Bool("recursive") || depth > 0).SetDepth(depth).
+		SetHumanReadable(c.Bool("human-readable")).SetShowProps(c.Bool("show-props"))
+	err = commands.Exec(lsCmd)
+	return cliutils.GetCliError(err, lsCmd.Count(), 0, isFailNoOp(c))
+}
+
+func verifyCmd(c *cli.Context) error {
+	if c.NArg() > 0 && c.IsSet("spec") {
+		return cliutils.PrintHelpAndReturnError("No arguments should be sent when the spec option is used.", c)
+	}
+	if !(c.NArg() == 2 || (c.NArg() == 0 && c.IsSet("spec"))) {
+		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
+	}
+	var verifySpec *spec.SpecFiles
+	var err error
+	if c.IsSet("spec") {
+		verifySpec, err = cliutils.GetSpec(c, true)
+	} else {
+		// The local directory is the target to which the files are downloaded.
+		verifySpec = spec.NewBuilder().
+			Pattern(strings.TrimPrefix(c.Args().Get(1), "/")).
+			Props(c.String("props")).
+			ExcludeProps(c.String("exclude-props")).
+			Build(c.String("build")).
+			Bundle(c.String("bundle")).
+			Recursive(c.BoolT("recursive")).
+			Exclusions(cliutils.GetStringsArrFlagValue(c, "exclusions")).
+			Flat(c.Bool("flat")).
+			Target(clientutils.AddTrailingSlashIfNeeded(c.Args().Get(0))).
+			BuildSpec()
+	}
+	if err != nil {
+		return err
+	}
+	if err = spec.ValidateSpec(verifySpec.Files, false, true, false); err != nil {
+		return err
+	}
+	fixWinPathsForDownloadCmd(verifySpec, c)
+	threads, err := cliutils.GetThreadsCount(c)
+	if err != nil {
+		return err
+	}
+	rtDetails, err := createArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	verifyCmd := transfer.NewVerifyCommand()
+	verifyCmd.SetServerDetails(rtDetails).SetSpec(verifySpec).SetThreads(threads)
+	return commands.Exec(verifyCmd)
+}
+
+func bundleExportCmd(c *cli.Context) error {
+	if !(c.NArg() == 2 || (c.NArg() == 1 && c.IsSet("spec"))) {
+		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
+	}
+	var exportSpec *spec.SpecFiles
+	var err error
+	if c.IsSet("spec") {
+		exportSpec, err = cliutils.GetSpec(c, true)
+	} else {
+		exportSpec = spec.NewBuilder().
+			Pattern(strings.TrimPrefix(c.Args().Get(0), "/")).
+			Props(c.String("props")).
+			ExcludeProps(c.String("exclude-props")).
+			Build(c.String("build")).
+			Bundle(c.String("bundle")).
+			Recursive(c.BoolT("recursive")).
+			Exclusions(cliutils.GetStringsArrFlagValue(c, "exclusions")).
+			BuildSpec()
+	}
+	if err != nil {
+		return err
+	}
+	if err = spec.ValidateSpec(exportSpec.Files, false, true, false); err != nil {
+		return err
+	}
+	configuration, err := createDownloadConfiguration(c)
+	if err != nil {
+		return err
 	}
-	err = spec.ValidateSpec(deleteSpec.Files, false, true, false)
+	rtDetails, err := createArtifactoryDetailsByFlags(c)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	return deleteSpec, nil
-}
-
-func deleteCmd(c *cli.Context) error {
-	deleteSpec, err := prepareDeleteCommand(c)
+	retries, err := getRetries(c)
 	if err != nil {
 		return err
 	}
-
-	deleteCommand := generic.NewDeleteCommand()
-	rtDetails, err := createArtifactoryDetailsByFlags(c)
+	retryWaitTime, err := getRetryWaitTime(c)
 	if err != nil {
 		return err
 	}
+	exportCommand := transfer.NewBundleExportCommand()
+	exportCommand.SetServerDetails(rtDetails).SetSpec(exportSpec).SetConfiguration(configuration).SetArchivePath(c.Args().Get(c.NArg() - 1)).
+		SetIncludeBuilds(c.BoolT("include-builds")).SetProject(c.String("project")).SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime)
+	err = progressbar.ExecWithProgress(exportCommand)
+	result := exportCommand.Result()
+	return printBriefSummaryAndGetError(result.SuccessCount(), result.FailCount(), isFailNoOp(c), err)
+}
 
-	threads, err := cliutils.GetThreadsCount(c)
+func bundleImportCmd(c *cli.Context) error {
+	if c.NArg() != 1 {
+		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
+	}
+	configuration, err := createUploadConfiguration(c)
+	if err != nil {
+		return err
+	}
+	rtDetails, err := createArtifactoryDetailsByFlags(c)
 	if err != nil {
 		return err
 	}
@@ -1521,35 +2798,73 @@ func deleteCmd(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	deleteCommand.SetThreads(threads).SetQuiet(cliutils.GetQuietValue(c)).SetDryRun(c.Bool("dry-run")).SetServerDetails(rtDetails).SetSpec(deleteSpec).SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime)
-	err = commands.Exec(deleteCommand)
-	result := deleteCommand.Result()
+	importCommand := transfer.NewBundleImportCommand()
+	importCommand.SetServerDetails(rtDetails).SetConfiguration(configuration).SetArchivePath(c.Args().Get(0)).SetProject(c.String("project")).
+		SetDryRun(c.Bool("dry-run")).SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime)
+	err = progressbar.ExecWithProgress(importCommand)
+	result := importCommand.Result()
 	return printBriefSummaryAndGetError(result.SuccessCount(), result.FailCount(), isFailNoOp(c), err)
 }
 
-func prepareSearchCommand(c *cli.Context) (*spec.SpecFiles, error) {
+func duCmd(c *cli.Context) error {
 	if c.NArg() > 0 && c.IsSet("spec") {
-		return nil, cliutils.PrintHelpAndReturnError("No arguments should be sent when the spec option is used.", c)
+		return cliutils.PrintHelpAndReturnError("No arguments should be sent when the spec option is used.", c)
 	}
-	if !(c.NArg() == 1 || (c.NArg() == 0 && (c.IsSet("spec") || c.IsSet("build") || c.IsSet("bundle")))) {
-		return nil, cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
+	if !(c.NArg() == 1 || (c.NArg() == 0 && c.IsSet("spec"))) {
+		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
 	}
-
-	var searchSpec *spec.SpecFiles
+	var duSpec *spec.SpecFiles
 	var err error
 	if c.IsSet("spec") {
-		searchSpec, err = cliutils.GetSpec(c, false)
+		duSpec, err = cliutils.GetSpec(c, false)
 	} else {
-		searchSpec, err = createDefaultSearchSpec(c)
+		duSpec = spec.NewBuilder().
+			Pattern(c.Args().Get(0)).
+			Props(c.String("props")).
+			ExcludeProps(c.String("exclude-props")).
+			Recursive(c.BoolT("recursive")).
+			Exclusions(cliutils.GetStringsArrFlagValue(c, "exclusions")).
+			BuildSpec()
 	}
 	if err != nil {
-		return nil, err
+		return err
 	}
-	err = spec.ValidateSpec(searchSpec.Files, false, true, false)
+	if err = spec.ValidateSpec(duSpec.Files, false, true, false); err != nil {
+		return err
+	}
+	depth, err := cliutils.GetIntFlagValue(c, "depth", 1)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	return searchSpec, err
+	if depth < 0 {
+		return cliutils.PrintHelpAndReturnError("The --depth option must be zero or a positive number.", c)
+	}
+	sortBy, err := diskusage.ParseSortBy(c.String("sort-by"))
+	if err != nil {
+		return err
+	}
+	// Folders are sorted by path in ascending order, and by any other field in descending order, by default.
+	ascending := sortBy == diskusage.SortByPath
+	switch c.String("sort-order") {
+	case "":
+	case "asc":
+		ascending = true
+	case "desc":
+		ascending = false
+	default:
+		return cliutils.PrintHelpAndReturnError("The --sort-order option accepts 'asc' or 'desc'.", c)
+	}
+	format, err := diskusage.ParseFormat(c.String("format"))
+	if err != nil {
+		return err
+	}
+	rtDetails, err := createArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	duCmd := diskusage.NewDuCommand()
+	duCmd.SetServerDetails(rtDetails).SetSpec(duSpec).SetDepth(depth).SetSortBy(sortBy).SetAscending(ascending).SetFormat(format)
+	return commands.Exec(duCmd)
 }
 
 func searchCmd(c *cli.Context) error {
@@ -1569,6 +2884,9 @@ func searchCmd(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	if c.IsSet("format") || c.IsSet("fields") || c.IsSet("template") {
+		return formattedSearchCmd(c, searchSpec, artDetails, retries, retryWaitTime)
+	}
 	searchCmd := generic.NewSearchCommand()
 	searchCmd.SetServerDetails(artDetails).SetSpec(searchSpec).SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime)
 	err = commands.Exec(searchCmd)
@@ -1592,6 +2910,40 @@ func searchCmd(c *cli.Context) error {
 	return nil
 }
 
+// Prints the search results in the format set by the --format, --fields and --template options.
+func formattedSearchCmd(c *cli.Context, searchSpec *spec.SpecFiles, artDetails *coreConfig.ServerDetails, retries, retryWaitTime int) error {
+	if c.Bool("count") {
+		return cliutils.PrintHelpAndReturnError("The --count option cannot be used together with --format, --fields or --template.", c)
+	}
+	var printer *searchcommand.Printer
+	if c.IsSet("template") {
+		if c.IsSet("format") || c.IsSet("fields") {
+			return cliutils.PrintHelpAndReturnError("The --template option cannot be used together with --format or --fields.", c)
+		}
+		var err error
+		if printer, err = searchcommand.NewTemplatePrinter(c.String("template")); err != nil {
+			return err
+		}
+	} else {
+		format, err := searchcommand.ParseFormat(c.String("format"))
+		if err != nil {
+			return err
+		}
+		fields, err := searchcommand.ParseFields(c.String("fields"))
+		if err != nil {
+			return err
+		}
+		if format == searchcommand.Paths && len(fields) > 0 {
+			return cliutils.PrintHelpAndReturnError("The --fields option cannot be used together with --format=paths.", c)
+		}
+		printer = searchcommand.NewPrinter(format, fields)
+	}
+	searchCmd := searchcommand.NewSearchCommand()
+	searchCmd.SetServerDetails(artDetails).SetSpec(searchSpec).SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime).SetPrinter(printer)
+	err := commands.Exec(searchCmd)
+	return cliutils.GetCliError(err, searchCmd.Count(), 0, isFailNoOp(c))
+}
+
 func preparePropsCmd(c *cli.Context) (*generic.PropsCommand, error) {
 	if c.NArg() > 1 && c.IsSet("spec") {
 		return nil, cliutils.PrintHelpAndReturnError("Only the 'artifact properties' argument should be sent when the spec option is used.", c)
@@ -1639,43 +2991,51 @@ func preparePropsCmd(c *cli.Context) (*generic.PropsCommand, error) {
 }
 
 func setPropsCmd(c *cli.Context) error {
+	return propsCmd(c, transfer.NewSetPropsCommand(), func(cmd *generic.PropsCommand) genericRemoteCmd {
+		return generic.NewSetPropsCommand().SetPropsCommand(*cmd)
+	})
+}
+
+func deletePropsCmd(c *cli.Context) error {
+	return propsCmd(c, transfer.NewDeletePropsCommand(), func(cmd *generic.PropsCommand) genericRemoteCmd {
+		return generic.NewDeletePropsCommand().DeletePropsCommand(*cmd)
+	})
+}
+
+// Sets or deletes properties using the transfer package, which handles the artifacts one by one, so that each of them can be reported.
+// The generic properties command is created by newGenericCmd, and is used when the command of the transfer package isn't required.
+func propsCmd(c *cli.Context, propsCommand *transfer.PropsCommand, newGenericCmd func(cmd *generic.PropsCommand) genericRemoteCmd) error {
 	cmd, err := preparePropsCmd(c)
 	if err != nil {
 		return err
 	}
-	retries, err := getRetries(c)
+	rtDetails, err := cmd.ServerDetails()
 	if err != nil {
 		return err
 	}
-	retryWaitTime, err := getRetryWaitTime(c)
+	retries, err := getRetries(c)
 	if err != nil {
 		return err
 	}
-	propsCmd := generic.NewSetPropsCommand().SetPropsCommand(*cmd)
-	propsCmd.SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime)
-	err = commands.Exec(propsCmd)
-	result := propsCmd.Result()
-	return printBriefSummaryAndGetError(result.SuccessCount(), result.FailCount(), isFailNoOp(c), err)
-}
-
-func deletePropsCmd(c *cli.Context) error {
-	cmd, err := preparePropsCmd(c)
+	retryWaitTime, err := getRetryWaitTime(c)
 	if err != nil {
 		return err
 	}
-	retries, err := getRetries(c)
+	summaryFormat, err := cliutils.GetSummaryFormat(c)
 	if err != nil {
 		return err
 	}
-	retryWaitTime, err := getRetryWaitTime(c)
+	useTransferCmd, err := shouldUseTransferRemoteCmd(c, nil)
 	if err != nil {
 		return err
 	}
-	propsCmd := generic.NewDeletePropsCommand().DeletePropsCommand(*cmd)
-	propsCmd.SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime)
-	err = commands.Exec(propsCmd)
-	result := propsCmd.Result()
-	return printBriefSummaryAndGetError(result.SuccessCount(), result.FailCount(), isFailNoOp(c), err)
+	if !useTransferCmd {
+		cmd.SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime)
+		return execGenericRemoteCmd(c, newGenericCmd(cmd), summaryFormat)
+	}
+	propsCommand.SetProps(cmd.Props())
+	propsCommand.SetThreads(cmd.Threads()).SetSpec(cmd.Spec()).SetServerDetails(rtDetails).SetDetailedSummary(c.Bool("detailed-summary")).SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime)
+	return execRemoteCmd(c, propsCommand, nil, summaryFormat)
 }
 
 func buildPublishCmd(c *cli.Context) error {
@@ -1691,12 +3051,18 @@ func buildPublishCmd(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	buildPublishCmd := buildinfo.NewBuildPublishCommand().SetServerDetails(rtDetails).SetBuildConfiguration(buildConfiguration).SetConfig(buildInfoConfiguration).SetDetailedSummary(c.Bool("detailed-summary"))
+	summaryFormat, err := cliutils.GetSummaryFormat(c)
+	if err != nil {
+		return err
+	}
+	// The summary is always printed when a format is set, since CI systems expect a report to be created.
+	detailedSummary := c.Bool("detailed-summary") || summaryFormat != summary.Json
+	buildPublishCmd := buildinfo.NewBuildPublishCommand().SetServerDetails(rtDetails).SetBuildConfiguration(buildConfiguration).SetConfig(buildInfoConfiguration).SetDetailedSummary(detailedSummary)
 
 	err = commands.Exec(buildPublishCmd)
 	if buildPublishCmd.IsDetailedSummary() {
-		if summary := buildPublishCmd.GetSummary(); summary != nil {
-			return cliutils.PrintBuildInfoSummaryReport(summary.IsSucceeded(), summary.GetSha256(), err)
+		if buildInfoSummary := buildPublishCmd.GetSummary(); buildInfoSummary != nil {
+			return cliutils.PrintBuildInfoSummaryReportInFormat(summaryFormat, buildPublishCmd.CommandName(), buildInfoSummary.IsSucceeded(), buildInfoSummary.GetSha256(), err)
 		}
 	}
 	return err
@@ -2002,6 +3368,46 @@ func repoDeleteCmd(c *cli.Context) error {
 	return commands.Exec(repoDeleteCmd)
 }
 
+func repoListCmd(c *cli.Context) error {
+	if c.NArg() != 0 {
+		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
+	}
+	rtDetails, err := createArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	repoListCmd := repocommand.NewRepoListCommand()
+	repoListCmd.SetServerDetails(rtDetails).SetRepoType(c.String("type")).SetPackageType(c.String("package-type"))
+	return commands.Exec(repoListCmd)
+}
+
+func repoShowCmd(c *cli.Context) error {
+	if c.NArg() != 1 {
+		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
+	}
+	rtDetails, err := createArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	repoShowCmd := repocommand.NewRepoShowCommand()
+	repoShowCmd.SetServerDetails(rtDetails).SetRepoKey(c.Args().Get(0))
+	return commands.Exec(repoShowCmd)
+}
+
+func repoApplyCmd(c *cli.Context) error {
+	if c.NArg() != 1 {
+		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
+	}
+	rtDetails, err := createArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	repoApplyCmd := repocommand.NewRepoApplyCommand()
+	repoApplyCmd.SetServerDetails(rtDetails).SetTemplatesDir(c.Args().Get(0)).SetVars(c.String("vars")).SetDryRun(c.Bool("dry-run")).
+		SetQuiet(cliutils.GetQuietValue(c)).SetDeleteMissing(c.Bool("delete-missing"))
+	return commands.Exec(repoApplyCmd)
+}
+
 func replicationTemplateCmd(c *cli.Context) error {
 	if c.NArg() != 1 {
 		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
@@ -2037,6 +3443,48 @@ func replicationDeleteCmd(c *cli.Context) error {
 	return commands.Exec(replicationDeleteCmd)
 }
 
+func replicationListCmd(c *cli.Context) error {
+	if c.NArg() != 0 {
+		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
+	}
+	format, err := replicationcommand.ParseFormat(c.String("format"))
+	if err != nil {
+		return err
+	}
+	rtDetails, err := createArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	replicationListCmd := replicationcommand.NewReplicationListCommand()
+	replicationListCmd.SetServerDetails(rtDetails).SetFormat(format)
+	return commands.Exec(replicationListCmd)
+}
+
+func replicationStatusCmd(c *cli.Context) error {
+	if c.NArg() != 1 {
+		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
+	}
+	if c.IsSet("max-wait-minutes") && !c.Bool("run") {
+		return cliutils.PrintHelpAndReturnError("The --max-wait-minutes option can't be used without --run", c)
+	}
+	format, err := replicationcommand.ParseFormat(c.String("format"))
+	if err != nil {
+		return err
+	}
+	maxWaitMinutes, err := cliutils.GetIntFlagValue(c, "max-wait-minutes", 60)
+	if err != nil {
+		return err
+	}
+	rtDetails, err := createArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	replicationStatusCmd := replicationcommand.NewReplicationStatusCommand()
+	replicationStatusCmd.SetServerDetails(rtDetails).SetRepoKey(c.Args().Get(0)).SetFormat(format).SetRun(c.Bool("run")).
+		SetTimeout(time.Duration(maxWaitMinutes) * time.Minute)
+	return commands.Exec(replicationStatusCmd)
+}
+
 func permissionTargetTemplateCmd(c *cli.Context) error {
 	if c.NArg() != 1 {
 		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
@@ -2095,6 +3543,21 @@ func permissionTargetDeleteCmd(c *cli.Context) error {
 	return commands.Exec(permissionTargetDeleteCmd)
 }
 
+func permissionTargetExportCmd(c *cli.Context) error {
+	if c.NArg() != 1 {
+		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
+	}
+
+	rtDetails, err := createArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+
+	permissionTargetExportCmd := accesscommand.NewPermissionTargetExportCommand()
+	permissionTargetExportCmd.SetTargetDir(c.Args().Get(0)).SetServerDetails(rtDetails)
+	return commands.Exec(permissionTargetExportCmd)
+}
+
 func userCreateCmd(c *cli.Context) error {
 	if c.NArg() != 3 {
 		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
@@ -2234,7 +3697,8 @@ func groupCreateCmd(c *cli.Context) error {
 }
 
 func groupAddUsersCmd(c *cli.Context) error {
-	if c.NArg() != 2 {
+	csvFilePath := c.String("csv")
+	if (csvFilePath == "" && c.NArg() != 2) || (csvFilePath != "" && c.NArg() != 0) {
 		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
 	}
 
@@ -2243,10 +3707,34 @@ func groupAddUsersCmd(c *cli.Context) error {
 		return err
 	}
 
+	groupsUsers := map[string][]string{}
+	if csvFilePath != "" {
+		// If --csv <group memberships file path> provided, add the users of each group in the file.
+		if groupsUsers, err = accesscommand.ReadGroupMemberships(csvFilePath); err != nil {
+			return err
+		}
+	} else {
+		groupsUsers[c.Args().Get(0)] = strings.Split(c.Args().Get(1), ",")
+	}
+	groupsNames := make([]string, 0, len(groupsUsers))
+	for groupName := range groupsUsers {
+		groupsNames = append(groupsNames, groupName)
+	}
+	sort.Strings(groupsNames)
+
 	// Run command.
-	groupAddUsersCmd := usersmanagement.NewGroupUpdateCommand()
-	groupAddUsersCmd.SetName(c.Args().Get(0)).SetUsers(strings.Split(c.Args().Get(1), ",")).SetServerDetails(rtDetails)
-	return commands.Exec(groupAddUsersCmd)
+	for _, groupName := range groupsNames {
+		// A group without users is listed in the file, but there are no users to add to it.
+		if len(groupsUsers[groupName]) == 0 {
+			continue
+		}
+		groupAddUsersCmd := usersmanagement.NewGroupUpdateCommand()
+		groupAddUsersCmd.SetName(groupName).SetUsers(groupsUsers[groupName]).SetServerDetails(rtDetails)
+		if err = commands.Exec(groupAddUsersCmd); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func groupDeleteCmd(c *cli.Context) error {
@@ -2270,6 +3758,55 @@ func groupDeleteCmd(c *cli.Context) error {
 	return commands.Exec(groupDeleteCmd)
 }
 
+func usersExportCmd(c *cli.Context) error {
+	if c.NArg() != 1 {
+		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
+	}
+
+	rtDetails, err := createArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+
+	usersExportCmd := accesscommand.NewUsersExportCommand()
+	usersExportCmd.SetCsvPath(c.Args().Get(0)).SetServerDetails(rtDetails)
+	return commands.Exec(usersExportCmd)
+}
+
+func groupsExportCmd(c *cli.Context) error {
+	if c.NArg() != 1 {
+		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
+	}
+
+	rtDetails, err := createArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+
+	groupsExportCmd := accesscommand.NewGroupsExportCommand()
+	groupsExportCmd.SetCsvPath(c.Args().Get(0)).SetServerDetails(rtDetails)
+	return commands.Exec(groupsExportCmd)
+}
+
+func accessDiffCmd(c *cli.Context) error {
+	if c.NArg() != 0 {
+		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
+	}
+	if c.String("permission-targets-dir") == "" && c.String("users-csv") == "" && c.String("groups-csv") == "" {
+		return cliutils.PrintHelpAndReturnError("At least one of the --permission-targets-dir, --users-csv and --groups-csv options is required.", c)
+	}
+
+	rtDetails, err := createArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+
+	accessDiffCmd := accesscommand.NewAccessDiffCommand()
+	accessDiffCmd.SetPermissionTargetsDir(c.String("permission-targets-dir")).SetUsersCsvPath(c.String("users-csv")).SetGroupsCsvPath(c.String("groups-csv")).
+		SetVars(c.String("vars")).SetFailOnDrift(c.Bool("fail-on-drift")).SetServerDetails(rtDetails)
+	return commands.Exec(accessDiffCmd)
+}
+
 func accessTokenCreateCmd(c *cli.Context) error {
 	if c.NArg() > 1 {
 		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
@@ -2306,6 +3843,55 @@ func accessTokenCreateCmd(c *cli.Context) error {
 	return nil
 }
 
+func accessTokenListCmd(c *cli.Context) error {
+	if c.NArg() != 0 {
+		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
+	}
+	serverDetails, err := createArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	accessTokenListCmd := accesscommand.NewAccessTokenListCommand()
+	accessTokenListCmd.SetServerDetails(serverDetails).SetSubject(c.String("subject"))
+	return commands.Exec(accessTokenListCmd)
+}
+
+func accessTokenRevokeCmd(c *cli.Context) error {
+	if c.NArg() > 1 {
+		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
+	}
+	if (c.NArg() == 1) == c.IsSet("subject") {
+		return cliutils.PrintHelpAndReturnError("Either a token ID or the --subject option should be provided.", c)
+	}
+	if c.IsSet("subject") && c.String("subject") == "" {
+		return cliutils.PrintHelpAndReturnError("The --subject option can't be empty.", c)
+	}
+	serverDetails, err := createArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	accessTokenRevokeCmd := accesscommand.NewAccessTokenRevokeCommand()
+	accessTokenRevokeCmd.SetServerDetails(serverDetails).SetTokenId(c.Args().Get(0)).SetSubject(c.String("subject")).SetQuiet(cliutils.GetQuietValue(c))
+	err = commands.Exec(accessTokenRevokeCmd)
+	if accessTokenRevokeCmd.RevokedCount() > 0 {
+		log.Info("Revoked", accessTokenRevokeCmd.RevokedCount(), "access tokens.")
+	}
+	return err
+}
+
+func accessTokenRotateCmd(c *cli.Context) error {
+	if c.NArg() != 0 {
+		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
+	}
+	expiry, err := cliutils.GetIntFlagValue(c, "expiry", -1)
+	if err != nil {
+		return err
+	}
+	accessTokenRotateCmd := accesscommand.NewAccessTokenRotateCommand()
+	accessTokenRotateCmd.SetServerId(c.String("server-id")).SetExpiry(expiry).SetRefreshable(c.Bool("refreshable"))
+	return commands.Exec(accessTokenRotateCmd)
+}
+
 func getDebFlag(c *cli.Context) (deb string, err error) {
 	deb = c.String("deb")
 	slashesCount := strings.Count(deb, "/") - strings.Count(deb, "\\/")
@@ -2610,6 +4196,33 @@ func createUploadConfiguration(c *cli.Context) (uploadConfiguration *utils.Uploa
 	return
 }
 
+// Creates the settings of the multipart upload of large files. The flags set the sizes in MB.
+func createMultipartConfiguration(c *cli.Context) (*transfer.MultipartConfiguration, error) {
+	minSplit, err := cliutils.GetIntFlagValue(c, "min-split", cliutils.UploadMinSplitMb)
+	if err != nil {
+		return nil, err
+	}
+	splitCount, err := cliutils.GetIntFlagValue(c, "split-count", cliutils.UploadSplitCount)
+	if err != nil {
+		return nil, err
+	}
+	if splitCount <= 0 || splitCount > cliutils.UploadMaxSplitCount {
+		return nil, errors.New("the '--split-count' option value should be between 1 and " + strconv.Itoa(cliutils.UploadMaxSplitCount))
+	}
+	chunkSize, err := cliutils.GetIntFlagValue(c, "chunk-size", cliutils.UploadChunkSizeMb)
+	if err != nil {
+		return nil, err
+	}
+	if chunkSize <= 0 {
+		return nil, errors.New("the '--chunk-size' option should be a positive number of MB")
+	}
+	multipartConfiguration := &transfer.MultipartConfiguration{MinSplitSize: -1, SplitCount: splitCount, ChunkSize: int64(chunkSize) * transfer.SizeMiB}
+	if minSplit >= 0 {
+		multipartConfiguration.MinSplitSize = int64(minSplit) * transfer.SizeMiB
+	}
+	return multipartConfiguration, nil
+}
+
 func getOffsetAndLimitValues(c *cli.Context) (offset, limit int, err error) {
 	offset, err = cliutils.GetIntFlagValue(c, "offset", 0)
 	if err != nil {
@@ -0,0 +1,246 @@
+package access
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/permissiontarget"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jszwec/csvutil"
+)
+
+const (
+	permissionTargetsApi = "api/v2/security/permissions"
+	groupsApi            = "api/security/groups"
+)
+
+// A permission target, in the format of the templates used by the permission-target-create and permission-target-update commands.
+type PermissionTargetTemplate struct {
+	Name          string                                    `json:"name"`
+	Repo          *permissiontarget.PermissionSectionAnswer `json:"repo,omitempty"`
+	Build         *permissiontarget.PermissionSectionAnswer `json:"build,omitempty"`
+	ReleaseBundle *permissiontarget.PermissionSectionAnswer `json:"releaseBundle,omitempty"`
+}
+
+// A user, in the CSV format used by the users-create command.
+// The password isn't returned by Artifactory, and the groups of the users are exported separately as group memberships.
+type ExportedUser struct {
+	Name                     string `csv:"username"`
+	Email                    string `csv:"email"`
+	Admin                    *bool  `csv:"admin"`
+	ProfileUpdatable         *bool  `csv:"profileUpdatable"`
+	DisableUIAccess          *bool  `csv:"disableUIAccess"`
+	InternalPasswordDisabled *bool  `csv:"internalPasswordDisabled"`
+	Realm                    string `csv:"realm"`
+}
+
+// A membership of a user in a group. A group without users is exported with an empty username.
+type GroupMembership struct {
+	Group string `csv:"groupname"`
+	User  string `csv:"username"`
+}
+
+// Returns the names of the entities listed by an Artifactory API, which returns an array of objects with a name field.
+func getNames(servicesManager artifactory.ArtifactoryServicesManager, api string) ([]string, error) {
+	serviceDetails := servicesManager.GetConfig().GetServiceDetails()
+	httpClientsDetails := serviceDetails.CreateHttpClientDetails()
+	resp, body, _, err := servicesManager.Client().SendGet(serviceDetails.GetUrl()+api, true, &httpClientsDetails)
+	if err != nil {
+		return nil, err
+	}
+	if err = errorutils.CheckResponseStatus(resp, http.StatusOK); err != nil {
+		return nil, errorutils.CheckError(errorutils.GenerateResponseError(resp.Status, clientutils.IndentJson(body)))
+	}
+	var entities []struct {
+		Name string `json:"name"`
+	}
+	if err = json.Unmarshal(body, &entities); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	var names []string
+	for _, entity := range entities {
+		names = append(names, entity.Name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func getPermissionTargetTemplates(servicesManager artifactory.ArtifactoryServicesManager) (map[string]*PermissionTargetTemplate, error) {
+	names, err := getNames(servicesManager, permissionTargetsApi)
+	if err != nil {
+		return nil, err
+	}
+	templates := make(map[string]*PermissionTargetTemplate)
+	for _, name := range names {
+		params, err := servicesManager.GetPermissionTarget(name)
+		if err != nil {
+			return nil, err
+		}
+		// The permission target may have been deleted after it was listed.
+		if params != nil {
+			templates[name] = toPermissionTargetTemplate(params)
+		}
+	}
+	return templates, nil
+}
+
+func toPermissionTargetTemplate(params *services.PermissionTargetParams) *PermissionTargetTemplate {
+	return &PermissionTargetTemplate{
+		Name:          params.Name,
+		Repo:          toPermissionSectionAnswer(params.Repo),
+		Build:         toPermissionSectionAnswer(params.Build),
+		ReleaseBundle: toPermissionSectionAnswer(params.ReleaseBundle),
+	}
+}
+
+func toPermissionSectionAnswer(section *services.PermissionTargetSection) *permissiontarget.PermissionSectionAnswer {
+	if section == nil {
+		return nil
+	}
+	answer := &permissiontarget.PermissionSectionAnswer{
+		Repositories:    joinSorted(section.Repositories),
+		IncludePatterns: joinSorted(section.IncludePatterns),
+		ExcludePatterns: joinSorted(section.ExcludePatterns),
+	}
+	if section.Actions != nil {
+		answer.ActionsUsers = toActionsAnswer(section.Actions.Users)
+		answer.ActionsGroups = toActionsAnswer(section.Actions.Groups)
+	}
+	return answer
+}
+
+func toActionsAnswer(actions map[string][]string) map[string]string {
+	if len(actions) == 0 {
+		return nil
+	}
+	answer := make(map[string]string)
+	for name, permissions := range actions {
+		answer[name] = joinSorted(permissions)
+	}
+	return answer
+}
+
+// Joins a list of values with commas, in a sorted order, so that lists with the same values are always joined the same way.
+func joinSorted(values []string) string {
+	sorted := append([]string{}, values...)
+	sort.Strings(sorted)
+	return strings.Join(sorted, ",")
+}
+
+func getUsers(servicesManager artifactory.ArtifactoryServicesManager) (map[string]*services.User, error) {
+	// The users list doesn't include the details of the users, so each of them is fetched separately.
+	usersList, err := servicesManager.GetAllUsers()
+	if err != nil {
+		return nil, err
+	}
+	users := make(map[string]*services.User)
+	for _, listedUser := range usersList {
+		user, err := servicesManager.GetUser(services.UserParams{UserDetails: services.User{Name: listedUser.Name}})
+		if err != nil {
+			return nil, err
+		}
+		if user != nil {
+			users[user.Name] = user
+		}
+	}
+	return users, nil
+}
+
+func toExportedUser(user *services.User) ExportedUser {
+	return ExportedUser{
+		Name:                     user.Name,
+		Email:                    user.Email,
+		Admin:                    user.Admin,
+		ProfileUpdatable:         user.ProfileUpdatable,
+		DisableUIAccess:          user.DisableUIAccess,
+		InternalPasswordDisabled: user.InternalPasswordDisabled,
+		Realm:                    user.Realm,
+	}
+}
+
+func sortUsers(users []ExportedUser) {
+	sort.Slice(users, func(i, j int) bool {
+		return users[i].Name < users[j].Name
+	})
+}
+
+// Returns the users of each of the groups in Artifactory.
+func getGroupsUsers(servicesManager artifactory.ArtifactoryServicesManager) (map[string][]string, error) {
+	names, err := getNames(servicesManager, groupsApi)
+	if err != nil {
+		return nil, err
+	}
+	groupsUsers := make(map[string][]string)
+	for _, name := range names {
+		group, err := servicesManager.GetGroup(services.GroupParams{GroupDetails: services.Group{Name: name}, IncludeUsers: true})
+		if err != nil {
+			return nil, err
+		}
+		if group != nil {
+			groupsUsers[name] = group.UsersNames
+		}
+	}
+	return groupsUsers, nil
+}
+
+func toGroupMemberships(groupsUsers map[string][]string) []GroupMembership {
+	memberships := []GroupMembership{}
+	for group, users := range groupsUsers {
+		if len(users) == 0 {
+			memberships = append(memberships, GroupMembership{Group: group})
+		}
+		for _, user := range users {
+			memberships = append(memberships, GroupMembership{Group: group, User: user})
+		}
+	}
+	sort.Slice(memberships, func(i, j int) bool {
+		if memberships[i].Group != memberships[j].Group {
+			return memberships[i].Group < memberships[j].Group
+		}
+		return memberships[i].User < memberships[j].User
+	})
+	return memberships
+}
+
+func fromGroupMemberships(memberships []GroupMembership) map[string][]string {
+	groupsUsers := make(map[string][]string)
+	for _, membership := range memberships {
+		users := groupsUsers[membership.Group]
+		if membership.User != "" {
+			users = append(users, membership.User)
+		}
+		groupsUsers[membership.Group] = users
+	}
+	return groupsUsers
+}
+
+// Reads a group memberships CSV file, as written by the groups-export command, and returns the users of each group.
+func ReadGroupMemberships(csvPath string) (map[string][]string, error) {
+	var memberships []GroupMembership
+	if err := readCsv(csvPath, &memberships); err != nil {
+		return nil, err
+	}
+	return fromGroupMemberships(memberships), nil
+}
+
+func writeCsv(csvPath string, records interface{}) error {
+	content, err := csvutil.Marshal(records)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	return errorutils.CheckError(ioutil.WriteFile(csvPath, content, 0644))
+}
+
+func readCsv(csvPath string, records interface{}) error {
+	content, err := ioutil.ReadFile(csvPath)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	return errorutils.CheckError(csvutil.Unmarshal(content, records))
+}
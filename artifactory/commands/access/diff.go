@@ -0,0 +1,381 @@
+package access
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/permissiontarget"
+	commandsutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+type DriftType string
+
+const (
+	// Exists in the snapshot, but not in Artifactory.
+	Missing DriftType = "missing"
+	// Exists in Artifactory, but not in the snapshot.
+	Unexpected DriftType = "unexpected"
+	// Exists in both, with different values.
+	Changed DriftType = "changed"
+)
+
+var driftSymbols = map[DriftType]string{
+	Missing:    "-",
+	Unexpected: "+",
+	Changed:    "~",
+}
+
+const (
+	PermissionTargetKind = "permission target"
+	UserKind             = "user"
+	GroupKind            = "group"
+)
+
+const groupMemberValue = "member"
+
+// A difference in a single field, between its value in the snapshot and its value in Artifactory.
+type FieldDrift struct {
+	Field    string
+	Snapshot string
+	Server   string
+}
+
+// A difference between a permission target, a user or a group in the snapshot and in Artifactory.
+type Drift struct {
+	Type   DriftType
+	Kind   string
+	Name   string
+	Fields []FieldDrift
+}
+
+// Compares a snapshot of the permission targets, users and group memberships, as written by the export commands, with Artifactory.
+// Only the parts of the snapshot which are provided are compared.
+type AccessDiffCommand struct {
+	serverDetails        *config.ServerDetails
+	permissionTargetsDir string
+	usersCsvPath         string
+	groupsCsvPath        string
+	vars                 string
+	failOnDrift          bool
+	drifts               []Drift
+}
+
+func NewAccessDiffCommand() *AccessDiffCommand {
+	return &AccessDiffCommand{}
+}
+
+func (adc *AccessDiffCommand) SetServerDetails(serverDetails *config.ServerDetails) *AccessDiffCommand {
+	adc.serverDetails = serverDetails
+	return adc
+}
+
+// A directory of permission target templates, as written by the permission-target-export command.
+func (adc *AccessDiffCommand) SetPermissionTargetsDir(permissionTargetsDir string) *AccessDiffCommand {
+	adc.permissionTargetsDir = permissionTargetsDir
+	return adc
+}
+
+// A users CSV file, as written by the users-export command.
+func (adc *AccessDiffCommand) SetUsersCsvPath(usersCsvPath string) *AccessDiffCommand {
+	adc.usersCsvPath = usersCsvPath
+	return adc
+}
+
+// A group memberships CSV file, as written by the groups-export command.
+func (adc *AccessDiffCommand) SetGroupsCsvPath(groupsCsvPath string) *AccessDiffCommand {
+	adc.groupsCsvPath = groupsCsvPath
+	return adc
+}
+
+// Vars to replace in the permission target templates.
+func (adc *AccessDiffCommand) SetVars(vars string) *AccessDiffCommand {
+	adc.vars = vars
+	return adc
+}
+
+// Return an error if any difference is found.
+func (adc *AccessDiffCommand) SetFailOnDrift(failOnDrift bool) *AccessDiffCommand {
+	adc.failOnDrift = failOnDrift
+	return adc
+}
+
+func (adc *AccessDiffCommand) ServerDetails() (*config.ServerDetails, error) {
+	return adc.serverDetails, nil
+}
+
+func (adc *AccessDiffCommand) CommandName() string {
+	return "rt_access_diff"
+}
+
+// Returns the differences found by the last run of the command.
+func (adc *AccessDiffCommand) Drifts() []Drift {
+	return adc.drifts
+}
+
+func (adc *AccessDiffCommand) Run() error {
+	servicesManager, err := utils.CreateServiceManager(adc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	adc.drifts = nil
+	if adc.permissionTargetsDir != "" {
+		snapshot, err := adc.readPermissionTargetTemplates()
+		if err != nil {
+			return err
+		}
+		live, err := getPermissionTargetTemplates(servicesManager)
+		if err != nil {
+			return err
+		}
+		adc.drifts = append(adc.drifts, diffPermissionTargets(snapshot, live)...)
+	}
+	if adc.usersCsvPath != "" {
+		var snapshot []ExportedUser
+		if err = readCsv(adc.usersCsvPath, &snapshot); err != nil {
+			return err
+		}
+		live, err := getUsers(servicesManager)
+		if err != nil {
+			return err
+		}
+		adc.drifts = append(adc.drifts, diffUsers(snapshot, live)...)
+	}
+	if adc.groupsCsvPath != "" {
+		snapshot, err := ReadGroupMemberships(adc.groupsCsvPath)
+		if err != nil {
+			return err
+		}
+		live, err := getGroupsUsers(servicesManager)
+		if err != nil {
+			return err
+		}
+		adc.drifts = append(adc.drifts, diffGroups(snapshot, live)...)
+	}
+	printDrifts(adc.drifts)
+	if adc.failOnDrift && len(adc.drifts) > 0 {
+		return errorutils.CheckErrorf("found %d differences between the snapshot and Artifactory", len(adc.drifts))
+	}
+	return nil
+}
+
+// Reads the JSON templates in the permission targets directory, mapped by their permission target names.
+func (adc *AccessDiffCommand) readPermissionTargetTemplates() (map[string]*PermissionTargetTemplate, error) {
+	files, err := ioutil.ReadDir(adc.permissionTargetsDir)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	templates := make(map[string]*PermissionTargetTemplate)
+	for _, file := range files {
+		if file.IsDir() || strings.ToLower(filepath.Ext(file.Name())) != ".json" {
+			continue
+		}
+		templatePath := filepath.Join(adc.permissionTargetsDir, file.Name())
+		templateConfig, err := commandsutils.ConvertTemplateToMap(permissiontarget.NewPermissionTargetCreateCommand().SetTemplatePath(templatePath).SetVars(adc.vars))
+		if err != nil {
+			return nil, err
+		}
+		content, err := json.Marshal(templateConfig)
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		template := &PermissionTargetTemplate{}
+		if err = json.Unmarshal(content, template); err != nil {
+			return nil, errorutils.CheckErrorf("the template %s isn't a valid permission target template: %s", templatePath, err.Error())
+		}
+		if template.Name == "" {
+			return nil, errorutils.CheckErrorf("the template %s doesn't include a permission target name", templatePath)
+		}
+		if _, ok := templates[template.Name]; ok {
+			return nil, errorutils.CheckErrorf("more than one template is of the permission target: %s", template.Name)
+		}
+		templates[template.Name] = template
+	}
+	return templates, nil
+}
+
+func diffPermissionTargets(snapshot, live map[string]*PermissionTargetTemplate) []Drift {
+	var drifts []Drift
+	for name, template := range snapshot {
+		liveTemplate, ok := live[name]
+		if !ok {
+			drifts = append(drifts, Drift{Type: Missing, Kind: PermissionTargetKind, Name: name})
+			continue
+		}
+		if fields := diffFields(flattenPermissionTarget(template), flattenPermissionTarget(liveTemplate), false); len(fields) > 0 {
+			drifts = append(drifts, Drift{Type: Changed, Kind: PermissionTargetKind, Name: name, Fields: fields})
+		}
+	}
+	for name := range live {
+		if _, ok := snapshot[name]; !ok {
+			drifts = append(drifts, Drift{Type: Unexpected, Kind: PermissionTargetKind, Name: name})
+		}
+	}
+	sortDrifts(drifts)
+	return drifts
+}
+
+// Returns the fields of a permission target, mapped by their paths in the template.
+// Lists are sorted, so that the order of the values in the template doesn't matter.
+func flattenPermissionTarget(template *PermissionTargetTemplate) map[string]string {
+	fields := make(map[string]string)
+	flattenPermissionSection(permissiontarget.Repo, template.Repo, fields)
+	flattenPermissionSection(permissiontarget.Build, template.Build, fields)
+	flattenPermissionSection(permissiontarget.ReleaseBundle, template.ReleaseBundle, fields)
+	return fields
+}
+
+func flattenPermissionSection(sectionName string, section *permissiontarget.PermissionSectionAnswer, fields map[string]string) {
+	if section == nil {
+		return
+	}
+	// The repositories of the build section are always set to the default value by the permission-target-create command.
+	if sectionName != permissiontarget.Build {
+		fields[sectionName+".repositories"] = normalizeList(section.Repositories)
+	}
+	fields[sectionName+".include-patterns"] = normalizeList(section.IncludePatterns)
+	fields[sectionName+".exclude-patterns"] = normalizeList(section.ExcludePatterns)
+	for user, actions := range section.ActionsUsers {
+		fields[sectionName+".actions-users."+user] = normalizeList(actions)
+	}
+	for group, actions := range section.ActionsGroups {
+		fields[sectionName+".actions-groups."+group] = normalizeList(actions)
+	}
+}
+
+func normalizeList(list string) string {
+	if list == "" {
+		return ""
+	}
+	values := strings.Split(list, ",")
+	for i := range values {
+		values[i] = strings.TrimSpace(values[i])
+	}
+	return joinSorted(values)
+}
+
+// Compares the users in the snapshot with the users in Artifactory.
+// Empty values in the snapshot aren't compared, since the users-create command doesn't set them either.
+func diffUsers(snapshot []ExportedUser, live map[string]*services.User) []Drift {
+	var drifts []Drift
+	snapshotNames := make(map[string]bool)
+	for _, user := range snapshot {
+		snapshotNames[user.Name] = true
+		liveUser, ok := live[user.Name]
+		if !ok {
+			drifts = append(drifts, Drift{Type: Missing, Kind: UserKind, Name: user.Name})
+			continue
+		}
+		if fields := diffFields(flattenUser(user), flattenUser(toExportedUser(liveUser)), true); len(fields) > 0 {
+			drifts = append(drifts, Drift{Type: Changed, Kind: UserKind, Name: user.Name, Fields: fields})
+		}
+	}
+	for name := range live {
+		if !snapshotNames[name] {
+			drifts = append(drifts, Drift{Type: Unexpected, Kind: UserKind, Name: name})
+		}
+	}
+	sortDrifts(drifts)
+	return drifts
+}
+
+func flattenUser(user ExportedUser) map[string]string {
+	fields := map[string]string{
+		"email": user.Email,
+		"realm": user.Realm,
+	}
+	for field, value := range map[string]*bool{
+		"admin":                    user.Admin,
+		"profileUpdatable":         user.ProfileUpdatable,
+		"disableUIAccess":          user.DisableUIAccess,
+		"internalPasswordDisabled": user.InternalPasswordDisabled,
+	} {
+		if value != nil {
+			fields[field] = strconv.FormatBool(*value)
+		}
+	}
+	return fields
+}
+
+func diffGroups(snapshot, live map[string][]string) []Drift {
+	var drifts []Drift
+	for name, users := range snapshot {
+		liveUsers, ok := live[name]
+		if !ok {
+			drifts = append(drifts, Drift{Type: Missing, Kind: GroupKind, Name: name})
+			continue
+		}
+		if fields := diffFields(flattenGroupUsers(users), flattenGroupUsers(liveUsers), false); len(fields) > 0 {
+			drifts = append(drifts, Drift{Type: Changed, Kind: GroupKind, Name: name, Fields: fields})
+		}
+	}
+	for name := range live {
+		if _, ok := snapshot[name]; !ok {
+			drifts = append(drifts, Drift{Type: Unexpected, Kind: GroupKind, Name: name})
+		}
+	}
+	sortDrifts(drifts)
+	return drifts
+}
+
+func flattenGroupUsers(users []string) map[string]string {
+	fields := make(map[string]string)
+	for _, user := range users {
+		fields["users."+user] = groupMemberValue
+	}
+	return fields
+}
+
+// Returns the fields with different values in the snapshot and in Artifactory, sorted by their names.
+// If skipEmpty is set, fields without a value in the snapshot aren't compared.
+func diffFields(snapshot, live map[string]string, skipEmpty bool) []FieldDrift {
+	var fields []FieldDrift
+	for field, value := range snapshot {
+		if skipEmpty && value == "" {
+			continue
+		}
+		if value != live[field] {
+			fields = append(fields, FieldDrift{Field: field, Snapshot: value, Server: live[field]})
+		}
+	}
+	if !skipEmpty {
+		for field, value := range live {
+			if _, ok := snapshot[field]; !ok && value != "" {
+				fields = append(fields, FieldDrift{Field: field, Server: value})
+			}
+		}
+	}
+	sort.Slice(fields, func(i, j int) bool {
+		return fields[i].Field < fields[j].Field
+	})
+	return fields
+}
+
+func sortDrifts(drifts []Drift) {
+	sort.Slice(drifts, func(i, j int) bool {
+		return drifts[i].Name < drifts[j].Name
+	})
+}
+
+func printDrifts(drifts []Drift) {
+	counts := make(map[DriftType]int)
+	for _, drift := range drifts {
+		counts[drift.Type]++
+	}
+	log.Output(fmt.Sprintf("Drift: %d missing from Artifactory, %d not in the snapshot, %d changed.", counts[Missing], counts[Unexpected], counts[Changed]))
+	for _, drift := range drifts {
+		log.Output(fmt.Sprintf("  %s %s %s", driftSymbols[drift.Type], drift.Kind, drift.Name))
+		for _, field := range drift.Fields {
+			log.Output(fmt.Sprintf("      %s: %q => %q", field.Field, field.Snapshot, field.Server))
+		}
+	}
+}
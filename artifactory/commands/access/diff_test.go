@@ -0,0 +1,129 @@
+package access
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/permissiontarget"
+	coretests "github.com/jfrog/jfrog-cli-core/v2/utils/tests"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToPermissionTargetTemplate(t *testing.T) {
+	params := &services.PermissionTargetParams{
+		Name: "readers",
+		Repo: &services.PermissionTargetSection{
+			Repositories:    []string{"repo-b", "repo-a"},
+			IncludePatterns: []string{"**"},
+			Actions:         &services.Actions{Users: map[string][]string{"bob": {"write", "read"}}},
+		},
+	}
+	expected := &PermissionTargetTemplate{
+		Name: "readers",
+		Repo: &permissiontarget.PermissionSectionAnswer{
+			Repositories:    "repo-a,repo-b",
+			IncludePatterns: "**",
+			ActionsUsers:    map[string]string{"bob": "read,write"},
+		},
+	}
+	assert.Equal(t, expected, toPermissionTargetTemplate(params))
+}
+
+func TestDiffPermissionTargets(t *testing.T) {
+	snapshot := map[string]*PermissionTargetTemplate{
+		"readers": {Name: "readers", Repo: &permissiontarget.PermissionSectionAnswer{
+			Repositories: "repo-b, repo-a", IncludePatterns: "**", ActionsUsers: map[string]string{"bob": "read"},
+		}},
+		"deployers": {Name: "deployers", Build: &permissiontarget.PermissionSectionAnswer{Repositories: "any", IncludePatterns: "**"}},
+		"removed":   {Name: "removed"},
+	}
+	live := map[string]*PermissionTargetTemplate{
+		"readers": {Name: "readers", Repo: &permissiontarget.PermissionSectionAnswer{
+			Repositories: "repo-a,repo-b", IncludePatterns: "**", ActionsUsers: map[string]string{"bob": "read,write"}, ActionsGroups: map[string]string{"dev": "read"},
+		}},
+		"deployers": {Name: "deployers", Build: &permissiontarget.PermissionSectionAnswer{Repositories: permissiontarget.DefaultBuildRepositoriesValue, IncludePatterns: "**"}},
+		"added":     {Name: "added"},
+	}
+	expected := []Drift{
+		{Type: Unexpected, Kind: PermissionTargetKind, Name: "added"},
+		{Type: Changed, Kind: PermissionTargetKind, Name: "readers", Fields: []FieldDrift{
+			{Field: "repo.actions-groups.dev", Server: "read"},
+			{Field: "repo.actions-users.bob", Snapshot: "read", Server: "read,write"},
+		}},
+		{Type: Missing, Kind: PermissionTargetKind, Name: "removed"},
+	}
+	assert.Equal(t, expected, diffPermissionTargets(snapshot, live))
+}
+
+func TestDiffUsers(t *testing.T) {
+	admin, notAdmin := true, false
+	snapshot := []ExportedUser{
+		{Name: "alice", Email: "alice@example.com", Admin: &notAdmin},
+		// Values which aren't set in the snapshot aren't compared.
+		{Name: "bob", Realm: "internal"},
+		{Name: "carol"},
+	}
+	live := map[string]*services.User{
+		"alice": {Name: "alice", Email: "alice@example.com", Admin: &admin, Realm: "internal"},
+		"bob":   {Name: "bob", Email: "bob@example.com", Admin: &admin, Realm: "internal"},
+		"dave":  {Name: "dave"},
+	}
+	expected := []Drift{
+		{Type: Changed, Kind: UserKind, Name: "alice", Fields: []FieldDrift{{Field: "admin", Snapshot: "false", Server: "true"}}},
+		{Type: Missing, Kind: UserKind, Name: "carol"},
+		{Type: Unexpected, Kind: UserKind, Name: "dave"},
+	}
+	assert.Equal(t, expected, diffUsers(snapshot, live))
+}
+
+func TestDiffGroups(t *testing.T) {
+	snapshot := fromGroupMemberships([]GroupMembership{
+		{Group: "dev", User: "alice"},
+		{Group: "dev", User: "bob"},
+		{Group: "empty"},
+		{Group: "ops", User: "carol"},
+	})
+	live := map[string][]string{
+		"dev":   {"bob", "dave"},
+		"empty": nil,
+		"qa":    {"erin"},
+	}
+	expected := []Drift{
+		{Type: Changed, Kind: GroupKind, Name: "dev", Fields: []FieldDrift{
+			{Field: "users.alice", Snapshot: groupMemberValue},
+			{Field: "users.dave", Server: groupMemberValue},
+		}},
+		{Type: Missing, Kind: GroupKind, Name: "ops"},
+		{Type: Unexpected, Kind: GroupKind, Name: "qa"},
+	}
+	assert.Equal(t, expected, diffGroups(snapshot, live))
+}
+
+func TestCsvRoundTrip(t *testing.T) {
+	tempDir, cleanUp := coretests.CreateTempDirWithCallbackAndAssert(t)
+	defer cleanUp()
+	admin := true
+
+	usersCsvPath := filepath.Join(tempDir, "users.csv")
+	users := []ExportedUser{{Name: "alice", Email: "alice@example.com", Admin: &admin, Realm: "internal"}, {Name: "bob"}}
+	assert.NoError(t, writeCsv(usersCsvPath, users))
+	content, err := ioutil.ReadFile(usersCsvPath)
+	assert.NoError(t, err)
+	assert.Equal(t, "username,email,admin,profileUpdatable,disableUIAccess,internalPasswordDisabled,realm\n"+
+		"alice,alice@example.com,true,,,,internal\nbob,,,,,,\n", string(content))
+	var readUsers []ExportedUser
+	assert.NoError(t, readCsv(usersCsvPath, &readUsers))
+	assert.Equal(t, users, readUsers)
+
+	groupsCsvPath := filepath.Join(tempDir, "groups.csv")
+	groupsUsers := map[string][]string{"dev": {"bob", "alice"}, "empty": nil}
+	assert.NoError(t, writeCsv(groupsCsvPath, toGroupMemberships(groupsUsers)))
+	content, err = ioutil.ReadFile(groupsCsvPath)
+	assert.NoError(t, err)
+	assert.Equal(t, "groupname,username\ndev,alice\ndev,bob\nempty,\n", string(content))
+	readGroupsUsers, err := ReadGroupMemberships(groupsCsvPath)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]string{"dev": {"alice", "bob"}, "empty": nil}, readGroupsUsers)
+}
@@ -0,0 +1,161 @@
+package access
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Writes a template file for each of the permission targets in Artifactory.
+// The templates can be used by the permission-target-create and permission-target-update commands.
+type PermissionTargetExportCommand struct {
+	serverDetails *config.ServerDetails
+	targetDir     string
+}
+
+func NewPermissionTargetExportCommand() *PermissionTargetExportCommand {
+	return &PermissionTargetExportCommand{}
+}
+
+func (pec *PermissionTargetExportCommand) SetServerDetails(serverDetails *config.ServerDetails) *PermissionTargetExportCommand {
+	pec.serverDetails = serverDetails
+	return pec
+}
+
+func (pec *PermissionTargetExportCommand) SetTargetDir(targetDir string) *PermissionTargetExportCommand {
+	pec.targetDir = targetDir
+	return pec
+}
+
+func (pec *PermissionTargetExportCommand) ServerDetails() (*config.ServerDetails, error) {
+	return pec.serverDetails, nil
+}
+
+func (pec *PermissionTargetExportCommand) CommandName() string {
+	return "rt_permission_target_export"
+}
+
+func (pec *PermissionTargetExportCommand) Run() error {
+	servicesManager, err := utils.CreateServiceManager(pec.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	templates, err := getPermissionTargetTemplates(servicesManager)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(pec.targetDir, 0755); err != nil {
+		return errorutils.CheckError(err)
+	}
+	for name, template := range templates {
+		content, err := json.MarshalIndent(template, "", "  ")
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		if err = ioutil.WriteFile(filepath.Join(pec.targetDir, name+".json"), content, 0644); err != nil {
+			return errorutils.CheckError(err)
+		}
+	}
+	log.Info("Exported", len(templates), "permission targets to", pec.targetDir)
+	return nil
+}
+
+// Writes the users in Artifactory to a CSV file, which can be used by the users-create command.
+type UsersExportCommand struct {
+	serverDetails *config.ServerDetails
+	csvPath       string
+}
+
+func NewUsersExportCommand() *UsersExportCommand {
+	return &UsersExportCommand{}
+}
+
+func (uec *UsersExportCommand) SetServerDetails(serverDetails *config.ServerDetails) *UsersExportCommand {
+	uec.serverDetails = serverDetails
+	return uec
+}
+
+func (uec *UsersExportCommand) SetCsvPath(csvPath string) *UsersExportCommand {
+	uec.csvPath = csvPath
+	return uec
+}
+
+func (uec *UsersExportCommand) ServerDetails() (*config.ServerDetails, error) {
+	return uec.serverDetails, nil
+}
+
+func (uec *UsersExportCommand) CommandName() string {
+	return "rt_users_export"
+}
+
+func (uec *UsersExportCommand) Run() error {
+	servicesManager, err := utils.CreateServiceManager(uec.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	users, err := getUsers(servicesManager)
+	if err != nil {
+		return err
+	}
+	exportedUsers := []ExportedUser{}
+	for _, user := range users {
+		exportedUsers = append(exportedUsers, toExportedUser(user))
+	}
+	sortUsers(exportedUsers)
+	if err = writeCsv(uec.csvPath, exportedUsers); err != nil {
+		return err
+	}
+	log.Info("Exported", len(exportedUsers), "users to", uec.csvPath)
+	return nil
+}
+
+// Writes the memberships of users in the groups in Artifactory to a CSV file, with a line for each user of each group.
+// The file can be used by the group-add-users command.
+type GroupsExportCommand struct {
+	serverDetails *config.ServerDetails
+	csvPath       string
+}
+
+func NewGroupsExportCommand() *GroupsExportCommand {
+	return &GroupsExportCommand{}
+}
+
+func (gec *GroupsExportCommand) SetServerDetails(serverDetails *config.ServerDetails) *GroupsExportCommand {
+	gec.serverDetails = serverDetails
+	return gec
+}
+
+func (gec *GroupsExportCommand) SetCsvPath(csvPath string) *GroupsExportCommand {
+	gec.csvPath = csvPath
+	return gec
+}
+
+func (gec *GroupsExportCommand) ServerDetails() (*config.ServerDetails, error) {
+	return gec.serverDetails, nil
+}
+
+func (gec *GroupsExportCommand) CommandName() string {
+	return "rt_groups_export"
+}
+
+func (gec *GroupsExportCommand) Run() error {
+	servicesManager, err := utils.CreateServiceManager(gec.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	groupsUsers, err := getGroupsUsers(servicesManager)
+	if err != nil {
+		return err
+	}
+	if err = writeCsv(gec.csvPath, toGroupMemberships(groupsUsers)); err != nil {
+		return err
+	}
+	log.Info("Exported", len(groupsUsers), "groups to", gec.csvPath)
+	return nil
+}
@@ -0,0 +1,358 @@
+package access
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli/utils/output"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// An access token, as printed by the access-token-list command.
+type TokenListItem struct {
+	TokenId     string `json:"tokenId"`
+	Subject     string `json:"subject"`
+	Issuer      string `json:"issuer,omitempty"`
+	IssuedAt    string `json:"issuedAt,omitempty"`
+	Expiry      string `json:"expiry,omitempty"`
+	Refreshable bool   `json:"refreshable"`
+}
+
+// Lists the access tokens in Artifactory, optionally only the tokens of a specific subject.
+type AccessTokenListCommand struct {
+	serverDetails *config.ServerDetails
+	subject       string
+}
+
+func NewAccessTokenListCommand() *AccessTokenListCommand {
+	return &AccessTokenListCommand{}
+}
+
+func (atlc *AccessTokenListCommand) SetServerDetails(serverDetails *config.ServerDetails) *AccessTokenListCommand {
+	atlc.serverDetails = serverDetails
+	return atlc
+}
+
+// The full subject of the listed tokens, or the name of the user they were created for.
+func (atlc *AccessTokenListCommand) SetSubject(subject string) *AccessTokenListCommand {
+	atlc.subject = subject
+	return atlc
+}
+
+func (atlc *AccessTokenListCommand) ServerDetails() (*config.ServerDetails, error) {
+	return atlc.serverDetails, nil
+}
+
+func (atlc *AccessTokenListCommand) CommandName() string {
+	return "rt_access_token_list"
+}
+
+func (atlc *AccessTokenListCommand) Run() error {
+	servicesManager, err := utils.CreateServiceManager(atlc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	tokens, err := getTokens(servicesManager, atlc.subject)
+	if err != nil {
+		return err
+	}
+	items := []TokenListItem{}
+	for _, token := range tokens {
+		items = append(items, toTokenListItem(token))
+	}
+	return output.PrintJson(items)
+}
+
+// Revokes an access token by its ID, or all the access tokens of a subject.
+type AccessTokenRevokeCommand struct {
+	serverDetails *config.ServerDetails
+	tokenId       string
+	subject       string
+	quiet         bool
+	revokedCount  int
+}
+
+func NewAccessTokenRevokeCommand() *AccessTokenRevokeCommand {
+	return &AccessTokenRevokeCommand{}
+}
+
+func (atrc *AccessTokenRevokeCommand) SetServerDetails(serverDetails *config.ServerDetails) *AccessTokenRevokeCommand {
+	atrc.serverDetails = serverDetails
+	return atrc
+}
+
+func (atrc *AccessTokenRevokeCommand) SetTokenId(tokenId string) *AccessTokenRevokeCommand {
+	atrc.tokenId = tokenId
+	return atrc
+}
+
+// The full subject of the revoked tokens, or the name of the user they were created for.
+func (atrc *AccessTokenRevokeCommand) SetSubject(subject string) *AccessTokenRevokeCommand {
+	atrc.subject = subject
+	return atrc
+}
+
+func (atrc *AccessTokenRevokeCommand) SetQuiet(quiet bool) *AccessTokenRevokeCommand {
+	atrc.quiet = quiet
+	return atrc
+}
+
+func (atrc *AccessTokenRevokeCommand) ServerDetails() (*config.ServerDetails, error) {
+	return atrc.serverDetails, nil
+}
+
+func (atrc *AccessTokenRevokeCommand) CommandName() string {
+	return "rt_access_token_revoke"
+}
+
+// Returns the number of tokens revoked by the last run of the command.
+func (atrc *AccessTokenRevokeCommand) RevokedCount() int {
+	return atrc.revokedCount
+}
+
+func (atrc *AccessTokenRevokeCommand) Run() error {
+	atrc.revokedCount = 0
+	servicesManager, err := utils.CreateServiceManager(atrc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	if atrc.tokenId != "" {
+		if err = revokeToken(servicesManager, services.RevokeTokenParams{TokenId: atrc.tokenId}); err != nil {
+			return err
+		}
+		atrc.revokedCount++
+		return nil
+	}
+	tokens, err := getTokens(servicesManager, atrc.subject)
+	if err != nil {
+		return err
+	}
+	if len(tokens) == 0 {
+		log.Info("No access tokens were found for the subject", atrc.subject)
+		return nil
+	}
+	if !atrc.quiet && !coreutils.AskYesNo(fmt.Sprintf("This command will revoke %d access tokens of %s. Are you sure you want to continue?\n"+
+		"You can avoid this confirmation message by adding --quiet to the command.", len(tokens), atrc.subject), false) {
+		return nil
+	}
+	for _, token := range tokens {
+		if err = revokeToken(servicesManager, services.RevokeTokenParams{TokenId: token.TokenId}); err != nil {
+			return err
+		}
+		atrc.revokedCount++
+	}
+	return nil
+}
+
+// Replaces the access token of a server in the JFrog CLI configuration.
+// A new token is created with the scope and audience of the current token, the server configuration is updated to use it,
+// and then the current token is revoked.
+type AccessTokenRotateCommand struct {
+	serverId    string
+	expiry      int
+	refreshable bool
+}
+
+func NewAccessTokenRotateCommand() *AccessTokenRotateCommand {
+	return &AccessTokenRotateCommand{expiry: -1}
+}
+
+// The ID of the server in the JFrog CLI configuration. If empty, the default server is used.
+func (atrc *AccessTokenRotateCommand) SetServerId(serverId string) *AccessTokenRotateCommand {
+	atrc.serverId = serverId
+	return atrc
+}
+
+// The time in seconds for which the new token will be valid. If negative, it's the lifetime of the current token.
+func (atrc *AccessTokenRotateCommand) SetExpiry(expiry int) *AccessTokenRotateCommand {
+	atrc.expiry = expiry
+	return atrc
+}
+
+func (atrc *AccessTokenRotateCommand) SetRefreshable(refreshable bool) *AccessTokenRotateCommand {
+	atrc.refreshable = refreshable
+	return atrc
+}
+
+// The usage isn't reported, since the access token of the server is replaced while the command runs.
+func (atrc *AccessTokenRotateCommand) ServerDetails() (*config.ServerDetails, error) {
+	return nil, nil
+}
+
+func (atrc *AccessTokenRotateCommand) CommandName() string {
+	return "rt_access_token_rotate"
+}
+
+func (atrc *AccessTokenRotateCommand) Run() error {
+	configs, err := config.GetAllServersConfigs()
+	if err != nil {
+		return err
+	}
+	details, err := getServerConfig(configs, atrc.serverId)
+	if err != nil {
+		return err
+	}
+	if details.AccessToken == "" {
+		return errorutils.CheckErrorf("the server %s isn't configured with an access token", details.ServerId)
+	}
+	oldToken := details.AccessToken
+	payload, err := parseTokenPayload(oldToken)
+	if err != nil {
+		return err
+	}
+	tokenParams, err := getRotatedTokenParams(payload, atrc.expiry, atrc.refreshable)
+	if err != nil {
+		return err
+	}
+	servicesManager, err := utils.CreateServiceManager(details, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	log.Info("Creating a new access token for " + tokenParams.Username + "...")
+	response, err := servicesManager.CreateToken(tokenParams)
+	if err != nil {
+		return err
+	}
+
+	details.AccessToken = response.AccessToken
+	details.RefreshToken = response.RefreshToken
+	if err = config.SaveServersConf(configs); err != nil {
+		return errorutils.CheckErrorf("the new access token was created, but the configuration of the server %s couldn't be updated: %s", details.ServerId, err.Error())
+	}
+	log.Info("Updated the configuration of the server " + details.ServerId + " with the new access token.")
+
+	// The new token is used to revoke the old token, which also verifies that it's valid.
+	servicesManager, err = utils.CreateServiceManager(details, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	if err = revokeToken(servicesManager, services.RevokeTokenParams{Token: oldToken}); err != nil {
+		return errorutils.CheckErrorf("the server %s is configured with the new access token, but the old token couldn't be revoked: %s", details.ServerId, err.Error())
+	}
+	return nil
+}
+
+func getServerConfig(configs []*config.ServerDetails, serverId string) (*config.ServerDetails, error) {
+	for _, details := range configs {
+		if (serverId == "" && details.IsDefault) || (serverId != "" && details.ServerId == serverId) {
+			return details, nil
+		}
+	}
+	if serverId == "" {
+		return nil, errorutils.CheckErrorf("no default server is configured")
+	}
+	return nil, errorutils.CheckErrorf("server ID '%s' does not exist", serverId)
+}
+
+type tokenPayload struct {
+	Subject        string      `json:"sub"`
+	Scope          string      `json:"scp"`
+	ExpirationTime int         `json:"exp"`
+	IssuedAt       int         `json:"iat"`
+	Audience       interface{} `json:"aud"`
+}
+
+// Reads the claims of an access token, which is a JWT.
+func parseTokenPayload(token string) (*tokenPayload, error) {
+	tokenParts := strings.Split(token, ".")
+	if len(tokenParts) != 3 {
+		return nil, errorutils.CheckErrorf("the configured access token isn't a valid JWT")
+	}
+	content, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(tokenParts[1], "="))
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	payload := &tokenPayload{}
+	if err = json.Unmarshal(content, payload); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return payload, nil
+}
+
+// Returns the parameters of a token which replaces the token with the given claims.
+func getRotatedTokenParams(payload *tokenPayload, expiry int, refreshable bool) (services.CreateTokenParams, error) {
+	tokenParams := services.NewCreateTokenParams()
+	usernameIndex := strings.LastIndex(payload.Subject, "/")
+	if usernameIndex < 0 {
+		return tokenParams, errorutils.CheckErrorf("couldn't get the user name from the access token subject: %s", payload.Subject)
+	}
+	tokenParams.Username = payload.Subject[usernameIndex+1:]
+	tokenParams.Scope = payload.Scope
+	tokenParams.Refreshable = refreshable
+	switch audience := payload.Audience.(type) {
+	case string:
+		tokenParams.Audience = audience
+	case []interface{}:
+		var audiences []string
+		for _, value := range audience {
+			audiences = append(audiences, fmt.Sprint(value))
+		}
+		tokenParams.Audience = strings.Join(audiences, " ")
+	}
+	tokenParams.ExpiresIn = expiry
+	if expiry < 0 {
+		// A token without an expiration time never expires, which is requested by an expiry of zero.
+		tokenParams.ExpiresIn = 0
+		if payload.ExpirationTime > 0 {
+			tokenParams.ExpiresIn = payload.ExpirationTime - payload.IssuedAt
+		}
+	}
+	return tokenParams, nil
+}
+
+// Returns the tokens of a subject, which is either the full subject of the tokens or the name of the user they were created for.
+// If the subject is empty, all the tokens are returned.
+func getTokens(servicesManager artifactory.ArtifactoryServicesManager, subject string) ([]services.Token, error) {
+	response, err := servicesManager.GetTokens()
+	if err != nil {
+		return nil, err
+	}
+	var tokens []services.Token
+	for _, token := range response.Tokens {
+		if matchesSubject(token, subject) {
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens, nil
+}
+
+func matchesSubject(token services.Token, subject string) bool {
+	return subject == "" || token.Subject == subject || strings.HasSuffix(token.Subject, "/"+subject)
+}
+
+func revokeToken(servicesManager artifactory.ArtifactoryServicesManager, params services.RevokeTokenParams) error {
+	if params.TokenId != "" {
+		log.Info("Revoking access token " + params.TokenId + "...")
+	} else {
+		log.Info("Revoking access token...")
+	}
+	_, err := servicesManager.RevokeToken(params)
+	return err
+}
+
+func toTokenListItem(token services.Token) TokenListItem {
+	return TokenListItem{
+		TokenId:     token.TokenId,
+		Subject:     token.Subject,
+		Issuer:      token.Issuer,
+		IssuedAt:    formatEpoch(token.IssuedAt),
+		Expiry:      formatEpoch(token.Expiry),
+		Refreshable: token.Refreshable,
+	}
+}
+
+func formatEpoch(seconds int) string {
+	if seconds <= 0 {
+		return ""
+	}
+	return time.Unix(int64(seconds), 0).UTC().Format(time.RFC3339)
+}
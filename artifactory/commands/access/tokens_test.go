@@ -0,0 +1,58 @@
+package access
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/stretchr/testify/assert"
+)
+
+func createTestToken(payload string) string {
+	return "header." + base64.RawURLEncoding.EncodeToString([]byte(payload)) + ".signature"
+}
+
+func TestGetRotatedTokenParams(t *testing.T) {
+	token := createTestToken(`{"sub":"jfrt@01abc/users/ci-bot","scp":"member-of-groups:deployers api:*","aud":"jfrt@*","iat":1600000000,"exp":1600086400}`)
+	payload, err := parseTokenPayload(token)
+	assert.NoError(t, err)
+
+	// By default, the new token has the lifetime of the old token.
+	tokenParams, err := getRotatedTokenParams(payload, -1, false)
+	assert.NoError(t, err)
+	assert.Equal(t, services.CreateTokenParams{Username: "ci-bot", Scope: "member-of-groups:deployers api:*", Audience: "jfrt@*", ExpiresIn: 86400}, tokenParams)
+
+	tokenParams, err = getRotatedTokenParams(payload, 3600, true)
+	assert.NoError(t, err)
+	assert.Equal(t, 3600, tokenParams.ExpiresIn)
+	assert.True(t, tokenParams.Refreshable)
+
+	// A token without an expiration time is replaced with a token which never expires.
+	payload, err = parseTokenPayload(createTestToken(`{"sub":"jfrt@01abc/users/ci-bot","aud":["jfrt@01abc","jfxr@01abc"],"iat":1600000000}`))
+	assert.NoError(t, err)
+	tokenParams, err = getRotatedTokenParams(payload, -1, false)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, tokenParams.ExpiresIn)
+	assert.Equal(t, "jfrt@01abc jfxr@01abc", tokenParams.Audience)
+
+	_, err = parseTokenPayload("not-a-token")
+	assert.Error(t, err)
+}
+
+func TestMatchesSubject(t *testing.T) {
+	token := services.Token{Subject: "jfrt@01abc/users/ci-bot"}
+	assert.True(t, matchesSubject(token, ""))
+	assert.True(t, matchesSubject(token, "ci-bot"))
+	assert.True(t, matchesSubject(token, "jfrt@01abc/users/ci-bot"))
+	assert.False(t, matchesSubject(token, "bot"))
+	assert.False(t, matchesSubject(token, "admin"))
+}
+
+func TestToTokenListItem(t *testing.T) {
+	token := services.Token{TokenId: "id", Subject: "jfrt@01abc/users/ci-bot", Issuer: "jfrt@01abc", IssuedAt: 1600000000, Expiry: 1600086400, Refreshable: true}
+	expected := TokenListItem{TokenId: "id", Subject: "jfrt@01abc/users/ci-bot", Issuer: "jfrt@01abc", IssuedAt: "2020-09-13T12:26:40Z", Expiry: "2020-09-14T12:26:40Z", Refreshable: true}
+	assert.Equal(t, expected, toTokenListItem(token))
+	// Tokens which never expire don't have an expiry.
+	token.Expiry = 0
+	assert.Empty(t, toTokenListItem(token).Expiry)
+}
@@ -0,0 +1,344 @@
+package cleanup
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"time"
+
+	commandsutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/transfer"
+	rtutils "github.com/jfrog/jfrog-cli/artifactory/utils"
+	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-cli/utils/output"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	ioUtils "github.com/jfrog/jfrog-client-go/utils/io"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const day = 24 * time.Hour
+
+// An artifact matching the pattern of a rule.
+type artifact struct {
+	Repo    string `json:"repo,omitempty"`
+	Path    string `json:"path,omitempty"`
+	Name    string `json:"name,omitempty"`
+	Size    int64  `json:"size,omitempty"`
+	Created string `json:"created,omitempty"`
+	Stats   []struct {
+		Downloaded string `json:"downloaded,omitempty"`
+	} `json:"stats,omitempty"`
+	created        time.Time
+	lastDownloaded time.Time
+}
+
+func (a *artifact) getRelativePath() string {
+	if a.Path == "" || a.Path == "." {
+		return a.Repo + "/" + a.Name
+	}
+	return a.Repo + "/" + a.Path + "/" + a.Name
+}
+
+// Returns the version folder of the artifact.
+func (a *artifact) getVersion() string {
+	return path.Dir(a.getRelativePath())
+}
+
+// Parses the times of the artifact. Artifacts which were never downloaded are considered downloaded when they were created.
+func (a *artifact) parseTimes() error {
+	var err error
+	if a.created, err = time.Parse(time.RFC3339, a.Created); err != nil {
+		return errorutils.CheckErrorf("failed parsing the creation time of %s: %s", a.getRelativePath(), err.Error())
+	}
+	a.lastDownloaded = a.created
+	for _, stats := range a.Stats {
+		if downloaded, err := time.Parse(time.RFC3339, stats.Downloaded); err == nil && downloaded.After(a.lastDownloaded) {
+			a.lastDownloaded = downloaded
+		}
+	}
+	return nil
+}
+
+// An artifact to delete, as printed in the report of the cleanup command.
+type reportItem struct {
+	artifact *artifact
+	rule     string
+}
+
+// Deletes the artifacts matching the retention rules of a cleanup policy.
+// A report of the artifacts to delete is printed first, and the artifacts are then deleted by the delete command.
+type CleanupCommand struct {
+	serverDetails          *config.ServerDetails
+	policy                 *Policy
+	threads                int
+	retries                int
+	retryWaitTimeMilliSecs int
+	dryRun                 bool
+	detailedSummary        bool
+	progress               ioUtils.ProgressMgr
+	result                 *commandsutils.Result
+	// The artifacts to delete, which are set by Report.
+	items    []reportItem
+	reported bool
+}
+
+func NewCleanupCommand() *CleanupCommand {
+	return &CleanupCommand{result: new(commandsutils.Result)}
+}
+
+func (cc *CleanupCommand) SetServerDetails(serverDetails *config.ServerDetails) *CleanupCommand {
+	cc.serverDetails = serverDetails
+	return cc
+}
+
+func (cc *CleanupCommand) SetPolicy(policy *Policy) *CleanupCommand {
+	cc.policy = policy
+	return cc
+}
+
+func (cc *CleanupCommand) SetThreads(threads int) *CleanupCommand {
+	cc.threads = threads
+	return cc
+}
+
+func (cc *CleanupCommand) SetRetries(retries int) *CleanupCommand {
+	cc.retries = retries
+	return cc
+}
+
+func (cc *CleanupCommand) SetRetryWaitMilliSecs(retryWaitMilliSecs int) *CleanupCommand {
+	cc.retryWaitTimeMilliSecs = retryWaitMilliSecs
+	return cc
+}
+
+// Set to true to only print the report, without deleting the artifacts.
+func (cc *CleanupCommand) SetDryRun(dryRun bool) *CleanupCommand {
+	cc.dryRun = dryRun
+	return cc
+}
+
+func (cc *CleanupCommand) SetDetailedSummary(detailedSummary bool) *CleanupCommand {
+	cc.detailedSummary = detailedSummary
+	return cc
+}
+
+func (cc *CleanupCommand) SetProgress(progress ioUtils.ProgressMgr) {
+	cc.progress = progress
+}
+
+func (cc *CleanupCommand) ServerDetails() (*config.ServerDetails, error) {
+	return cc.serverDetails, nil
+}
+
+func (cc *CleanupCommand) CommandName() string {
+	return "rt_cleanup"
+}
+
+func (cc *CleanupCommand) Result() *commandsutils.Result {
+	return cc.result
+}
+
+// Searches the artifacts matching the policy and prints their report. Returns the number of artifacts to delete.
+// It's called before the command runs, so that the report can be confirmed before the progress of the deletion is displayed.
+func (cc *CleanupCommand) Report() (int, error) {
+	servicesManager, err := utils.CreateServiceManager(cc.serverDetails, cc.retries, cc.retryWaitTimeMilliSecs, false)
+	if err != nil {
+		return 0, err
+	}
+	now := time.Now()
+	var items []reportItem
+	// An artifact may match more than one rule. It is reported with the first rule which selected it.
+	selected := make(map[string]bool)
+	for i := range cc.policy.Rules {
+		rule := &cc.policy.Rules[i]
+		artifacts, err := searchArtifacts(servicesManager, rule)
+		if err != nil {
+			return 0, err
+		}
+		released := make(map[string]bool)
+		if rule.ExcludeReleasedBuilds {
+			if released, err = searchReleasedArtifacts(servicesManager, rule); err != nil {
+				return 0, err
+			}
+		}
+		for _, artifact := range selectArtifacts(rule, artifacts, released, now) {
+			if relativePath := artifact.getRelativePath(); !selected[relativePath] {
+				selected[relativePath] = true
+				items = append(items, reportItem{artifact: artifact, rule: rule.Pattern})
+			}
+		}
+	}
+	if err = printReport(items); err != nil {
+		return 0, err
+	}
+	cc.items = items
+	cc.reported = true
+	return len(items), nil
+}
+
+// Deletes the reported artifacts without asking for a confirmation. The report is created first if Report wasn't called.
+func (cc *CleanupCommand) Run() error {
+	if !cc.reported {
+		if _, err := cc.Report(); err != nil {
+			return err
+		}
+	}
+	if cc.dryRun || len(cc.items) == 0 {
+		return nil
+	}
+	return cc.deleteArtifacts(cc.items)
+}
+
+// Deletes the artifacts with the delete command, so that they are deleted in parallel and with retries.
+func (cc *CleanupCommand) deleteArtifacts(items []reportItem) error {
+	writer, err := content.NewContentWriter(content.DefaultKey, true, false)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		writer.Write(servicesutils.ResultItem{Repo: item.artifact.Repo, Path: item.artifact.Path, Name: item.artifact.Name, Type: "file"})
+	}
+	if err = writer.Close(); err != nil {
+		return err
+	}
+	reader := content.NewContentReader(writer.GetFilePath(), content.DefaultKey)
+	defer reader.Close()
+	deleteCommand := transfer.NewDeleteCommand().SetItems(reader)
+	deleteCommand.SetProgress(cc.progress)
+	deleteCommand.SetThreads(cc.threads).SetServerDetails(cc.serverDetails).SetDetailedSummary(cc.detailedSummary).
+		SetRetries(cc.retries).SetRetryWaitMilliSecs(cc.retryWaitTimeMilliSecs)
+	err = deleteCommand.Run()
+	cc.result = deleteCommand.Result()
+	return err
+}
+
+// Returns the artifacts which match the pattern of the rule, and don't have any of its excluded properties.
+func searchArtifacts(servicesManager artifactory.ArtifactoryServicesManager, rule *Rule) ([]*artifact, error) {
+	aqlBody, err := getAqlBody(rule)
+	if err != nil {
+		return nil, err
+	}
+	query := fmt.Sprintf(`items.find(%s).include("repo","path","name","size","created","stat.downloaded")`, aqlBody)
+	reader, err := servicesutils.ExecAqlSaveToFile(query, rtutils.NewSearchConf(servicesManager))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	var artifacts []*artifact
+	for item := new(artifact); reader.NextRecord(item) == nil; item = new(artifact) {
+		if err = item.parseTimes(); err != nil {
+			return nil, err
+		}
+		artifacts = append(artifacts, item)
+	}
+	return artifacts, reader.GetError()
+}
+
+// Returns the paths of the artifacts which match the pattern of the rule and belong to a released build.
+func searchReleasedArtifacts(servicesManager artifactory.ArtifactoryServicesManager, rule *Rule) (map[string]bool, error) {
+	aqlBody, err := getAqlBody(rule)
+	if err != nil {
+		return nil, err
+	}
+	query := fmt.Sprintf(`items.find({"$and":[%s,{"$or":[{"artifact.module.build.promotion.status":"released"},{"artifact.module.build.promotion.status":"Released"}]}]}).include("repo","path","name")`, aqlBody)
+	reader, err := servicesutils.ExecAqlSaveToFile(query, rtutils.NewSearchConf(servicesManager))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	released := make(map[string]bool)
+	for item := new(artifact); reader.NextRecord(item) == nil; item = new(artifact) {
+		released[item.getRelativePath()] = true
+	}
+	return released, reader.GetError()
+}
+
+func getAqlBody(rule *Rule) (string, error) {
+	return servicesutils.CreateAqlBodyForSpecWithPattern(&servicesutils.CommonParams{
+		Pattern:      rule.Pattern,
+		Exclusions:   rule.Exclusions,
+		ExcludeProps: rule.ExcludeProps,
+		Recursive:    true,
+	})
+}
+
+// Returns the artifacts to which all the conditions of the rule apply.
+func selectArtifacts(rule *Rule, artifacts []*artifact, released map[string]bool, now time.Time) []*artifact {
+	keptVersions := getKeptVersions(artifacts, rule.KeepLastVersions)
+	var selected []*artifact
+	for _, artifact := range artifacts {
+		if released[artifact.getRelativePath()] || keptVersions[artifact.getVersion()] {
+			continue
+		}
+		if rule.OlderThanDays > 0 && artifact.created.After(now.Add(-time.Duration(rule.OlderThanDays)*day)) {
+			continue
+		}
+		if rule.NotDownloadedForDays > 0 && artifact.lastDownloaded.After(now.Add(-time.Duration(rule.NotDownloadedForDays)*day)) {
+			continue
+		}
+		selected = append(selected, artifact)
+	}
+	return selected
+}
+
+// Returns the most recently created versions of each path.
+// A version is created when the latest of its artifacts was created.
+func getKeptVersions(artifacts []*artifact, keepLastVersions int) map[string]bool {
+	kept := make(map[string]bool)
+	if keepLastVersions == 0 {
+		return kept
+	}
+	versionsCreated := make(map[string]time.Time)
+	for _, artifact := range artifacts {
+		version := artifact.getVersion()
+		if created, ok := versionsCreated[version]; !ok || artifact.created.After(created) {
+			versionsCreated[version] = artifact.created
+		}
+	}
+	versionsByPath := make(map[string][]string)
+	for version := range versionsCreated {
+		parent := path.Dir(version)
+		versionsByPath[parent] = append(versionsByPath[parent], version)
+	}
+	for _, versions := range versionsByPath {
+		sort.Slice(versions, func(i, j int) bool {
+			if versionsCreated[versions[i]].Equal(versionsCreated[versions[j]]) {
+				return versions[i] > versions[j]
+			}
+			return versionsCreated[versions[i]].After(versionsCreated[versions[j]])
+		})
+		for i := 0; i < len(versions) && i < keepLastVersions; i++ {
+			kept[versions[i]] = true
+		}
+	}
+	return kept
+}
+
+func printReport(items []reportItem) error {
+	if len(items) == 0 {
+		log.Info("No artifacts match the cleanup policy.")
+		return nil
+	}
+	var rows [][]string
+	var totalSize int64
+	for _, item := range items {
+		lastDownloaded := ""
+		if item.artifact.lastDownloaded.After(item.artifact.created) {
+			lastDownloaded = item.artifact.lastDownloaded.Format(time.RFC3339)
+		}
+		rows = append(rows, []string{item.artifact.getRelativePath(), cliutils.FormatSize(item.artifact.Size),
+			item.artifact.created.Format(time.RFC3339), output.FormatCell(lastDownloaded), item.rule})
+		totalSize += item.artifact.Size
+	}
+	if err := output.PrintTable([]string{"PATH", "SIZE", "CREATED", "LAST DOWNLOADED", "RULE"}, rows); err != nil {
+		return err
+	}
+	log.Info(strconv.Itoa(len(items)) + " artifacts (" + cliutils.FormatSize(totalSize) + ") match the cleanup policy.")
+	return nil
+}
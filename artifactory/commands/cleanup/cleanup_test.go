@@ -0,0 +1,117 @@
+package cleanup
+
+import (
+	"io/ioutil"
+	"path"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	coretests "github.com/jfrog/jfrog-cli-core/v2/utils/tests"
+	"github.com/stretchr/testify/assert"
+)
+
+var testNow = time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+
+func createTestArtifact(t *testing.T, relativePath string, createdDaysAgo, downloadedDaysAgo int) *artifact {
+	parts := strings.SplitN(relativePath, "/", 2)
+	item := &artifact{Repo: parts[0], Path: path.Dir(parts[1]), Name: path.Base(parts[1]), Created: testNow.AddDate(0, 0, -createdDaysAgo).Format(time.RFC3339)}
+	if downloadedDaysAgo >= 0 {
+		item.Stats = append(item.Stats, struct {
+			Downloaded string `json:"downloaded,omitempty"`
+		}{testNow.AddDate(0, 0, -downloadedDaysAgo).Format(time.RFC3339)})
+	}
+	assert.NoError(t, item.parseTimes())
+	return item
+}
+
+func getPaths(artifacts []*artifact) []string {
+	var paths []string
+	for _, artifact := range artifacts {
+		paths = append(paths, artifact.getRelativePath())
+	}
+	return paths
+}
+
+func TestSelectArtifacts(t *testing.T) {
+	artifacts := []*artifact{
+		createTestArtifact(t, "repo/app/1.0/app-1.0.jar", 100, -1),
+		createTestArtifact(t, "repo/app/1.1/app-1.1.jar", 90, 10),
+		createTestArtifact(t, "repo/app/1.2/app-1.2.jar", 80, 70),
+		createTestArtifact(t, "repo/app/1.3/app-1.3.jar", 20, -1),
+		createTestArtifact(t, "repo/app/1.4/app-1.4.jar", 10, -1),
+		createTestArtifact(t, "repo/lib/2.0/lib-2.0.jar", 100, -1),
+	}
+
+	rule := &Rule{OlderThanDays: 30}
+	assert.Equal(t, []string{"repo/app/1.0/app-1.0.jar", "repo/app/1.1/app-1.1.jar", "repo/app/1.2/app-1.2.jar", "repo/lib/2.0/lib-2.0.jar"},
+		getPaths(selectArtifacts(rule, artifacts, nil, testNow)))
+
+	// Artifacts which were never downloaded are compared by their creation time.
+	rule = &Rule{NotDownloadedForDays: 60}
+	assert.Equal(t, []string{"repo/app/1.0/app-1.0.jar", "repo/app/1.2/app-1.2.jar", "repo/lib/2.0/lib-2.0.jar"},
+		getPaths(selectArtifacts(rule, artifacts, nil, testNow)))
+
+	// The last versions are kept per path.
+	rule = &Rule{KeepLastVersions: 2}
+	assert.Equal(t, []string{"repo/app/1.0/app-1.0.jar", "repo/app/1.1/app-1.1.jar", "repo/app/1.2/app-1.2.jar"},
+		getPaths(selectArtifacts(rule, artifacts, nil, testNow)))
+
+	// All the conditions of a rule should apply, and artifacts of released builds are excluded.
+	rule = &Rule{OlderThanDays: 30, NotDownloadedForDays: 60, KeepLastVersions: 3, ExcludeReleasedBuilds: true}
+	released := map[string]bool{"repo/app/1.0/app-1.0.jar": true}
+	assert.Empty(t, getPaths(selectArtifacts(rule, artifacts, released, testNow)))
+	rule.KeepLastVersions = 1
+	assert.Equal(t, []string{"repo/app/1.2/app-1.2.jar"}, getPaths(selectArtifacts(rule, artifacts, released, testNow)))
+}
+
+func TestGetKeptVersions(t *testing.T) {
+	artifacts := []*artifact{
+		createTestArtifact(t, "repo/app/1.0/app-1.0.jar", 30, -1),
+		// A version is created when the latest of its artifacts was created.
+		createTestArtifact(t, "repo/app/1.0/app-1.0.pom", 5, -1),
+		createTestArtifact(t, "repo/app/1.1/app-1.1.jar", 10, -1),
+		createTestArtifact(t, "repo/app/1.2/app-1.2.jar", 20, -1),
+	}
+	assert.Equal(t, map[string]bool{"repo/app/1.0": true, "repo/app/1.1": true}, getKeptVersions(artifacts, 2))
+	assert.Empty(t, getKeptVersions(artifacts, 0))
+}
+
+func TestLoadPolicy(t *testing.T) {
+	tempDir, cleanUp := coretests.CreateTempDirWithCallbackAndAssert(t)
+	defer cleanUp()
+	policyPath := filepath.Join(tempDir, "cleanup.yaml")
+
+	writePolicy := func(content string) {
+		assert.NoError(t, ioutil.WriteFile(policyPath, []byte(content), 0644))
+	}
+	writePolicy(`rules:
+  - pattern: libs-snapshot-local/*
+    exclusions: ["libs-snapshot-local/keep/*"]
+    olderThanDays: 30
+    notDownloadedForDays: 60
+    keepLastVersions: 5
+    excludeProps: retain=true
+    excludeReleasedBuilds: true
+`)
+	policy, err := LoadPolicy(policyPath)
+	assert.NoError(t, err)
+	expected := Rule{Pattern: "libs-snapshot-local/*", Exclusions: []string{"libs-snapshot-local/keep/*"}, OlderThanDays: 30, NotDownloadedForDays: 60,
+		KeepLastVersions: 5, ExcludeProps: "retain=true", ExcludeReleasedBuilds: true}
+	assert.Equal(t, []Rule{expected}, policy.Rules)
+
+	// A misspelled condition is rejected.
+	writePolicy("rules:\n  - pattern: repo/*\n    olderThanDay: 30\n")
+	_, err = LoadPolicy(policyPath)
+	assert.Error(t, err)
+
+	// A rule without conditions is rejected.
+	writePolicy("rules:\n  - pattern: repo/*\n    excludeProps: retain=true\n")
+	_, err = LoadPolicy(policyPath)
+	assert.Error(t, err)
+
+	writePolicy("rules: []\n")
+	_, err = LoadPolicy(policyPath)
+	assert.Error(t, err)
+}
@@ -0,0 +1,68 @@
+package cleanup
+
+import (
+	"io/ioutil"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"gopkg.in/yaml.v2"
+)
+
+// The retention rules of a cleanup policy file.
+type Policy struct {
+	Rules []Rule `yaml:"rules"`
+}
+
+// A retention rule of the artifacts matching a pattern.
+// An artifact is deleted only if all the conditions set in the rule apply to it.
+type Rule struct {
+	// The pattern of the artifacts the rule applies to, in the following format: <repository name>/<repository path>.
+	Pattern    string   `yaml:"pattern"`
+	Exclusions []string `yaml:"exclusions,omitempty"`
+	// The artifact was created more than this number of days ago.
+	OlderThanDays int `yaml:"olderThanDays,omitempty"`
+	// The artifact wasn't downloaded in this number of days. Artifacts which were never downloaded are compared by their creation time.
+	NotDownloadedForDays int `yaml:"notDownloadedForDays,omitempty"`
+	// The artifact isn't in one of the most recently created versions of its path.
+	// A version is a folder of artifacts, such as the version folder of a Maven artifact or the tag folder of a Docker image,
+	// and its path is the parent folder of the version.
+	KeepLastVersions int `yaml:"keepLastVersions,omitempty"`
+	// The artifact doesn't have any of these properties, in the form of "key1=value1;key2=value2,...".
+	ExcludeProps string `yaml:"excludeProps,omitempty"`
+	// The artifact doesn't belong to a build which was promoted with the released status.
+	ExcludeReleasedBuilds bool `yaml:"excludeReleasedBuilds,omitempty"`
+}
+
+func LoadPolicy(path string) (*Policy, error) {
+	content, err := ioutil.ReadFile(path)
+	if errorutils.CheckError(err) != nil {
+		return nil, err
+	}
+	policy := new(Policy)
+	// Unknown fields are rejected, since a misspelled condition would cause more artifacts to be deleted.
+	if err = errorutils.CheckError(yaml.UnmarshalStrict(content, policy)); err != nil {
+		return nil, err
+	}
+	if err = policy.validate(); err != nil {
+		return nil, errorutils.CheckErrorf("the cleanup policy at %s is invalid: %s", path, err.Error())
+	}
+	return policy, nil
+}
+
+func (p *Policy) validate() error {
+	if len(p.Rules) == 0 {
+		return errorutils.CheckErrorf("no rules are defined")
+	}
+	for i, rule := range p.Rules {
+		if rule.Pattern == "" {
+			return errorutils.CheckErrorf("rule %d doesn't have a pattern", i+1)
+		}
+		if rule.OlderThanDays < 0 || rule.NotDownloadedForDays < 0 || rule.KeepLastVersions < 0 {
+			return errorutils.CheckErrorf("rule %s has a negative value", rule.Pattern)
+		}
+		// A rule without conditions would delete all the artifacts matching its pattern.
+		if rule.OlderThanDays == 0 && rule.NotDownloadedForDays == 0 && rule.KeepLastVersions == 0 {
+			return errorutils.CheckErrorf("rule %s should set at least one of olderThanDays, notDownloadedForDays and keepLastVersions", rule.Pattern)
+		}
+	}
+	return nil
+}
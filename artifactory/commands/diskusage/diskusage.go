@@ -0,0 +1,338 @@
+package diskusage
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	rtutils "github.com/jfrog/jfrog-cli/artifactory/utils"
+	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-cli/utils/output"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+type Format = output.Format
+
+const (
+	Json  = output.Json
+	Csv   = output.Csv
+	Table = output.Table
+)
+
+type SortBy string
+
+const (
+	SortBySize       SortBy = "size"
+	SortByFiles      SortBy = "files"
+	SortByPath       SortBy = "path"
+	SortByModified   SortBy = "modified"
+	SortByDownloaded SortBy = "downloaded"
+)
+
+// The path of the folder which includes all the repositories.
+const rootPath = "/"
+
+// Returns the format matching the provided value. An empty value is the json format.
+func ParseFormat(value string) (Format, error) {
+	return output.ParseFormat(value, "format", Json, Csv, Table)
+}
+
+// Returns the sort field matching the provided value. An empty value is the size.
+func ParseSortBy(value string) (SortBy, error) {
+	switch sortBy := SortBy(strings.ToLower(value)); sortBy {
+	case "":
+		return SortBySize, nil
+	case SortBySize, SortByFiles, SortByPath, SortByModified, SortByDownloaded:
+		return sortBy, nil
+	}
+	return "", errorutils.CheckErrorf("the --sort-by option accepts one of the following values: %s, %s, %s, %s, %s",
+		SortBySize, SortByFiles, SortByPath, SortByModified, SortByDownloaded)
+}
+
+// The aggregated storage usage of a folder.
+type FolderUsage struct {
+	Path           string `json:"path"`
+	Size           int64  `json:"size"`
+	Files          int    `json:"files"`
+	LastModified   string `json:"lastModified,omitempty"`
+	LastDownloaded string `json:"lastDownloaded,omitempty"`
+	lastModified   time.Time
+	lastDownloaded time.Time
+}
+
+// A file returned by the AQL query of the command.
+type fileItem struct {
+	Repo     string `json:"repo,omitempty"`
+	Path     string `json:"path,omitempty"`
+	Name     string `json:"name,omitempty"`
+	Size     int64  `json:"size,omitempty"`
+	Modified string `json:"modified,omitempty"`
+	Stats    []struct {
+		Downloaded string `json:"downloaded,omitempty"`
+	} `json:"stats,omitempty"`
+}
+
+// Reports the aggregated size, number of files and last modified and downloaded dates of the folders matched by a spec.
+// The folders are reported up to a depth below the folder of each pattern, which is the part of the pattern before its first wildcard.
+type DuCommand struct {
+	serverDetails *config.ServerDetails
+	spec          *spec.SpecFiles
+	depth         int
+	sortBy        SortBy
+	ascending     bool
+	format        Format
+	results       []*FolderUsage
+}
+
+func NewDuCommand() *DuCommand {
+	return &DuCommand{depth: 1, sortBy: SortBySize, format: Json}
+}
+
+func (dc *DuCommand) SetServerDetails(serverDetails *config.ServerDetails) *DuCommand {
+	dc.serverDetails = serverDetails
+	return dc
+}
+
+func (dc *DuCommand) SetSpec(spec *spec.SpecFiles) *DuCommand {
+	dc.spec = spec
+	return dc
+}
+
+// The number of folder levels reported below the folder of each pattern. Zero reports only the folder of the pattern.
+func (dc *DuCommand) SetDepth(depth int) *DuCommand {
+	dc.depth = depth
+	return dc
+}
+
+func (dc *DuCommand) SetSortBy(sortBy SortBy) *DuCommand {
+	dc.sortBy = sortBy
+	return dc
+}
+
+func (dc *DuCommand) SetAscending(ascending bool) *DuCommand {
+	dc.ascending = ascending
+	return dc
+}
+
+func (dc *DuCommand) SetFormat(format Format) *DuCommand {
+	dc.format = format
+	return dc
+}
+
+func (dc *DuCommand) ServerDetails() (*config.ServerDetails, error) {
+	return dc.serverDetails, nil
+}
+
+func (dc *DuCommand) CommandName() string {
+	return "rt_du"
+}
+
+// Returns the folders reported by the last run of the command.
+func (dc *DuCommand) Results() []*FolderUsage {
+	return dc.results
+}
+
+func (dc *DuCommand) Run() error {
+	servicesManager, err := utils.CreateServiceManager(dc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	aggregator := newUsageAggregator(dc.depth)
+	for i := 0; i < len(dc.spec.Files); i++ {
+		if err = dc.aggregateFile(servicesManager, dc.spec.Get(i), aggregator); err != nil {
+			return err
+		}
+	}
+	dc.results = aggregator.folders()
+	sortFolders(dc.results, dc.sortBy, dc.ascending)
+	return printFolders(dc.results, dc.format)
+}
+
+func (dc *DuCommand) aggregateFile(servicesManager artifactory.ArtifactoryServicesManager, file *spec.File, aggregator *usageAggregator) error {
+	if file.Build != "" || file.Bundle != "" {
+		return errorutils.CheckErrorf("the du command doesn't support the build and bundle spec options")
+	}
+	searchParams, err := utils.GetSearchParams(file)
+	if err != nil {
+		return err
+	}
+	// Folders are aggregated from the files they contain.
+	searchParams.IncludeDirs = false
+	aqlBody := searchParams.Aql.ItemsFind
+	basePath := ""
+	if aqlBody == "" {
+		if aqlBody, err = servicesutils.CreateAqlBodyForSpecWithPattern(searchParams.CommonParams); err != nil {
+			return err
+		}
+		basePath = getBasePath(searchParams.Pattern)
+	}
+	query := fmt.Sprintf(`items.find(%s).include("repo","path","name","size","modified","stat.downloaded")`, aqlBody)
+	reader, err := servicesutils.ExecAqlSaveToFile(query, rtutils.NewSearchConf(servicesManager))
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	for item := new(fileItem); reader.NextRecord(item) == nil; item = new(fileItem) {
+		aggregator.add(basePath, item)
+	}
+	return reader.GetError()
+}
+
+// Returns the folder of a search pattern, which is the part of the pattern before its first wildcard, up to the last slash.
+// An empty path is returned for patterns which match all repositories.
+func getBasePath(pattern string) string {
+	pattern = strings.TrimPrefix(pattern, "/")
+	if !strings.Contains(pattern, "/") {
+		pattern += "/"
+	}
+	if strings.HasSuffix(pattern, "/") {
+		pattern += "*"
+	}
+	if wildcardIndex := strings.Index(pattern, "*"); wildcardIndex >= 0 {
+		pattern = pattern[:wildcardIndex]
+	}
+	slashIndex := strings.LastIndex(pattern, "/")
+	if slashIndex < 0 {
+		return ""
+	}
+	return pattern[:slashIndex]
+}
+
+// Sums the usage of files into their folders.
+type usageAggregator struct {
+	depth     int
+	usage     map[string]*FolderUsage
+	seenFiles map[string]bool
+}
+
+func newUsageAggregator(depth int) *usageAggregator {
+	return &usageAggregator{depth: depth, usage: make(map[string]*FolderUsage), seenFiles: make(map[string]bool)}
+}
+
+// Adds a file to its folder and to each of the folder's parents, up to the base path.
+// Only the folders up to the depth below the base path are reported.
+func (ua *usageAggregator) add(basePath string, item *fileItem) {
+	folderPath := item.Repo
+	if item.Path != "" && item.Path != "." {
+		folderPath += "/" + item.Path
+	}
+	// A file may be matched by more than one spec file.
+	filePath := folderPath + "/" + item.Name
+	if ua.seenFiles[filePath] {
+		return
+	}
+	ua.seenFiles[filePath] = true
+
+	folders := strings.Split(folderPath, "/")
+	baseDepth := 0
+	if basePath != "" {
+		baseDepth = strings.Count(basePath, "/") + 1
+	}
+	for i := baseDepth; i <= len(folders) && i-baseDepth <= ua.depth; i++ {
+		path := strings.Join(folders[:i], "/")
+		if i == 0 {
+			path = rootPath
+		} else if i == baseDepth && path != basePath {
+			// The file isn't under the base path.
+			return
+		}
+		ua.addToFolder(path, item)
+	}
+}
+
+func (ua *usageAggregator) addToFolder(path string, item *fileItem) {
+	folder, ok := ua.usage[path]
+	if !ok {
+		folder = &FolderUsage{Path: path}
+		ua.usage[path] = folder
+	}
+	folder.Size += item.Size
+	folder.Files++
+	if modified, err := time.Parse(time.RFC3339, item.Modified); err == nil && modified.After(folder.lastModified) {
+		folder.lastModified = modified
+		folder.LastModified = item.Modified
+	}
+	for _, stats := range item.Stats {
+		if downloaded, err := time.Parse(time.RFC3339, stats.Downloaded); err == nil && downloaded.After(folder.lastDownloaded) {
+			folder.lastDownloaded = downloaded
+			folder.LastDownloaded = stats.Downloaded
+		}
+	}
+}
+
+func (ua *usageAggregator) folders() []*FolderUsage {
+	folders := []*FolderUsage{}
+	for _, folder := range ua.usage {
+		folders = append(folders, folder)
+	}
+	return folders
+}
+
+// Sorts the folders by a field. The path is always used to order folders with equal values.
+func sortFolders(folders []*FolderUsage, sortBy SortBy, ascending bool) {
+	sort.Slice(folders, func(i, j int) bool {
+		var compare int
+		switch sortBy {
+		case SortBySize:
+			compare = compareInts(folders[i].Size, folders[j].Size)
+		case SortByFiles:
+			compare = compareInts(int64(folders[i].Files), int64(folders[j].Files))
+		case SortByModified:
+			compare = compareTimes(folders[i].lastModified, folders[j].lastModified)
+		case SortByDownloaded:
+			compare = compareTimes(folders[i].lastDownloaded, folders[j].lastDownloaded)
+		}
+		if compare == 0 {
+			compare = strings.Compare(folders[i].Path, folders[j].Path)
+		}
+		if ascending {
+			return compare < 0
+		}
+		return compare > 0
+	})
+}
+
+func compareInts(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func compareTimes(a, b time.Time) int {
+	switch {
+	case a.Before(b):
+		return -1
+	case a.After(b):
+		return 1
+	}
+	return 0
+}
+
+func printFolders(folders []*FolderUsage, format Format) error {
+	switch format {
+	case Csv:
+		records := [][]string{{"path", "size", "files", "lastModified", "lastDownloaded"}}
+		for _, folder := range folders {
+			records = append(records, []string{folder.Path, strconv.FormatInt(folder.Size, 10), strconv.Itoa(folder.Files), folder.LastModified, folder.LastDownloaded})
+		}
+		return output.PrintCsv(records)
+	case Table:
+		var rows [][]string
+		for _, folder := range folders {
+			rows = append(rows, []string{folder.Path, cliutils.FormatSize(folder.Size), strconv.Itoa(folder.Files), output.FormatCell(folder.LastModified), output.FormatCell(folder.LastDownloaded)})
+		}
+		return output.PrintTable([]string{"PATH", "SIZE", "FILES", "LAST MODIFIED", "LAST DOWNLOADED"}, rows)
+	}
+	return output.PrintJson(folders)
+}
@@ -0,0 +1,118 @@
+package diskusage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetBasePath(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		expected string
+	}{
+		{"*", ""},
+		{"/*", ""},
+		{"repo", "repo"},
+		{"repo/", "repo"},
+		{"repo/*", "repo"},
+		{"repo/a/b/*.jar", "repo/a/b"},
+		{"repo/a*/b", "repo"},
+		{"repo/a/file.txt", "repo/a"},
+	}
+	for _, test := range tests {
+		t.Run(test.pattern, func(t *testing.T) {
+			assert.Equal(t, test.expected, getBasePath(test.pattern))
+		})
+	}
+}
+
+func createTestItem(repo, path, name string, size int64, modified, downloaded string) *fileItem {
+	item := &fileItem{Repo: repo, Path: path, Name: name, Size: size, Modified: modified}
+	if downloaded != "" {
+		item.Stats = append(item.Stats, struct {
+			Downloaded string `json:"downloaded,omitempty"`
+		}{downloaded})
+	}
+	return item
+}
+
+func getUsageByPath(folders []*FolderUsage) map[string]FolderUsage {
+	usage := make(map[string]FolderUsage)
+	for _, folder := range folders {
+		usage[folder.Path] = FolderUsage{Path: folder.Path, Size: folder.Size, Files: folder.Files, LastModified: folder.LastModified, LastDownloaded: folder.LastDownloaded}
+	}
+	return usage
+}
+
+func TestUsageAggregator(t *testing.T) {
+	aggregator := newUsageAggregator(1)
+	aggregator.add("repo", createTestItem("repo", ".", "root.txt", 1, "2021-01-01T10:00:00.000Z", ""))
+	aggregator.add("repo", createTestItem("repo", "a", "a.txt", 10, "2021-03-01T10:00:00.000Z", "2021-05-01T10:00:00.000Z"))
+	aggregator.add("repo", createTestItem("repo", "a/b/c", "c.txt", 100, "2021-02-01T10:00:00.000Z", "2021-06-01T10:00:00.000Z"))
+	aggregator.add("repo", createTestItem("repo", "d", "d.txt", 1000, "2021-04-01T10:00:00.000Z", ""))
+	// Files matched by more than one spec file are counted once.
+	aggregator.add("repo", createTestItem("repo", "d", "d.txt", 1000, "2021-04-01T10:00:00.000Z", ""))
+
+	usage := getUsageByPath(aggregator.folders())
+	assert.Len(t, usage, 3)
+	assert.Equal(t, FolderUsage{Path: "repo", Size: 1111, Files: 4, LastModified: "2021-04-01T10:00:00.000Z", LastDownloaded: "2021-06-01T10:00:00.000Z"}, usage["repo"])
+	// Deeper folders are aggregated into the folders at the depth limit.
+	assert.Equal(t, FolderUsage{Path: "repo/a", Size: 110, Files: 2, LastModified: "2021-03-01T10:00:00.000Z", LastDownloaded: "2021-06-01T10:00:00.000Z"}, usage["repo/a"])
+	assert.Equal(t, FolderUsage{Path: "repo/d", Size: 1000, Files: 1, LastModified: "2021-04-01T10:00:00.000Z"}, usage["repo/d"])
+}
+
+func TestUsageAggregatorRoot(t *testing.T) {
+	aggregator := newUsageAggregator(1)
+	aggregator.add("", createTestItem("repo1", "a", "a.txt", 10, "", ""))
+	aggregator.add("", createTestItem("repo2", ".", "b.txt", 20, "", ""))
+	usage := getUsageByPath(aggregator.folders())
+	assert.Len(t, usage, 3)
+	assert.Equal(t, int64(30), usage[rootPath].Size)
+	assert.Equal(t, int64(10), usage["repo1"].Size)
+	assert.Equal(t, int64(20), usage["repo2"].Size)
+
+	// Depth zero reports only the base folder. Files outside of it are ignored.
+	aggregator = newUsageAggregator(0)
+	aggregator.add("repo/a", createTestItem("repo", "a/b", "b.txt", 10, "", ""))
+	aggregator.add("repo/a", createTestItem("repo", "ab", "ab.txt", 20, "", ""))
+	usage = getUsageByPath(aggregator.folders())
+	assert.Len(t, usage, 1)
+	assert.Equal(t, int64(10), usage["repo/a"].Size)
+}
+
+func TestSortFolders(t *testing.T) {
+	aggregator := newUsageAggregator(1)
+	aggregator.add("repo", createTestItem("repo", "a", "1", 30, "2021-01-01T10:00:00.000Z", ""))
+	aggregator.add("repo", createTestItem("repo", "b", "1", 10, "2021-03-01T10:00:00.000Z", ""))
+	aggregator.add("repo", createTestItem("repo", "b", "2", 10, "2021-02-01T10:00:00.000Z", ""))
+	aggregator.add("repo", createTestItem("repo", "c", "1", 30, "2021-02-01T10:00:00.000Z", ""))
+	folders := aggregator.folders()
+
+	getPaths := func() []string {
+		var paths []string
+		for _, folder := range folders {
+			paths = append(paths, folder.Path)
+		}
+		return paths
+	}
+	sortFolders(folders, SortBySize, false)
+	assert.Equal(t, []string{"repo", "repo/c", "repo/a", "repo/b"}, getPaths())
+	sortFolders(folders, SortByFiles, true)
+	assert.Equal(t, []string{"repo/a", "repo/c", "repo/b", "repo"}, getPaths())
+	sortFolders(folders, SortByPath, true)
+	assert.Equal(t, []string{"repo", "repo/a", "repo/b", "repo/c"}, getPaths())
+	sortFolders(folders, SortByModified, false)
+	assert.Equal(t, []string{"repo/b", "repo", "repo/c", "repo/a"}, getPaths())
+}
+
+func TestParseSortBy(t *testing.T) {
+	sortBy, err := ParseSortBy("")
+	assert.NoError(t, err)
+	assert.Equal(t, SortBySize, sortBy)
+	sortBy, err = ParseSortBy("Downloaded")
+	assert.NoError(t, err)
+	assert.Equal(t, SortByDownloaded, sortBy)
+	_, err = ParseSortBy("name")
+	assert.Error(t, err)
+}
@@ -0,0 +1,316 @@
+package list
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	rtutils "github.com/jfrog/jfrog-cli/artifactory/utils"
+	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-cli/utils/output"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	folderType = "folder"
+	timeFormat = "2006-01-02 15:04"
+)
+
+// Lists the files and folders under a path in Artifactory, like the ls command.
+// When recursive, the content of the sub-folders is printed as a tree, like the tree command.
+type ListCommand struct {
+	serverDetails *config.ServerDetails
+	target        string
+	recursive     bool
+	depth         int
+	humanReadable bool
+	showProps     bool
+	count         int
+}
+
+func NewListCommand() *ListCommand {
+	return &ListCommand{}
+}
+
+func (lc *ListCommand) SetServerDetails(serverDetails *config.ServerDetails) *ListCommand {
+	lc.serverDetails = serverDetails
+	return lc
+}
+
+// The path to list, in the following format: <repository name>/<repository path>.
+func (lc *ListCommand) SetTarget(target string) *ListCommand {
+	lc.target = target
+	return lc
+}
+
+func (lc *ListCommand) SetRecursive(recursive bool) *ListCommand {
+	lc.recursive = recursive
+	return lc
+}
+
+// The number of folder levels to list when recursive. Zero means no limit.
+func (lc *ListCommand) SetDepth(depth int) *ListCommand {
+	lc.depth = depth
+	return lc
+}
+
+func (lc *ListCommand) SetHumanReadable(humanReadable bool) *ListCommand {
+	lc.humanReadable = humanReadable
+	return lc
+}
+
+func (lc *ListCommand) SetShowProps(showProps bool) *ListCommand {
+	lc.showProps = showProps
+	return lc
+}
+
+func (lc *ListCommand) ServerDetails() (*config.ServerDetails, error) {
+	return lc.serverDetails, nil
+}
+
+func (lc *ListCommand) CommandName() string {
+	return "rt_ls"
+}
+
+// Returns the number of files and folders listed by the last run of the command.
+func (lc *ListCommand) Count() int {
+	return lc.count
+}
+
+func (lc *ListCommand) Run() error {
+	repo, folderPath := rtutils.SplitRepoPath(lc.target)
+	if repo == "" {
+		return errorutils.CheckErrorf("the path to list should start with a repository name")
+	}
+	servicesManager, err := utils.CreateServiceManager(lc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	items, err := lc.searchItems(servicesManager, repo, folderPath)
+	if err != nil {
+		return err
+	}
+	depth := 1
+	if lc.recursive {
+		depth = lc.depth
+	}
+	root := buildTree(items, repo, folderPath, depth)
+	var rows []row
+	if lc.recursive {
+		rows = getTreeRows(root, "", lc.humanReadable, lc.showProps)
+	} else {
+		rows = getListRows(root, lc.humanReadable, lc.showProps)
+	}
+	lc.count = len(rows)
+	if lc.recursive {
+		log.Output(strings.TrimSuffix(path.Join(repo, folderPath), "/") + "/")
+	}
+	return printRows(rows, lc.showProps)
+}
+
+func (lc *ListCommand) searchItems(servicesManager artifactory.ArtifactoryServicesManager, repo, folderPath string) ([]*servicesutils.ResultItem, error) {
+	aqlBody, err := createAqlBody(repo, folderPath, lc.recursive)
+	if err != nil {
+		return nil, err
+	}
+	includeFields := `"repo","path","name","type","size","modified"`
+	if lc.showProps {
+		includeFields += `,"property"`
+	}
+	query := fmt.Sprintf(`items.find(%s).include(%s)`, aqlBody, includeFields)
+	reader, err := servicesutils.ExecAqlSaveToFile(query, rtutils.NewSearchConf(servicesManager))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	var items []*servicesutils.ResultItem
+	for item := new(servicesutils.ResultItem); reader.NextRecord(item) == nil; item = new(servicesutils.ResultItem) {
+		items = append(items, item)
+	}
+	return items, reader.GetError()
+}
+
+// Creates the body of an AQL query, which finds the files and folders in a folder, or in its entire subtree when recursive.
+// If the path is of a file, the file itself is found.
+func createAqlBody(repo, folderPath string, recursive bool) (string, error) {
+	var pathConditions []interface{}
+	if folderPath == "" {
+		if recursive {
+			pathConditions = append(pathConditions, map[string]interface{}{"path": map[string]string{"$match": "*"}})
+		} else {
+			pathConditions = append(pathConditions, map[string]interface{}{"path": "."})
+		}
+	} else {
+		pathConditions = append(pathConditions, map[string]interface{}{"path": folderPath})
+		if recursive {
+			pathConditions = append(pathConditions, map[string]interface{}{"path": map[string]string{"$match": folderPath + "/*"}})
+		}
+		parent, name := path.Split(folderPath)
+		if parent = strings.TrimSuffix(parent, "/"); parent == "" {
+			parent = "."
+		}
+		pathConditions = append(pathConditions, map[string]interface{}{"path": parent, "name": name})
+	}
+	body, err := json.Marshal(map[string]interface{}{"repo": repo, "type": "any", "$or": pathConditions})
+	return string(body), errorutils.CheckError(err)
+}
+
+// A file or folder in the listed tree.
+type node struct {
+	name     string
+	item     *servicesutils.ResultItem
+	children map[string]*node
+}
+
+func newNode(name string) *node {
+	return &node{name: name, children: make(map[string]*node)}
+}
+
+func (n *node) isFolder() bool {
+	return n.item == nil || n.item.Type == folderType
+}
+
+func (n *node) getSortedChildren() []*node {
+	var children []*node
+	for _, child := range n.children {
+		children = append(children, child)
+	}
+	sort.Slice(children, func(i, j int) bool {
+		return children[i].name < children[j].name
+	})
+	return children
+}
+
+// Builds the tree of the items under the listed folder, up to the depth. Zero depth means no limit.
+// If the listed path is of a file, the tree includes only the file.
+func buildTree(items []*servicesutils.ResultItem, repo, folderPath string, depth int) *node {
+	root := newNode("")
+	listedPath := strings.TrimSuffix(path.Join(repo, folderPath), "/")
+	for _, item := range items {
+		itemPath := strings.TrimSuffix(item.GetItemRelativePath(), "/")
+		if itemPath == listedPath {
+			if item.Type != folderType {
+				root.children[item.Name] = &node{name: item.Name, item: item, children: make(map[string]*node)}
+			}
+			continue
+		}
+		parts := strings.Split(strings.TrimPrefix(itemPath, listedPath+"/"), "/")
+		if depth > 0 && len(parts) > depth {
+			continue
+		}
+		current := root
+		for _, part := range parts {
+			child, ok := current.children[part]
+			if !ok {
+				child = newNode(part)
+				current.children[part] = child
+			}
+			current = child
+		}
+		current.item = item
+	}
+	return root
+}
+
+// A printed line of the listing.
+type row struct {
+	itemType string
+	size     string
+	modified string
+	name     string
+	props    string
+}
+
+func getListRows(root *node, humanReadable, showProps bool) []row {
+	var rows []row
+	for _, child := range root.getSortedChildren() {
+		rows = append(rows, toRow(child, child.name, humanReadable, showProps))
+	}
+	return rows
+}
+
+// Returns the rows of the tree under the node. The prefix is added to the names of the children, to draw the tree.
+func getTreeRows(parent *node, prefix string, humanReadable, showProps bool) []row {
+	var rows []row
+	children := parent.getSortedChildren()
+	for i, child := range children {
+		connector, childPrefix := "├── ", "│   "
+		if i == len(children)-1 {
+			connector, childPrefix = "└── ", "    "
+		}
+		rows = append(rows, toRow(child, prefix+connector+child.name, humanReadable, showProps))
+		rows = append(rows, getTreeRows(child, prefix+childPrefix, humanReadable, showProps)...)
+	}
+	return rows
+}
+
+func toRow(n *node, name string, humanReadable, showProps bool) row {
+	r := row{itemType: "-", size: "-", modified: "-", name: name}
+	if n.isFolder() {
+		r.itemType = "d"
+		r.name += "/"
+	} else if humanReadable {
+		r.size = cliutils.FormatSize(n.item.Size)
+	} else {
+		r.size = strconv.FormatInt(n.item.Size, 10)
+	}
+	if n.item != nil {
+		if modified, err := time.Parse(time.RFC3339, n.item.Modified); err == nil {
+			r.modified = modified.Format(timeFormat)
+		}
+		if showProps {
+			r.props = formatProps(n.item.Properties)
+		}
+	}
+	return r
+}
+
+// Formats properties in the form of "key1=value1,value2;key2=value3", sorted by their keys.
+func formatProps(properties []servicesutils.Property) string {
+	values := make(map[string][]string)
+	var keys []string
+	for _, property := range properties {
+		if _, ok := values[property.Key]; !ok {
+			keys = append(keys, property.Key)
+		}
+		values[property.Key] = append(values[property.Key], property.Value)
+	}
+	sort.Strings(keys)
+	var formatted []string
+	for _, key := range keys {
+		sort.Strings(values[key])
+		formatted = append(formatted, key+"="+strings.Join(values[key], ","))
+	}
+	return strings.Join(formatted, ";")
+}
+
+func printRows(rows []row, showProps bool) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	// Sizes are aligned to the right, like in the output of the ls command.
+	sizeWidth := 0
+	for _, r := range rows {
+		if len(r.size) > sizeWidth {
+			sizeWidth = len(r.size)
+		}
+	}
+	var cells [][]string
+	for _, r := range rows {
+		rowCells := []string{r.itemType, fmt.Sprintf("%*s", sizeWidth, r.size), r.modified, r.name}
+		if showProps {
+			rowCells = append(rowCells, r.props)
+		}
+		cells = append(cells, rowCells)
+	}
+	return output.PrintTable(nil, cells)
+}
@@ -0,0 +1,69 @@
+package list
+
+import (
+	"testing"
+
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateAqlBody(t *testing.T) {
+	body, err := createAqlBody("repo", "", false)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"$or":[{"path":"."}],"repo":"repo","type":"any"}`, body)
+	body, err = createAqlBody("repo", "", true)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"$or":[{"path":{"$match":"*"}}],"repo":"repo","type":"any"}`, body)
+	body, err = createAqlBody("repo", "a/b", true)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"$or":[{"path":"a/b"},{"path":{"$match":"a/b/*"}},{"name":"b","path":"a"}],"repo":"repo","type":"any"}`, body)
+	body, err = createAqlBody("repo", "a", false)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"$or":[{"path":"a"},{"name":"a","path":"."}],"repo":"repo","type":"any"}`, body)
+}
+
+var testItems = []*servicesutils.ResultItem{
+	// When listing repo/a, the folder itself is returned, but isn't printed.
+	{Repo: "repo", Path: ".", Name: "a", Type: "folder", Modified: "2021-03-01T10:00:00.000Z"},
+	{Repo: "repo", Path: "a", Name: "b", Type: "folder"},
+	{Repo: "repo", Path: "a/b", Name: "deep.txt", Type: "file", Size: 1},
+	{Repo: "repo", Path: "a", Name: "file.jar", Type: "file", Size: 2048, Modified: "2021-03-01T10:00:00.000Z",
+		Properties: []servicesutils.Property{{Key: "b", Value: "2"}, {Key: "a", Value: "y"}, {Key: "a", Value: "x"}}},
+}
+
+func getNames(rows []row) []string {
+	var names []string
+	for _, r := range rows {
+		names = append(names, r.name)
+	}
+	return names
+}
+
+func TestListRows(t *testing.T) {
+	rows := getListRows(buildTree(testItems, "repo", "a", 1), false, true)
+	assert.Equal(t, []row{
+		{itemType: "d", size: "-", modified: "-", name: "b/"},
+		{itemType: "-", size: "2048", modified: "2021-03-01 10:00", name: "file.jar", props: "a=x,y;b=2"},
+	}, rows)
+	rows = getListRows(buildTree(testItems, "repo", "a", 1), true, false)
+	assert.Equal(t, "2.0KB", rows[1].size)
+	assert.Empty(t, rows[1].props)
+
+	// Listing a file shows the file itself.
+	rows = getListRows(buildTree(testItems[3:4], "repo", "a/file.jar", 1), false, false)
+	assert.Equal(t, []string{"file.jar"}, getNames(rows))
+}
+
+func TestTreeRows(t *testing.T) {
+	rows := getTreeRows(buildTree(testItems, "repo", "", 0), "", false, false)
+	assert.Equal(t, []string{
+		"└── a/",
+		"    ├── b/",
+		"    │   └── deep.txt",
+		"    └── file.jar",
+	}, getNames(rows))
+
+	// The depth limits the levels of the tree.
+	rows = getTreeRows(buildTree(testItems, "repo", "", 2), "", false, false)
+	assert.Equal(t, []string{"└── a/", "    ├── b/", "    └── file.jar"}, getNames(rows))
+}
@@ -0,0 +1,88 @@
+package replication
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli/utils/output"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// A replication, as printed by the replication-list command.
+type ReplicationListItem struct {
+	RepoKey                string `json:"repoKey"`
+	Type                   string `json:"replicationType"`
+	Url                    string `json:"url,omitempty"`
+	CronExp                string `json:"cronExp,omitempty"`
+	Enabled                bool   `json:"enabled"`
+	EnableEventReplication bool   `json:"enableEventReplication"`
+}
+
+// Lists the replications configured in Artifactory.
+type ReplicationListCommand struct {
+	serverDetails *config.ServerDetails
+	format        Format
+}
+
+func NewReplicationListCommand() *ReplicationListCommand {
+	return &ReplicationListCommand{format: Json}
+}
+
+func (rlc *ReplicationListCommand) SetServerDetails(serverDetails *config.ServerDetails) *ReplicationListCommand {
+	rlc.serverDetails = serverDetails
+	return rlc
+}
+
+func (rlc *ReplicationListCommand) SetFormat(format Format) *ReplicationListCommand {
+	rlc.format = format
+	return rlc
+}
+
+func (rlc *ReplicationListCommand) ServerDetails() (*config.ServerDetails, error) {
+	return rlc.serverDetails, nil
+}
+
+func (rlc *ReplicationListCommand) CommandName() string {
+	return "rt_replication_list"
+}
+
+func (rlc *ReplicationListCommand) Run() error {
+	servicesManager, err := utils.CreateServiceManager(rlc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	serviceDetails := servicesManager.GetConfig().GetServiceDetails()
+	httpClientsDetails := serviceDetails.CreateHttpClientDetails()
+	resp, body, _, err := servicesManager.Client().SendGet(serviceDetails.GetUrl()+"api/replications", true, &httpClientsDetails)
+	if err != nil {
+		return err
+	}
+	if err = errorutils.CheckResponseStatus(resp, http.StatusOK); err != nil {
+		return errorutils.CheckError(errorutils.GenerateResponseError(resp.Status, clientutils.IndentJson(body)))
+	}
+	items := []ReplicationListItem{}
+	if err = json.Unmarshal(body, &items); err != nil {
+		return errorutils.CheckError(err)
+	}
+	if rlc.format == Table {
+		return output.PrintTable(getListHeader(), getListRows(items))
+	}
+	return output.PrintJson(items)
+}
+
+func getListHeader() []string {
+	return []string{"REPO KEY", "TYPE", "URL", "CRON", "ENABLED", "EVENT BASED"}
+}
+
+func getListRows(items []ReplicationListItem) [][]string {
+	var rows [][]string
+	for _, item := range items {
+		rows = append(rows, []string{item.RepoKey, output.FormatCell(item.Type), output.FormatCell(item.Url), output.FormatCell(item.CronExp),
+			strconv.FormatBool(item.Enabled), strconv.FormatBool(item.EnableEventReplication)})
+	}
+	return rows
+}
@@ -0,0 +1,17 @@
+package replication
+
+import (
+	"github.com/jfrog/jfrog-cli/utils/output"
+)
+
+type Format = output.Format
+
+const (
+	Json  = output.Json
+	Table = output.Table
+)
+
+// Returns the format matching the provided value. An empty value is the json format.
+func ParseFormat(value string) (Format, error) {
+	return output.ParseFormat(value, "format", Json, Table)
+}
@@ -0,0 +1,195 @@
+package replication
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli/utils/output"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Statuses of a replication, as returned by Artifactory.
+const (
+	StatusOk         = "ok"
+	StatusInProgress = "inprogress"
+)
+
+const defaultPollInterval = 5 * time.Second
+
+// The status of the replication of a repository to a single target.
+type TargetStatus struct {
+	Url           string `json:"url"`
+	RepoKey       string `json:"repoKey,omitempty"`
+	Status        string `json:"status"`
+	LastCompleted string `json:"lastCompleted,omitempty"`
+}
+
+// The status of the last replication of a repository.
+type ReplicationStatus struct {
+	RepoKey       string         `json:"repoKey"`
+	Status        string         `json:"status"`
+	LastCompleted string         `json:"lastCompleted,omitempty"`
+	Targets       []TargetStatus `json:"targets,omitempty"`
+}
+
+// Prints the status of the last replication of a repository.
+// If run is set, a replication is triggered first, and the status is printed once it's finished.
+type ReplicationStatusCommand struct {
+	serverDetails *config.ServerDetails
+	repoKey       string
+	format        Format
+	run           bool
+	timeout       time.Duration
+	pollInterval  time.Duration
+	status        *ReplicationStatus
+}
+
+func NewReplicationStatusCommand() *ReplicationStatusCommand {
+	return &ReplicationStatusCommand{format: Json, pollInterval: defaultPollInterval}
+}
+
+func (rsc *ReplicationStatusCommand) SetServerDetails(serverDetails *config.ServerDetails) *ReplicationStatusCommand {
+	rsc.serverDetails = serverDetails
+	return rsc
+}
+
+func (rsc *ReplicationStatusCommand) SetRepoKey(repoKey string) *ReplicationStatusCommand {
+	rsc.repoKey = repoKey
+	return rsc
+}
+
+func (rsc *ReplicationStatusCommand) SetFormat(format Format) *ReplicationStatusCommand {
+	rsc.format = format
+	return rsc
+}
+
+func (rsc *ReplicationStatusCommand) SetRun(run bool) *ReplicationStatusCommand {
+	rsc.run = run
+	return rsc
+}
+
+// The maximum time to wait for a triggered replication to finish. Zero means no limit.
+func (rsc *ReplicationStatusCommand) SetTimeout(timeout time.Duration) *ReplicationStatusCommand {
+	rsc.timeout = timeout
+	return rsc
+}
+
+func (rsc *ReplicationStatusCommand) ServerDetails() (*config.ServerDetails, error) {
+	return rsc.serverDetails, nil
+}
+
+func (rsc *ReplicationStatusCommand) CommandName() string {
+	return "rt_replication_status"
+}
+
+// Returns the status printed by the last run of the command.
+func (rsc *ReplicationStatusCommand) Status() *ReplicationStatus {
+	return rsc.status
+}
+
+func (rsc *ReplicationStatusCommand) Run() (err error) {
+	servicesManager, err := utils.CreateServiceManager(rsc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	rsc.status, err = getStatus(servicesManager, rsc.repoKey)
+	if err != nil {
+		return err
+	}
+	if rsc.run {
+		if err = triggerReplication(servicesManager, rsc.repoKey); err != nil {
+			return err
+		}
+		rsc.status, err = rsc.waitForReplication(rsc.status, func() (*ReplicationStatus, error) {
+			return getStatus(servicesManager, rsc.repoKey)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if rsc.format == Table {
+		err = output.PrintTable(getStatusHeader(), getStatusRows(rsc.status))
+	} else {
+		err = output.PrintJson(rsc.status)
+	}
+	if err == nil && rsc.run && rsc.status.Status != StatusOk {
+		err = errorutils.CheckErrorf("the replication of %s finished with status: %s", rsc.repoKey, rsc.status.Status)
+	}
+	return err
+}
+
+func (rsc *ReplicationStatusCommand) waitForReplication(before *ReplicationStatus, getCurrentStatus func() (*ReplicationStatus, error)) (*ReplicationStatus, error) {
+	// The status may not change to in progress right after the replication is triggered, and a short replication may finish
+	// between two checks. The replication is therefore finished once it was seen in progress or its completion time changed.
+	log.Info("Waiting for the replication of " + rsc.repoKey + " to finish...")
+	start := time.Now()
+	inProgressSeen := false
+	for {
+		time.Sleep(rsc.pollInterval)
+		current, err := getCurrentStatus()
+		if err != nil {
+			return nil, err
+		}
+		if current.Status == StatusInProgress {
+			inProgressSeen = true
+		} else if inProgressSeen || current.LastCompleted != before.LastCompleted {
+			return current, nil
+		}
+		if rsc.timeout > 0 && time.Since(start) > rsc.timeout {
+			return nil, errorutils.CheckErrorf("the replication of %s didn't finish within %s. Its current status is: %s", rsc.repoKey, rsc.timeout, current.Status)
+		}
+	}
+}
+
+func getStatus(servicesManager artifactory.ArtifactoryServicesManager, repoKey string) (*ReplicationStatus, error) {
+	serviceDetails := servicesManager.GetConfig().GetServiceDetails()
+	httpClientsDetails := serviceDetails.CreateHttpClientDetails()
+	resp, body, _, err := servicesManager.Client().SendGet(serviceDetails.GetUrl()+"api/replication/"+repoKey, true, &httpClientsDetails)
+	if err != nil {
+		return nil, err
+	}
+	if err = errorutils.CheckResponseStatus(resp, http.StatusOK); err != nil {
+		return nil, errorutils.CheckError(errorutils.GenerateResponseError(resp.Status, clientutils.IndentJson(body)))
+	}
+	status := &ReplicationStatus{}
+	if err = json.Unmarshal(body, status); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	// The response doesn't include the repository key.
+	status.RepoKey = repoKey
+	return status, nil
+}
+
+func triggerReplication(servicesManager artifactory.ArtifactoryServicesManager, repoKey string) error {
+	log.Info("Triggering the replication of " + repoKey + "...")
+	serviceDetails := servicesManager.GetConfig().GetServiceDetails()
+	httpClientsDetails := serviceDetails.CreateHttpClientDetails()
+	resp, body, err := servicesManager.Client().SendPost(serviceDetails.GetUrl()+"api/replication/execute/"+repoKey, nil, &httpClientsDetails)
+	if err != nil {
+		return err
+	}
+	if err = errorutils.CheckResponseStatus(resp, http.StatusOK, http.StatusAccepted); err != nil {
+		return errorutils.CheckError(errorutils.GenerateResponseError(resp.Status, clientutils.IndentJson(body)))
+	}
+	log.Debug("Artifactory response:", resp.Status)
+	return nil
+}
+
+func getStatusHeader() []string {
+	return []string{"REPO KEY", "TARGET", "STATUS", "LAST COMPLETED"}
+}
+
+// The first row is the status of the repository, followed by a row for each of its targets.
+func getStatusRows(status *ReplicationStatus) [][]string {
+	rows := [][]string{{status.RepoKey, "-", output.FormatCell(status.Status), output.FormatCell(status.LastCompleted)}}
+	for _, target := range status.Targets {
+		rows = append(rows, []string{output.FormatCell(target.RepoKey), output.FormatCell(target.Url), output.FormatCell(target.Status), output.FormatCell(target.LastCompleted)})
+	}
+	return rows
+}
@@ -0,0 +1,70 @@
+package replication
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWaitForReplication(t *testing.T) {
+	before := &ReplicationStatus{RepoKey: "repo", Status: "failure", LastCompleted: "2021-06-01T10:00:00.000Z"}
+	tests := []struct {
+		name     string
+		statuses []*ReplicationStatus
+		expected int
+	}{
+		// The status of the previous replication is returned until the triggered replication starts.
+		{"inProgress", []*ReplicationStatus{before, {Status: StatusInProgress}, {Status: StatusOk, LastCompleted: "2021-06-02T10:00:00.000Z"}}, 2},
+		{"failedAgain", []*ReplicationStatus{{Status: StatusInProgress}, {Status: "failure", LastCompleted: before.LastCompleted}}, 1},
+		{"finishedBetweenChecks", []*ReplicationStatus{before, {Status: StatusOk, LastCompleted: "2021-06-02T10:00:00.000Z"}}, 1},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			calls := 0
+			command := NewReplicationStatusCommand().SetRepoKey("repo")
+			command.pollInterval = time.Millisecond
+			status, err := command.waitForReplication(before, func() (*ReplicationStatus, error) {
+				calls++
+				return test.statuses[calls-1], nil
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, test.statuses[test.expected], status)
+			assert.Equal(t, test.expected+1, calls)
+		})
+	}
+}
+
+func TestWaitForReplicationTimeout(t *testing.T) {
+	before := &ReplicationStatus{RepoKey: "repo", Status: StatusOk}
+	command := NewReplicationStatusCommand().SetRepoKey("repo").SetTimeout(5 * time.Millisecond)
+	command.pollInterval = time.Millisecond
+	_, err := command.waitForReplication(before, func() (*ReplicationStatus, error) {
+		return &ReplicationStatus{Status: StatusInProgress}, nil
+	})
+	assert.Error(t, err)
+}
+
+func TestGetStatusRows(t *testing.T) {
+	status := &ReplicationStatus{RepoKey: "repo", Status: StatusOk, LastCompleted: "2021-06-02T10:00:00.000Z", Targets: []TargetStatus{
+		{Url: "https://dr.example.com/artifactory/repo", RepoKey: "repo", Status: StatusOk, LastCompleted: "2021-06-02T10:00:00.000Z"},
+		{Url: "https://backup.example.com/artifactory/repo", Status: "never_run"},
+	}}
+	expected := [][]string{
+		{"repo", "-", StatusOk, "2021-06-02T10:00:00.000Z"},
+		{"repo", "https://dr.example.com/artifactory/repo", StatusOk, "2021-06-02T10:00:00.000Z"},
+		{"-", "https://backup.example.com/artifactory/repo", "never_run", "-"},
+	}
+	assert.Equal(t, expected, getStatusRows(status))
+}
+
+func TestParseFormat(t *testing.T) {
+	format, err := ParseFormat("")
+	assert.NoError(t, err)
+	assert.Equal(t, Json, format)
+	format, err = ParseFormat("TABLE")
+	assert.NoError(t, err)
+	assert.Equal(t, Table, format)
+	_, err = ParseFormat("csv")
+	assert.Error(t, err)
+}
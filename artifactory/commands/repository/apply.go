@@ -0,0 +1,308 @@
+package repository
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	corerepository "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/repository"
+	commandsutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+type ChangeType string
+
+const (
+	Create ChangeType = "create"
+	Update ChangeType = "update"
+	Delete ChangeType = "delete"
+)
+
+var changeSymbols = map[ChangeType]string{
+	Create: "+",
+	Update: "~",
+	Delete: "-",
+}
+
+// Fields which are never compared, since Artifactory doesn't return their actual values.
+var ignoredFields = map[string]bool{
+	corerepository.Key:      true,
+	corerepository.Password: true,
+}
+
+// Local and remote repositories are created before the virtual repositories which may include them, and deleted after them.
+var rclassOrder = map[string]int{
+	corerepository.Local:   0,
+	corerepository.Remote:  1,
+	corerepository.Virtual: 2,
+}
+
+// A change of a single field of a repository.
+type FieldChange struct {
+	Field   string
+	Current string
+	Desired string
+}
+
+// A change of a single repository, which is required to make Artifactory match the templates.
+type RepoChange struct {
+	Type         ChangeType
+	Key          string
+	Rclass       string
+	TemplatePath string
+	Fields       []FieldChange
+}
+
+// A repository template read from the templates directory.
+type repoTemplate struct {
+	path   string
+	config map[string]interface{}
+}
+
+// Makes the repositories in Artifactory match a directory of repository templates, as created by the repo-template command.
+// The changes are printed as a plan before they are applied.
+// Repositories which don't have a template are deleted only if deleteMissing is set.
+type RepoApplyCommand struct {
+	serverDetails *config.ServerDetails
+	templatesDir  string
+	vars          string
+	deleteMissing bool
+	dryRun        bool
+	quiet         bool
+	plan          []RepoChange
+}
+
+func NewRepoApplyCommand() *RepoApplyCommand {
+	return &RepoApplyCommand{}
+}
+
+func (rac *RepoApplyCommand) SetServerDetails(serverDetails *config.ServerDetails) *RepoApplyCommand {
+	rac.serverDetails = serverDetails
+	return rac
+}
+
+func (rac *RepoApplyCommand) SetTemplatesDir(templatesDir string) *RepoApplyCommand {
+	rac.templatesDir = templatesDir
+	return rac
+}
+
+func (rac *RepoApplyCommand) SetVars(vars string) *RepoApplyCommand {
+	rac.vars = vars
+	return rac
+}
+
+func (rac *RepoApplyCommand) SetDeleteMissing(deleteMissing bool) *RepoApplyCommand {
+	rac.deleteMissing = deleteMissing
+	return rac
+}
+
+func (rac *RepoApplyCommand) SetDryRun(dryRun bool) *RepoApplyCommand {
+	rac.dryRun = dryRun
+	return rac
+}
+
+func (rac *RepoApplyCommand) SetQuiet(quiet bool) *RepoApplyCommand {
+	rac.quiet = quiet
+	return rac
+}
+
+func (rac *RepoApplyCommand) ServerDetails() (*config.ServerDetails, error) {
+	return rac.serverDetails, nil
+}
+
+func (rac *RepoApplyCommand) CommandName() string {
+	return "rt_repo_apply"
+}
+
+// Returns the changes found by the last run of the command.
+func (rac *RepoApplyCommand) Plan() []RepoChange {
+	return rac.plan
+}
+
+func (rac *RepoApplyCommand) Run() error {
+	templates, err := rac.readTemplates()
+	if err != nil {
+		return err
+	}
+	servicesManager, err := utils.CreateServiceManager(rac.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	repos, err := servicesManager.GetAllRepositories()
+	if err != nil {
+		return err
+	}
+	// The configuration of an existing repository is fetched only if it has a template. The listed details are enough for deleting it.
+	liveConfigs := make(map[string]map[string]interface{})
+	for _, repo := range *repos {
+		liveConfig := map[string]interface{}{corerepository.Rclass: strings.ToLower(repo.GetRepoType()), corerepository.PackageType: repo.PackageType}
+		if _, ok := templates[repo.Key]; ok {
+			if err = servicesManager.GetRepository(repo.Key, &liveConfig); err != nil {
+				return err
+			}
+		}
+		liveConfigs[repo.Key] = liveConfig
+	}
+	rac.plan = createPlan(templates, liveConfigs, rac.deleteMissing)
+	printPlan(rac.plan)
+	if len(rac.plan) == 0 || rac.dryRun {
+		return nil
+	}
+	if !rac.quiet && !coreutils.AskYesNo("Are you sure you want to apply the above changes?", false) {
+		return nil
+	}
+	return rac.applyPlan(servicesManager)
+}
+
+// Reads the JSON templates in the templates directory, mapped by their repository keys.
+func (rac *RepoApplyCommand) readTemplates() (map[string]*repoTemplate, error) {
+	files, err := ioutil.ReadDir(rac.templatesDir)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	templates := make(map[string]*repoTemplate)
+	for _, file := range files {
+		if file.IsDir() || strings.ToLower(filepath.Ext(file.Name())) != ".json" {
+			continue
+		}
+		templatePath := filepath.Join(rac.templatesDir, file.Name())
+		templateConfig, err := commandsutils.ConvertTemplateToMap(corerepository.NewRepoCreateCommand().SetTemplatePath(templatePath).SetVars(rac.vars))
+		if err != nil {
+			return nil, err
+		}
+		key, ok := templateConfig[corerepository.Key].(string)
+		if !ok || key == "" {
+			return nil, errorutils.CheckErrorf("the template %s doesn't include a repository key", templatePath)
+		}
+		if existing, ok := templates[key]; ok {
+			return nil, errorutils.CheckErrorf("the templates %s and %s are of the same repository: %s", existing.path, templatePath, key)
+		}
+		templates[key] = &repoTemplate{path: templatePath, config: templateConfig}
+	}
+	return templates, nil
+}
+
+// Compares the templates with the configurations of the existing repositories, and returns the required changes.
+// The values of the templates are strings, so the values of the existing configurations are compared as strings too.
+func createPlan(templates map[string]*repoTemplate, liveConfigs map[string]map[string]interface{}, deleteMissing bool) []RepoChange {
+	var plan []RepoChange
+	for key, template := range templates {
+		rclass := fmt.Sprint(template.config[corerepository.Rclass])
+		liveConfig, ok := liveConfigs[key]
+		if !ok {
+			plan = append(plan, RepoChange{Type: Create, Key: key, Rclass: rclass, TemplatePath: template.path})
+			continue
+		}
+		var fields []FieldChange
+		for field, value := range template.config {
+			if ignoredFields[field] {
+				continue
+			}
+			desired := fmt.Sprint(value)
+			current := formatLiveValue(liveConfig[field])
+			if desired != current {
+				fields = append(fields, FieldChange{Field: field, Current: current, Desired: desired})
+			}
+		}
+		if len(fields) > 0 {
+			sort.Slice(fields, func(i, j int) bool {
+				return fields[i].Field < fields[j].Field
+			})
+			plan = append(plan, RepoChange{Type: Update, Key: key, Rclass: rclass, TemplatePath: template.path, Fields: fields})
+		}
+	}
+	if deleteMissing {
+		for key, liveConfig := range liveConfigs {
+			if _, ok := templates[key]; !ok {
+				plan = append(plan, RepoChange{Type: Delete, Key: key, Rclass: fmt.Sprint(liveConfig[corerepository.Rclass])})
+			}
+		}
+	}
+	sortPlan(plan)
+	return plan
+}
+
+// Sorts the changes in the order they should be applied: creations, updates and then deletions.
+func sortPlan(plan []RepoChange) {
+	changeOrder := map[ChangeType]int{Create: 0, Update: 1, Delete: 2}
+	sort.Slice(plan, func(i, j int) bool {
+		if plan[i].Type != plan[j].Type {
+			return changeOrder[plan[i].Type] < changeOrder[plan[j].Type]
+		}
+		if plan[i].Rclass != plan[j].Rclass {
+			if plan[i].Type == Delete {
+				return rclassOrder[plan[i].Rclass] > rclassOrder[plan[j].Rclass]
+			}
+			return rclassOrder[plan[i].Rclass] < rclassOrder[plan[j].Rclass]
+		}
+		return plan[i].Key < plan[j].Key
+	})
+}
+
+// Formats a value of a repository configuration returned by Artifactory, the same way it's written in a template.
+func formatLiveValue(value interface{}) string {
+	switch typed := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return typed
+	case bool:
+		return strconv.FormatBool(typed)
+	case float64:
+		return strconv.FormatFloat(typed, 'f', -1, 64)
+	case []interface{}:
+		var values []string
+		for _, element := range typed {
+			values = append(values, formatLiveValue(element))
+		}
+		return strings.Join(values, ",")
+	default:
+		content, err := json.Marshal(typed)
+		if err != nil {
+			return fmt.Sprint(typed)
+		}
+		return string(content)
+	}
+}
+
+func printPlan(plan []RepoChange) {
+	counts := make(map[ChangeType]int)
+	for _, change := range plan {
+		counts[change.Type]++
+	}
+	log.Output(fmt.Sprintf("Plan: %d to create, %d to update, %d to delete.", counts[Create], counts[Update], counts[Delete]))
+	for _, change := range plan {
+		log.Output(fmt.Sprintf("  %s %s (%s)", changeSymbols[change.Type], change.Key, change.Rclass))
+		for _, field := range change.Fields {
+			log.Output(fmt.Sprintf("      %s: %q => %q", field.Field, field.Current, field.Desired))
+		}
+	}
+}
+
+func (rac *RepoApplyCommand) applyPlan(servicesManager artifactory.ArtifactoryServicesManager) error {
+	for _, change := range rac.plan {
+		var err error
+		switch change.Type {
+		case Create:
+			err = corerepository.NewRepoCreateCommand().SetTemplatePath(change.TemplatePath).SetVars(rac.vars).SetServerDetails(rac.serverDetails).Run()
+		case Update:
+			err = corerepository.NewRepoUpdateCommand().SetTemplatePath(change.TemplatePath).SetVars(rac.vars).SetServerDetails(rac.serverDetails).Run()
+		case Delete:
+			log.Info("Deleting repository " + change.Key + "...")
+			err = servicesManager.DeleteRepository(change.Key)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	coretests "github.com/jfrog/jfrog-cli-core/v2/utils/tests"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreatePlan(t *testing.T) {
+	templates := map[string]*repoTemplate{
+		"maven-local": {path: "maven-local.json", config: map[string]interface{}{
+			"key": "maven-local", "rclass": "local", "packageType": "maven", "handleSnapshots": "false", "maxUniqueSnapshots": "10",
+		}},
+		"npm-remote": {path: "npm-remote.json", config: map[string]interface{}{
+			"key": "npm-remote", "rclass": "remote", "packageType": "npm", "url": "https://registry.npmjs.org", "password": "secret",
+		}},
+		"maven-virtual": {path: "maven-virtual.json", config: map[string]interface{}{
+			"key": "maven-virtual", "rclass": "virtual", "packageType": "maven", "repositories": "maven-local,maven-remote",
+		}},
+		"npm-local": {path: "npm-local.json", config: map[string]interface{}{
+			"key": "npm-local", "rclass": "local", "packageType": "npm",
+		}},
+	}
+	liveConfigs := map[string]map[string]interface{}{
+		"maven-local": {"key": "maven-local", "rclass": "local", "packageType": "maven", "handleSnapshots": true, "maxUniqueSnapshots": float64(10)},
+		"npm-remote":  {"key": "npm-remote", "rclass": "remote", "packageType": "npm", "url": "https://registry.npmjs.org", "password": ""},
+		"old-virtual": {"rclass": "virtual", "packageType": "generic"},
+		"old-local":   {"rclass": "local", "packageType": "generic"},
+	}
+
+	plan := createPlan(templates, liveConfigs, false)
+	expected := []RepoChange{
+		{Type: Create, Key: "npm-local", Rclass: "local", TemplatePath: "npm-local.json"},
+		{Type: Create, Key: "maven-virtual", Rclass: "virtual", TemplatePath: "maven-virtual.json"},
+		{Type: Update, Key: "maven-local", Rclass: "local", TemplatePath: "maven-local.json", Fields: []FieldChange{
+			{Field: "handleSnapshots", Current: "true", Desired: "false"},
+		}},
+	}
+	assert.Equal(t, expected, plan)
+
+	// Repositories without templates are deleted only when requested, virtual repositories first.
+	plan = createPlan(templates, liveConfigs, true)
+	assert.Len(t, plan, 5)
+	assert.Equal(t, RepoChange{Type: Delete, Key: "old-virtual", Rclass: "virtual"}, plan[3])
+	assert.Equal(t, RepoChange{Type: Delete, Key: "old-local", Rclass: "local"}, plan[4])
+}
+
+func TestFormatLiveValue(t *testing.T) {
+	assert.Equal(t, "", formatLiveValue(nil))
+	assert.Equal(t, "value", formatLiveValue("value"))
+	assert.Equal(t, "true", formatLiveValue(true))
+	assert.Equal(t, "1440", formatLiveValue(float64(1440)))
+	assert.Equal(t, "a,b", formatLiveValue([]interface{}{"a", "b"}))
+	assert.Equal(t, `{"a":1}`, formatLiveValue(map[string]interface{}{"a": 1}))
+}
+
+func TestReadTemplates(t *testing.T) {
+	tempDir, cleanUp := coretests.CreateTempDirWithCallbackAndAssert(t)
+	defer cleanUp()
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(tempDir, "a.json"), []byte(`{"key":"${name}-local","rclass":"local","packageType":"generic"}`), 0644))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(tempDir, "README.md"), []byte("Not a template"), 0644))
+
+	templates, err := NewRepoApplyCommand().SetTemplatesDir(tempDir).SetVars("name=generic").readTemplates()
+	assert.NoError(t, err)
+	if assert.Contains(t, templates, "generic-local") {
+		assert.Equal(t, filepath.Join(tempDir, "a.json"), templates["generic-local"].path)
+	}
+	assert.Len(t, templates, 1)
+
+	// Two templates of the same repository are rejected.
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(tempDir, "b.json"), []byte(`{"key":"generic-local","rclass":"local","packageType":"generic"}`), 0644))
+	_, err = NewRepoApplyCommand().SetTemplatesDir(tempDir).SetVars("name=generic").readTemplates()
+	assert.Error(t, err)
+}
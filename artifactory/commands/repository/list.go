@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli/utils/output"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+)
+
+// A repository, as printed by the repo-list command.
+type RepoListItem struct {
+	Key         string `json:"key"`
+	Type        string `json:"type"`
+	PackageType string `json:"packageType"`
+	Url         string `json:"url,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// Lists the repositories in Artifactory, optionally filtered by their type and package type.
+type RepoListCommand struct {
+	serverDetails *config.ServerDetails
+	repoType      string
+	packageType   string
+}
+
+func NewRepoListCommand() *RepoListCommand {
+	return &RepoListCommand{}
+}
+
+func (rlc *RepoListCommand) SetServerDetails(serverDetails *config.ServerDetails) *RepoListCommand {
+	rlc.serverDetails = serverDetails
+	return rlc
+}
+
+// The type of the listed repositories: local, remote, virtual or federated.
+func (rlc *RepoListCommand) SetRepoType(repoType string) *RepoListCommand {
+	rlc.repoType = repoType
+	return rlc
+}
+
+// The package type of the listed repositories, such as maven or npm.
+func (rlc *RepoListCommand) SetPackageType(packageType string) *RepoListCommand {
+	rlc.packageType = packageType
+	return rlc
+}
+
+func (rlc *RepoListCommand) ServerDetails() (*config.ServerDetails, error) {
+	return rlc.serverDetails, nil
+}
+
+func (rlc *RepoListCommand) CommandName() string {
+	return "rt_repo_list"
+}
+
+func (rlc *RepoListCommand) Run() error {
+	servicesManager, err := utils.CreateServiceManager(rlc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	filterParams := services.NewRepositoriesFilterParams()
+	filterParams.RepoType = rlc.repoType
+	filterParams.PackageType = rlc.packageType
+	repos, err := servicesManager.GetAllRepositoriesFiltered(filterParams)
+	if err != nil {
+		return err
+	}
+	items := []RepoListItem{}
+	for _, repo := range *repos {
+		items = append(items, RepoListItem{Key: repo.Key, Type: repo.GetRepoType(), PackageType: repo.PackageType, Url: repo.Url, Description: repo.Description})
+	}
+	return output.PrintJson(items)
+}
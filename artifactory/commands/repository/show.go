@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli/utils/output"
+)
+
+// Prints the configuration of a repository, as returned by Artifactory.
+type RepoShowCommand struct {
+	serverDetails *config.ServerDetails
+	repoKey       string
+}
+
+func NewRepoShowCommand() *RepoShowCommand {
+	return &RepoShowCommand{}
+}
+
+func (rsc *RepoShowCommand) SetServerDetails(serverDetails *config.ServerDetails) *RepoShowCommand {
+	rsc.serverDetails = serverDetails
+	return rsc
+}
+
+func (rsc *RepoShowCommand) SetRepoKey(repoKey string) *RepoShowCommand {
+	rsc.repoKey = repoKey
+	return rsc
+}
+
+func (rsc *RepoShowCommand) ServerDetails() (*config.ServerDetails, error) {
+	return rsc.serverDetails, nil
+}
+
+func (rsc *RepoShowCommand) CommandName() string {
+	return "rt_repo_show"
+}
+
+func (rsc *RepoShowCommand) Run() error {
+	servicesManager, err := utils.CreateServiceManager(rsc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	repoConfig := make(map[string]interface{})
+	if err = servicesManager.GetRepository(rsc.repoKey, &repoConfig); err != nil {
+		return err
+	}
+	return output.PrintJson(repoConfig)
+}
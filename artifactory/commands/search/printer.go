@@ -0,0 +1,254 @@
+package search
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/jfrog/jfrog-cli/utils/output"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+type Format = output.Format
+
+const (
+	Json         = output.Json
+	Table        = output.Table
+	Csv          = output.Csv
+	Paths Format = "paths"
+
+	propsField       = "props"
+	propsFieldPrefix = propsField + "."
+)
+
+var Formats = []Format{Json, Table, Csv, Paths}
+
+// The fields of a search result, in the order they are printed by default.
+var Fields = []string{"path", "repo", "name", "type", "size", "created", "modified", "sha1", "md5", "sha256", propsField}
+
+var (
+	defaultFields      = []string{"path", "type", "size", "created", "modified", "sha1", "md5", "sha256", propsField}
+	defaultTableFields = []string{"path", "type", "size", "modified"}
+)
+
+// Returns the format matching the provided value. An empty value is the json format.
+func ParseFormat(value string) (Format, error) {
+	return output.ParseFormat(value, "format", Formats...)
+}
+
+// Parses a comma separated list of fields, such as "path,size,props.build.name".
+// A field starting with "props." selects the values of a single property.
+func ParseFields(value string) ([]string, error) {
+	var fields []string
+	for _, field := range strings.Split(value, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		if !isValidField(field) {
+			return nil, errorutils.CheckErrorf("'%s' is not a valid field. Valid fields are: %s, or %s<property key>", field, strings.Join(Fields, ", "), propsFieldPrefix)
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+func isValidField(field string) bool {
+	if strings.HasPrefix(field, propsFieldPrefix) {
+		return len(field) > len(propsFieldPrefix)
+	}
+	for _, valid := range Fields {
+		if field == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// Prints search results in one of the supported formats, or using a template.
+// The results are printed as they are added, except for the table format, which is printed once all results are added, so that its columns are aligned.
+type Printer struct {
+	format   Format
+	fields   []string
+	template *template.Template
+	count    int
+	rows     [][]string
+	// The last JSON object, which is printed once it's known whether it's followed by another object.
+	pendingJson string
+}
+
+func NewPrinter(format Format, fields []string) *Printer {
+	if len(fields) == 0 {
+		fields = defaultFields
+		if format == Table {
+			fields = defaultTableFields
+		}
+	}
+	return &Printer{format: format, fields: fields}
+}
+
+// Creates a printer which executes a Go template for each result.
+// The template is executed with a map of all fields, in which "props" maps each property key to its values.
+func NewTemplatePrinter(text string) (*Printer, error) {
+	tmpl, err := template.New("search").Option("missingkey=zero").Parse(text)
+	if err != nil {
+		return nil, errorutils.CheckErrorf("invalid template: %s", err.Error())
+	}
+	return &Printer{template: tmpl}, nil
+}
+
+func (p *Printer) Add(item *servicesutils.ResultItem) error {
+	p.count++
+	values := toValues(item)
+	if p.template != nil {
+		var buffer bytes.Buffer
+		if err := p.template.Execute(&buffer, values); err != nil {
+			return errorutils.CheckError(err)
+		}
+		log.Output(buffer.String())
+		return nil
+	}
+	switch p.format {
+	case Paths:
+		log.Output(values["path"])
+	case Csv:
+		if p.count == 1 {
+			if err := printCsvLine(p.fields); err != nil {
+				return err
+			}
+		}
+		return printCsvLine(p.formatFields(values))
+	case Table:
+		p.rows = append(p.rows, p.formatFields(values))
+	default:
+		return p.printJson(values)
+	}
+	return nil
+}
+
+// Completes the output once all results are added.
+func (p *Printer) Close() error {
+	if p.template != nil {
+		return nil
+	}
+	switch p.format {
+	case Table:
+		return p.printTable()
+	case Json:
+		if p.count == 0 {
+			log.Output("[]")
+		} else {
+			log.Output(p.pendingJson)
+			log.Output("]")
+		}
+	}
+	return nil
+}
+
+func (p *Printer) Count() int {
+	return p.count
+}
+
+// Prints a single result as a JSON object in a JSON array, including only the selected fields in their selected order.
+func (p *Printer) printJson(values map[string]interface{}) error {
+	if p.count == 1 {
+		log.Output("[")
+	} else {
+		log.Output(p.pendingJson + ",")
+	}
+	var parts []string
+	for _, field := range p.fields {
+		key, err := json.Marshal(field)
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		value, err := json.Marshal(getFieldValue(values, field))
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		parts = append(parts, "    "+string(key)+": "+string(value))
+	}
+	p.pendingJson = "  {\n" + strings.Join(parts, ",\n") + "\n  }"
+	return nil
+}
+
+func (p *Printer) printTable() error {
+	var header []string
+	for _, field := range p.fields {
+		header = append(header, strings.ToUpper(field))
+	}
+	return output.PrintTable(header, p.rows)
+}
+
+func printCsvLine(record []string) error {
+	return output.PrintCsv([][]string{record})
+}
+
+func (p *Printer) formatFields(values map[string]interface{}) []string {
+	var formatted []string
+	for _, field := range p.fields {
+		formatted = append(formatted, formatValue(getFieldValue(values, field)))
+	}
+	return formatted
+}
+
+// Formats a field value for the table and csv formats. Multiple property values are separated by commas.
+func formatValue(value interface{}) string {
+	switch typed := value.(type) {
+	case []string:
+		return strings.Join(typed, ",")
+	case map[string][]string:
+		var keys []string
+		for key := range typed {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		var props []string
+		for _, key := range keys {
+			props = append(props, key+"="+strings.Join(typed[key], ","))
+		}
+		return strings.Join(props, ";")
+	default:
+		return fmt.Sprint(typed)
+	}
+}
+
+func getFieldValue(values map[string]interface{}, field string) interface{} {
+	if strings.HasPrefix(field, propsFieldPrefix) {
+		propValues := values[propsField].(map[string][]string)[strings.TrimPrefix(field, propsFieldPrefix)]
+		if propValues == nil {
+			return []string{}
+		}
+		return propValues
+	}
+	return values[field]
+}
+
+func toValues(item *servicesutils.ResultItem) map[string]interface{} {
+	props := make(map[string][]string)
+	for _, property := range item.Properties {
+		props[property.Key] = append(props[property.Key], property.Value)
+	}
+	itemType := item.Type
+	if itemType == "" {
+		itemType = "file"
+	}
+	return map[string]interface{}{
+		"path":     item.GetItemRelativePath(),
+		"repo":     item.Repo,
+		"name":     item.Name,
+		"type":     itemType,
+		"size":     item.Size,
+		"created":  item.Created,
+		"modified": item.Modified,
+		"sha1":     item.Actual_Sha1,
+		"md5":      item.Actual_Md5,
+		"sha256":   item.Sha256,
+		propsField: props,
+	}
+}
@@ -0,0 +1,73 @@
+package search
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/stretchr/testify/assert"
+)
+
+var testItems = []*servicesutils.ResultItem{
+	{Repo: "repo", Path: "a", Name: "b.zip", Type: "file", Size: 10, Sha256: "abc", Properties: []servicesutils.Property{{Key: "build.name", Value: "x"}, {Key: "build.name", Value: "y"}}},
+	{Repo: "repo", Path: ".", Name: "c, d.zip", Type: "file", Size: 20, Sha256: "def"},
+}
+
+// Prints the test items and returns the output.
+func printItems(t *testing.T, printer *Printer) string {
+	previousLogger := log.Logger
+	defer log.SetLogger(previousLogger)
+	buffer := &bytes.Buffer{}
+	logger := log.NewLogger(log.ERROR, nil)
+	logger.SetOutputWriter(buffer)
+	log.SetLogger(logger)
+	for _, item := range testItems {
+		assert.NoError(t, printer.Add(item))
+	}
+	assert.NoError(t, printer.Close())
+	assert.Equal(t, len(testItems), printer.Count())
+	return buffer.String()
+}
+
+func TestParseFields(t *testing.T) {
+	fields, err := ParseFields("path, size,props.build.name")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"path", "size", "props.build.name"}, fields)
+	_, err = ParseFields("path,owner")
+	assert.Error(t, err)
+	_, err = ParseFields("props.")
+	assert.Error(t, err)
+}
+
+func TestPrintJson(t *testing.T) {
+	output := printItems(t, NewPrinter(Json, []string{"path", "size", "props.build.name"}))
+	var results []map[string]interface{}
+	assert.NoError(t, json.Unmarshal([]byte(output), &results))
+	assert.Equal(t, []map[string]interface{}{
+		{"path": "repo/a/b.zip", "size": float64(10), "props.build.name": []interface{}{"x", "y"}},
+		{"path": "repo/c, d.zip", "size": float64(20), "props.build.name": []interface{}{}},
+	}, results)
+}
+
+func TestPrintCsv(t *testing.T) {
+	output := printItems(t, NewPrinter(Csv, []string{"path", "sha256", "props.build.name"}))
+	assert.Equal(t, "path,sha256,props.build.name\nrepo/a/b.zip,abc,\"x,y\"\n\"repo/c, d.zip\",def,\n", output)
+}
+
+func TestPrintTableAndPaths(t *testing.T) {
+	output := printItems(t, NewPrinter(Table, []string{"path", "size"}))
+	assert.Equal(t, "PATH           SIZE\nrepo/a/b.zip   10\nrepo/c, d.zip  20\n", output)
+	output = printItems(t, NewPrinter(Paths, nil))
+	assert.Equal(t, "repo/a/b.zip\nrepo/c, d.zip\n", output)
+}
+
+func TestPrintTemplate(t *testing.T) {
+	printer, err := NewTemplatePrinter(`{{.name}} {{.size}} {{index .props "build.name"}}`)
+	assert.NoError(t, err)
+	output := printItems(t, printer)
+	assert.Equal(t, "b.zip 10 [x y]\nc, d.zip 20 []\n", output)
+	_, err = NewTemplatePrinter("{{.name")
+	assert.Error(t, err)
+}
@@ -0,0 +1,92 @@
+package search
+
+import (
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Searches the files of a file spec and prints them using a Printer.
+// Unlike the search command of jfrog-cli-core, the results keep all the fields returned by Artifactory, such as sha256 and the properties.
+type SearchCommand struct {
+	spec                   *spec.SpecFiles
+	serverDetails          *config.ServerDetails
+	retries                int
+	retryWaitTimeMilliSecs int
+	printer                *Printer
+}
+
+func NewSearchCommand() *SearchCommand {
+	return &SearchCommand{}
+}
+
+func (sc *SearchCommand) SetSpec(spec *spec.SpecFiles) *SearchCommand {
+	sc.spec = spec
+	return sc
+}
+
+func (sc *SearchCommand) SetServerDetails(serverDetails *config.ServerDetails) *SearchCommand {
+	sc.serverDetails = serverDetails
+	return sc
+}
+
+func (sc *SearchCommand) SetRetries(retries int) *SearchCommand {
+	sc.retries = retries
+	return sc
+}
+
+func (sc *SearchCommand) SetRetryWaitMilliSecs(retryWaitMilliSecs int) *SearchCommand {
+	sc.retryWaitTimeMilliSecs = retryWaitMilliSecs
+	return sc
+}
+
+func (sc *SearchCommand) SetPrinter(printer *Printer) *SearchCommand {
+	sc.printer = printer
+	return sc
+}
+
+func (sc *SearchCommand) ServerDetails() (*config.ServerDetails, error) {
+	return sc.serverDetails, nil
+}
+
+func (sc *SearchCommand) CommandName() string {
+	return "rt_search"
+}
+
+// Returns the number of files and folders found.
+func (sc *SearchCommand) Count() int {
+	return sc.printer.Count()
+}
+
+func (sc *SearchCommand) Run() error {
+	servicesManager, err := utils.CreateServiceManager(sc.serverDetails, sc.retries, sc.retryWaitTimeMilliSecs, false)
+	if err != nil {
+		return err
+	}
+	log.Info("Searching artifacts...")
+	for i := range sc.spec.Files {
+		searchParams, err := utils.GetSearchParams(sc.spec.Get(i))
+		if err != nil {
+			return err
+		}
+		reader, err := servicesManager.SearchFiles(searchParams)
+		if err != nil {
+			return err
+		}
+		for item := new(servicesutils.ResultItem); reader.NextRecord(item) == nil; item = new(servicesutils.ResultItem) {
+			if err = sc.printer.Add(item); err != nil {
+				reader.Close()
+				return err
+			}
+		}
+		err = reader.GetError()
+		reader.Close()
+		if err != nil {
+			return err
+		}
+	}
+	servicesutils.LogSearchResults(sc.printer.Count())
+	return sc.printer.Close()
+}
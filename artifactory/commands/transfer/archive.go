@@ -0,0 +1,424 @@
+package transfer
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/ioutils"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/klauspost/compress/zstd"
+	"github.com/mholt/archiver/v3"
+)
+
+// The formats of the archives which files can be uploaded in.
+const (
+	ArchiveZip    = "zip"
+	ArchiveTar    = "tar"
+	ArchiveTarGz  = "tar.gz"
+	ArchiveTarZst = "tar.zst"
+)
+
+var archiveFormats = []string{ArchiveZip, ArchiveTar, ArchiveTarGz, ArchiveTarZst}
+
+// The zip archives are packed by the client, while the tar archives are packed by the CLI before the upload.
+func isTarFormat(format string) bool {
+	return format == ArchiveTar || format == ArchiveTarGz || format == ArchiveTarZst
+}
+
+// Validates an upload spec, which may include the archive formats packed by the CLI.
+func ValidateUploadSpec(uploadSpec *spec.SpecFiles) error {
+	files := make([]spec.File, len(uploadSpec.Files))
+	for i, file := range uploadSpec.Files {
+		if file.Archive != "" && file.Archive != ArchiveZip && !isTarFormat(file.Archive) {
+			return errorutils.CheckErrorf("the value of 'archive' (if provided) must be one of: %s", strings.Join(archiveFormats, ", "))
+		}
+		// The spec validation of the client accepts only zip archives. The tar archives are subject to the same rules.
+		if isTarFormat(file.Archive) {
+			file.Archive = ArchiveZip
+		}
+		files[i] = file
+	}
+	return spec.ValidateSpec(files, true, false, true)
+}
+
+// Returns a copy of the upload spec, in which the tar archives are replaced by zip archives.
+// This allows listing the files which would be packed in a dry run, without packing them.
+func ToZipArchivesSpec(uploadSpec *spec.SpecFiles) *spec.SpecFiles {
+	zipSpec := &spec.SpecFiles{}
+	for _, file := range uploadSpec.Files {
+		if isTarFormat(file.Archive) {
+			file.Archive = ArchiveZip
+		}
+		zipSpec.Files = append(zipSpec.Files, file)
+	}
+	return zipSpec
+}
+
+// Packs the files of the spec groups which are uploaded in tar archives into local archives,
+// and returns a spec which uploads the local archives instead.
+// The returned function removes the local archives, and should be called once the upload is done.
+func PackArchives(uploadSpec *spec.SpecFiles, configuration *utils.UploadConfiguration, serverDetails *config.ServerDetails) (*spec.SpecFiles, func() error, error) {
+	cleanup := func() error { return nil }
+	packedSpec := &spec.SpecFiles{}
+	var tempDir string
+	for i, file := range uploadSpec.Files {
+		if !isTarFormat(file.Archive) {
+			packedSpec.Files = append(packedSpec.Files, file)
+			continue
+		}
+		if tempDir == "" {
+			var err error
+			if tempDir, err = fileutils.CreateTempDir(); err != nil {
+				return nil, cleanup, err
+			}
+			cleanup = func() error { return fileutils.RemoveTempDir(tempDir) }
+		}
+		sources, err := collectArchiveSources(file, configuration, serverDetails)
+		if err != nil {
+			return nil, cleanup, err
+		}
+		if len(sources) == 0 {
+			log.Info("No files to pack in the archive " + file.Target + ".")
+			continue
+		}
+		flat, err := file.IsFlat(false)
+		if err != nil {
+			return nil, cleanup, err
+		}
+		symlinks, err := file.IsSymlinks(false)
+		if err != nil {
+			return nil, cleanup, err
+		}
+		archiveDir := filepath.Join(tempDir, strconv.Itoa(i))
+		if err = os.MkdirAll(archiveDir, 0755); errorutils.CheckError(err) != nil {
+			return nil, cleanup, err
+		}
+		archivePath := filepath.Join(archiveDir, path.Base(file.Target))
+		log.Info("Packing", len(sources), "files into", archivePath+"...")
+		if err = WriteTarArchive(archivePath, file.Archive, sources, flat, symlinks); err != nil {
+			return nil, cleanup, err
+		}
+		file.Pattern = archivePath
+		if coreutils.IsWindows() {
+			file.Pattern = ioutils.DoubleWinPathSeparator(file.Pattern)
+		}
+		file.Archive = ""
+		file.Exclusions = nil
+		file.Recursive = "false"
+		file.Flat = "true"
+		file.Regexp = "false"
+		file.Ant = "false"
+		file.Symlinks = "false"
+		file.IncludeDirs = "false"
+		packedSpec.Files = append(packedSpec.Files, file)
+	}
+	return packedSpec, cleanup, nil
+}
+
+// Collects the local paths of the files of a spec group, by running the upload in dry-run mode without an archive.
+func collectArchiveSources(file spec.File, configuration *utils.UploadConfiguration, serverDetails *config.ServerDetails) ([]string, error) {
+	file.Archive = ""
+	file.Explode = "false"
+	reader, err := runUploadDryRun(file, configuration, serverDetails, 0, 0)
+	if err != nil || reader == nil {
+		return nil, err
+	}
+	defer reader.Close()
+	var sources []string
+	for transferDetails := new(clientutils.FileTransferDetails); reader.NextRecord(transferDetails) == nil; transferDetails = new(clientutils.FileTransferDetails) {
+		sources = append(sources, transferDetails.SourcePath)
+	}
+	return sources, reader.GetError()
+}
+
+// Writes the files to a tar archive in the format, compressed according to the format.
+// The permissions of the files are kept in the archive. When flat, the files are placed in the root of the archive.
+// Otherwise, they are placed according to their local paths.
+// Symlinks are written as symlinks if requested, otherwise the files they point to are written.
+func WriteTarArchive(archivePath, format string, sources []string, flat, symlinks bool) error {
+	return writeTar(archivePath, format, func(tarWriter *tar.Writer) error {
+		for _, source := range sources {
+			if err := addToTar(tarWriter, source, getTarEntryName(source, flat), symlinks); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Writes the content of a directory to a tar archive in the format. The files are placed in the archive according to their paths relative to the directory.
+func writeDirTarArchive(archivePath, format, dirPath string) error {
+	return writeTar(archivePath, format, func(tarWriter *tar.Writer) error {
+		return filepath.Walk(dirPath, func(source string, info os.FileInfo, err error) error {
+			if err != nil {
+				return errorutils.CheckError(err)
+			}
+			if source == dirPath {
+				return nil
+			}
+			entryName, err := filepath.Rel(dirPath, source)
+			if err != nil {
+				return errorutils.CheckError(err)
+			}
+			return addToTar(tarWriter, source, filepath.ToSlash(entryName), false)
+		})
+	})
+}
+
+// Creates a tar archive in the format, and calls writeEntries to write its entries.
+func writeTar(archivePath, format string, writeEntries func(tarWriter *tar.Writer) error) (err error) {
+	archiveFile, err := os.Create(archivePath)
+	if errorutils.CheckError(err) != nil {
+		return
+	}
+	defer closeArchiveWriter(archiveFile, &err)
+	var writer io.Writer = archiveFile
+	switch format {
+	case ArchiveTarGz:
+		gzipWriter := gzip.NewWriter(archiveFile)
+		defer closeArchiveWriter(gzipWriter, &err)
+		writer = gzipWriter
+	case ArchiveTarZst:
+		zstdWriter, e := zstd.NewWriter(archiveFile)
+		if e != nil {
+			return errorutils.CheckError(e)
+		}
+		defer closeArchiveWriter(zstdWriter, &err)
+		writer = zstdWriter
+	}
+	tarWriter := tar.NewWriter(writer)
+	defer closeArchiveWriter(tarWriter, &err)
+	return writeEntries(tarWriter)
+}
+
+// The writers are closed in the reverse order of their creation, so that each writer is flushed before the one it writes to is closed.
+func closeArchiveWriter(closer io.Closer, err *error) {
+	if e := closer.Close(); *err == nil {
+		*err = errorutils.CheckError(e)
+	}
+}
+
+func addToTar(tarWriter *tar.Writer, source, entryName string, symlinks bool) error {
+	info, err := os.Lstat(source)
+	if errorutils.CheckError(err) != nil {
+		return err
+	}
+	link := ""
+	if info.Mode()&os.ModeSymlink != 0 {
+		if symlinks {
+			link, err = os.Readlink(source)
+		} else {
+			info, err = os.Stat(source)
+		}
+		if errorutils.CheckError(err) != nil {
+			return err
+		}
+	}
+	header, err := tar.FileInfoHeader(info, link)
+	if errorutils.CheckError(err) != nil {
+		return err
+	}
+	header.Name = entryName
+	if info.IsDir() {
+		header.Name += "/"
+	}
+	if err = tarWriter.WriteHeader(header); errorutils.CheckError(err) != nil {
+		return err
+	}
+	if !info.Mode().IsRegular() {
+		return nil
+	}
+	file, err := os.Open(source)
+	if errorutils.CheckError(err) != nil {
+		return err
+	}
+	defer file.Close()
+	_, err = io.Copy(tarWriter, file)
+	return errorutils.CheckError(err)
+}
+
+// Returns the path of a file in the archive, in the same way as in the zip archives packed by the client.
+// Unlike in zip archives, the leading slash and the volume name are removed, like the tar command does.
+func getTarEntryName(source string, flat bool) string {
+	if flat {
+		return filepath.Base(source)
+	}
+	name := strings.TrimPrefix(source, filepath.VolumeName(source))
+	name = clientutils.TrimPath(filepath.ToSlash(name))
+	return strings.TrimLeft(name, "/")
+}
+
+// Extracts a downloaded archive into a directory, and removes the archive.
+// The format is determined by the name of the archive in Artifactory, since the downloaded file may have a different name.
+// The archive is inspected before anything is extracted, and rejected if any of its entries would be written outside of the directory.
+func ExtractArchive(archivePath, archiveName, destination string) error {
+	archive, err := newArchive(archiveName)
+	if err != nil {
+		log.Debug("Skipping the extraction of " + archivePath + ", since " + archiveName + " isn't a supported archive.")
+		return nil
+	}
+	if err = unpackArchive(archive, archivePath, destination); err != nil {
+		return err
+	}
+	return errorutils.CheckError(os.Remove(archivePath))
+}
+
+// Extracts an archive into a directory, after inspecting its entries.
+func unpackArchive(archive extractableArchive, archivePath, destination string) error {
+	destination, err := filepath.Abs(destination)
+	if errorutils.CheckError(err) != nil {
+		return err
+	}
+	if err = inspectArchive(archive, archivePath, destination); err != nil {
+		return err
+	}
+	log.Info("Extracting archive:", archivePath, "to", destination)
+	return errorutils.CheckError(archive.Unarchive(archivePath, destination))
+}
+
+// An archive format which can be both inspected and extracted.
+type extractableArchive interface {
+	archiver.Walker
+	archiver.Unarchiver
+}
+
+// Returns the archive format of a file name. Like in the client, existing files are overwritten when the archive is extracted.
+func newArchive(archiveName string) (extractableArchive, error) {
+	archive, err := archiver.ByExtension(archiveName)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	switch format := archive.(type) {
+	case *archiver.Zip:
+		format.OverwriteExisting = true
+	case *archiver.Rar:
+		format.OverwriteExisting = true
+	case *archiver.Tar:
+		format.OverwriteExisting = true
+	case *archiver.TarGz:
+		format.OverwriteExisting = true
+	case *archiver.TarBz2:
+		format.OverwriteExisting = true
+	case *archiver.TarXz:
+		format.OverwriteExisting = true
+	case *archiver.TarZstd:
+		format.OverwriteExisting = true
+	case *archiver.TarLz4:
+		format.OverwriteExisting = true
+	case *archiver.TarSz:
+		format.OverwriteExisting = true
+	case *archiver.TarBrotli:
+		format.OverwriteExisting = true
+	}
+	extractable, ok := archive.(extractableArchive)
+	if !ok {
+		return nil, errorutils.CheckErrorf("%s isn't an archive", archiveName)
+	}
+	return extractable, nil
+}
+
+// Protects against "zip slip" entries, which are written outside of the extraction directory by using ".." or absolute paths,
+// and against links which point outside of it.
+// Entries which are written through a link of the archive are rejected as well, since the link may point anywhere once extracted.
+func inspectArchive(archive archiver.Walker, archivePath, destination string) error {
+	links := make(map[string]bool)
+	err := archive.Walk(archivePath, func(archiveEntry archiver.File) error {
+		name, linkName, isHardLink, err := getArchiveEntryHeader(archiveEntry)
+		if err != nil {
+			return err
+		}
+		entryPath, ok := getPathInDestination(destination, name)
+		if !ok {
+			return errorutils.CheckErrorf("illegal path in archive %s: '%s'. The path should lead to an entry under '%s'", archivePath, name, destination)
+		}
+		for parent := filepath.Dir(entryPath); len(parent) > len(destination); parent = filepath.Dir(parent) {
+			if links[parent] {
+				return errorutils.CheckErrorf("illegal path in archive %s: '%s'. The path shouldn't lead through a link of the archive", archivePath, name)
+			}
+		}
+		isSymlink := archiveEntry.Mode()&os.ModeSymlink != 0
+		if !isSymlink && !isHardLink {
+			return nil
+		}
+		links[entryPath] = true
+		if isSymlink && linkName == "" {
+			// The link of a zip entry is its content.
+			content, err := ioutil.ReadAll(archiveEntry)
+			if err != nil {
+				return errorutils.CheckError(err)
+			}
+			linkName = string(content)
+		}
+		// Symlinks are relative to their own directory, while hard links are extracted relative to the destination.
+		linkBase := destination
+		if isSymlink {
+			linkBase = filepath.Dir(entryPath)
+		}
+		if filepath.IsAbs(linkName) {
+			linkBase = ""
+		}
+		if _, ok := getPathInDestination(destination, filepath.Join(linkBase, linkName)); !ok {
+			return errorutils.CheckErrorf("illegal link in archive %s: '%s' points to '%s'. The link should lead to an entry under '%s'", archivePath, name, linkName, destination)
+		}
+		return nil
+	})
+	return errorutils.CheckError(err)
+}
+
+// Returns the path a name would be extracted to, and whether it's under the destination.
+// Relative names are resolved from the destination, while absolute names must be under it.
+func getPathInDestination(destination, name string) (string, bool) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", false
+	}
+	// On Windows, a name which starts with a slash is relative to the drive rather than the destination.
+	if os.IsPathSeparator('\\') && (strings.HasPrefix(name, "/") || strings.HasPrefix(name, "\\")) {
+		return "", false
+	}
+	if filepath.VolumeName(name) != "" && !filepath.IsAbs(name) {
+		return "", false
+	}
+	entryPath := filepath.Clean(name)
+	if !filepath.IsAbs(entryPath) {
+		entryPath = filepath.Join(destination, entryPath)
+	}
+	relativePath, err := filepath.Rel(destination, entryPath)
+	if err != nil || relativePath == ".." || strings.HasPrefix(relativePath, ".."+string(os.PathSeparator)) {
+		return "", false
+	}
+	return entryPath, true
+}
+
+// Returns the name of an archive entry, the target of its link, and whether it's a hard link.
+// The header is different for each archive format, but all of them include the name of the entry.
+func getArchiveEntryHeader(archiveEntry archiver.File) (name, linkName string, isHardLink bool, err error) {
+	if tarHeader, ok := archiveEntry.Header.(*tar.Header); ok {
+		return tarHeader.Name, tarHeader.Linkname, tarHeader.Typeflag == tar.TypeLink, nil
+	}
+	headerBytes, err := json.Marshal(archiveEntry.Header)
+	if err != nil {
+		return "", "", false, errorutils.CheckError(err)
+	}
+	header := struct {
+		Name     string `json:"Name,omitempty"`
+		Linkname string `json:"Linkname,omitempty"`
+	}{}
+	err = errorutils.CheckError(json.Unmarshal(headerBytes, &header))
+	return header.Name, header.Linkname, false, err
+}
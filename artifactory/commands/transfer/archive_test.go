@@ -0,0 +1,111 @@
+package transfer
+
+import (
+	"archive/tar"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	coretests "github.com/jfrog/jfrog-cli-core/v2/utils/tests"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTarArchiveRoundTrip(t *testing.T) {
+	for _, format := range []string{ArchiveTar, ArchiveTarGz, ArchiveTarZst} {
+		t.Run(format, func(t *testing.T) {
+			tempDirPath, createTempDirCallback := coretests.CreateTempDirWithCallbackAndAssert(t)
+			defer createTempDirCallback()
+			scriptPath := filepath.Join(tempDirPath, "run.sh")
+			assert.NoError(t, ioutil.WriteFile(scriptPath, []byte("#!/bin/sh"), 0755))
+			textPath := filepath.Join(tempDirPath, "a.txt")
+			assert.NoError(t, ioutil.WriteFile(textPath, []byte("content"), 0600))
+
+			archivePath := filepath.Join(tempDirPath, "archive")
+			assert.NoError(t, WriteTarArchive(archivePath, format, []string{scriptPath, textPath}, true, false))
+			destination := filepath.Join(tempDirPath, "out")
+			// The format is determined by the name of the archive in Artifactory, rather than the local name.
+			assert.NoError(t, ExtractArchive(archivePath, "archive."+format, destination))
+
+			assert.NoFileExists(t, archivePath)
+			content, err := ioutil.ReadFile(filepath.Join(destination, "a.txt"))
+			assert.NoError(t, err)
+			assert.Equal(t, "content", string(content))
+			info, err := os.Stat(filepath.Join(destination, "run.sh"))
+			if assert.NoError(t, err) {
+				assert.Equal(t, os.FileMode(0755), info.Mode().Perm())
+			}
+			info, err = os.Stat(filepath.Join(destination, "a.txt"))
+			if assert.NoError(t, err) {
+				assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+			}
+		})
+	}
+}
+
+func TestGetTarEntryName(t *testing.T) {
+	assert.Equal(t, "c.txt", getTarEntryName(filepath.Join("a", "b", "c.txt"), true))
+	assert.Equal(t, "a/b/c.txt", getTarEntryName(filepath.Join("a", "b", "c.txt"), false))
+	assert.Equal(t, "a/c.txt", getTarEntryName(filepath.Join("..", "a", "c.txt"), false))
+}
+
+func TestExtractArchiveRejectsEntriesOutsideOfDestination(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers []*tar.Header
+	}{
+		{"parent", []*tar.Header{{Name: "../evil.txt", Typeflag: tar.TypeReg}}},
+		{"sibling with the same prefix", []*tar.Header{{Name: "../out-evil/evil.txt", Typeflag: tar.TypeReg}}},
+		{"nested parent", []*tar.Header{{Name: "a/../../evil.txt", Typeflag: tar.TypeReg}}},
+		{"symlink", []*tar.Header{{Name: "link", Linkname: "../..", Typeflag: tar.TypeSymlink}}},
+		{"absolute symlink", []*tar.Header{{Name: "link", Linkname: "/etc/passwd", Typeflag: tar.TypeSymlink}}},
+		{"hard link", []*tar.Header{{Name: "link", Linkname: "../evil.txt", Typeflag: tar.TypeLink}}},
+		{"entry through a symlink", []*tar.Header{
+			{Name: "sub/", Typeflag: tar.TypeDir},
+			{Name: "link", Linkname: "sub", Typeflag: tar.TypeSymlink},
+			{Name: "link/evil.txt", Typeflag: tar.TypeReg},
+		}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tempDirPath, createTempDirCallback := coretests.CreateTempDirWithCallbackAndAssert(t)
+			defer createTempDirCallback()
+			archivePath := filepath.Join(tempDirPath, "archive.tar")
+			writeTestTar(t, archivePath, test.headers)
+			destination := filepath.Join(tempDirPath, "out")
+
+			assert.Error(t, ExtractArchive(archivePath, "archive.tar", destination))
+			// Nothing is extracted, and the archive is kept.
+			assert.NoDirExists(t, destination)
+			assert.NoFileExists(t, filepath.Join(tempDirPath, "evil.txt"))
+			assert.FileExists(t, archivePath)
+		})
+	}
+}
+
+func TestExtractArchiveAllowsLinksInsideOfDestination(t *testing.T) {
+	tempDirPath, createTempDirCallback := coretests.CreateTempDirWithCallbackAndAssert(t)
+	defer createTempDirCallback()
+	archivePath := filepath.Join(tempDirPath, "archive.tar")
+	writeTestTar(t, archivePath, []*tar.Header{
+		{Name: "sub/a.txt", Typeflag: tar.TypeReg},
+		{Name: "sub/link", Linkname: "../sub/a.txt", Typeflag: tar.TypeSymlink},
+	})
+	destination := filepath.Join(tempDirPath, "out")
+	assert.NoError(t, ExtractArchive(archivePath, "archive.tar", destination))
+	linkTarget, err := os.Readlink(filepath.Join(destination, "sub", "link"))
+	assert.NoError(t, err)
+	assert.Equal(t, "../sub/a.txt", linkTarget)
+}
+
+func writeTestTar(t *testing.T, archivePath string, headers []*tar.Header) {
+	archiveFile, err := os.Create(archivePath)
+	assert.NoError(t, err)
+	defer archiveFile.Close()
+	tarWriter := tar.NewWriter(archiveFile)
+	for _, header := range headers {
+		header.Mode = 0644
+		assert.NoError(t, tarWriter.WriteHeader(header))
+	}
+	assert.NoError(t, tarWriter.Close())
+}
@@ -0,0 +1,187 @@
+package transfer
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+const (
+	// The index of a bundle, which describes its content. It's placed in the root of the bundle archive.
+	BundleIndexName = "index.json"
+	// The version of the index written by this version of the CLI.
+	bundleIndexVersion = 1
+	// The artifacts are placed under this directory of the bundle archive, according to their paths in Artifactory.
+	bundleFilesDir = "files"
+	// The build-info JSON files are placed under this directory of the bundle archive.
+	bundleBuildsDir = "builds"
+)
+
+// The index of a bundle archive, which is written by the bundle-export command and read by the bundle-import command.
+type BundleIndex struct {
+	Version   int               `json:"version"`
+	Created   string            `json:"created"`
+	Source    string            `json:"source,omitempty"`
+	Artifacts []*BundleArtifact `json:"artifacts"`
+	Builds    []*BundleBuild    `json:"builds"`
+}
+
+// An artifact of a bundle. The file is the path of the artifact in the bundle archive.
+type BundleArtifact struct {
+	Path       string              `json:"path"`
+	File       string              `json:"file"`
+	Size       int64               `json:"size"`
+	Md5        string              `json:"md5,omitempty"`
+	Sha1       string              `json:"sha1,omitempty"`
+	Sha256     string              `json:"sha256,omitempty"`
+	Properties map[string][]string `json:"properties,omitempty"`
+}
+
+// The build-info of a build which produced or used the artifacts of a bundle. The file is the path of its JSON in the bundle archive.
+type BundleBuild struct {
+	Name    string `json:"name"`
+	Number  string `json:"number"`
+	Project string `json:"project,omitempty"`
+	File    string `json:"file"`
+}
+
+func newBundleIndex(source string) *BundleIndex {
+	return &BundleIndex{Version: bundleIndexVersion, Created: time.Now().UTC().Format(time.RFC3339), Source: source,
+		Artifacts: []*BundleArtifact{}, Builds: []*BundleBuild{}}
+}
+
+func writeBundleIndex(index *BundleIndex, bundleDir string) error {
+	content, err := json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	return errorutils.CheckError(ioutil.WriteFile(filepath.Join(bundleDir, BundleIndexName), content, 0644))
+}
+
+// Reads the index of an extracted bundle, and validates that the files it lists are inside the bundle.
+func readBundleIndex(bundleDir string) (*BundleIndex, error) {
+	content, err := ioutil.ReadFile(filepath.Join(bundleDir, BundleIndexName))
+	if err != nil {
+		return nil, errorutils.CheckErrorf("the archive isn't a bundle, since it doesn't include %s: %s", BundleIndexName, err.Error())
+	}
+	index := new(BundleIndex)
+	if err = json.Unmarshal(content, index); err != nil {
+		return nil, errorutils.CheckErrorf("failed parsing the bundle index: %s", err.Error())
+	}
+	if index.Version < 1 || index.Version > bundleIndexVersion {
+		return nil, errorutils.CheckErrorf("the bundle index version %d isn't supported by this version of JFrog CLI", index.Version)
+	}
+	for _, artifact := range index.Artifacts {
+		if artifact.Path == "" || strings.HasPrefix(artifact.Path, "/") || path.Clean(artifact.Path) != artifact.Path || !strings.Contains(artifact.Path, "/") {
+			return nil, errorutils.CheckErrorf("illegal artifact path in the bundle index: '%s'", artifact.Path)
+		}
+		if _, err = getBundleFilePath(bundleDir, artifact.File); err != nil {
+			return nil, err
+		}
+	}
+	for _, build := range index.Builds {
+		if _, err = getBundleFilePath(bundleDir, build.File); err != nil {
+			return nil, err
+		}
+	}
+	return index, nil
+}
+
+// Returns the local path of a file of an extracted bundle. A file which would be outside of the bundle is rejected.
+func getBundleFilePath(bundleDir, file string) (string, error) {
+	bundleDir, err := filepath.Abs(bundleDir)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	localPath, ok := getPathInDestination(bundleDir, filepath.FromSlash(file))
+	if !ok || filepath.IsAbs(filepath.FromSlash(file)) {
+		return "", errorutils.CheckErrorf("illegal file path in the bundle index: '%s'. The path should lead to a file inside the bundle", file)
+	}
+	return localPath, nil
+}
+
+// Returns the format of a bundle archive according to the extension of its path.
+func getBundleFormat(archivePath string) (string, error) {
+	switch {
+	case strings.HasSuffix(archivePath, "."+ArchiveTarGz) || strings.HasSuffix(archivePath, ".tgz"):
+		return ArchiveTarGz, nil
+	case strings.HasSuffix(archivePath, "."+ArchiveTarZst):
+		return ArchiveTarZst, nil
+	case strings.HasSuffix(archivePath, "."+ArchiveTar):
+		return ArchiveTar, nil
+	}
+	return "", errorutils.CheckErrorf("the bundle archive path should end with one of the following extensions: .%s, .%s, .tgz, .%s", ArchiveTar, ArchiveTarGz, ArchiveTarZst)
+}
+
+// Compares the checksums of a local file with the checksums of the artifact in the index.
+// The sha256 checksum is compared when the index has it. Otherwise, the sha1 checksum is compared.
+func verifyBundleArtifact(artifact *BundleArtifact, localPath string) error {
+	checksums, err := calcFileChecksums(localPath)
+	if err != nil {
+		return err
+	}
+	switch {
+	case artifact.Sha256 != "":
+		if checksums.sha256() != artifact.Sha256 {
+			return errorutils.CheckErrorf("the sha256 checksum of %s is %s, while the expected checksum is %s", artifact.Path, checksums.sha256(), artifact.Sha256)
+		}
+	case artifact.Sha1 != "":
+		if checksums.sha1() != artifact.Sha1 {
+			return errorutils.CheckErrorf("the sha1 checksum of %s is %s, while the expected checksum is %s", artifact.Path, checksums.sha1(), artifact.Sha1)
+		}
+	default:
+		return errorutils.CheckErrorf("the bundle index doesn't include the checksums of %s", artifact.Path)
+	}
+	return nil
+}
+
+// Returns the properties in the format of the --target-props option. The separators in the keys and values are escaped.
+// Empty values can't be set in this format, so they are skipped.
+func formatBundleProperties(properties map[string][]string) string {
+	keys := make([]string, 0, len(properties))
+	for key := range properties {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var props []string
+	for _, key := range keys {
+		var values []string
+		for _, value := range properties[key] {
+			if value != "" {
+				values = append(values, escapeProperty(value))
+			}
+		}
+		if len(values) > 0 {
+			props = append(props, escapeProperty(key)+"="+strings.Join(values, ","))
+		}
+	}
+	return strings.Join(props, ";")
+}
+
+func escapeProperty(property string) string {
+	return strings.NewReplacer(";", `\;`, ",", `\,`).Replace(property)
+}
+
+// Returns the builds ordered by their name and number, so that the builds of a bundle are always listed in the same order.
+func sortedBundleBuilds(builds map[string]*BundleBuild) []*BundleBuild {
+	sorted := make([]*BundleBuild, 0, len(builds))
+	for _, build := range builds {
+		sorted = append(sorted, build)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Name != sorted[j].Name {
+			return sorted[i].Name < sorted[j].Name
+		}
+		if sorted[i].Number != sorted[j].Number {
+			return sorted[i].Number < sorted[j].Number
+		}
+		return sorted[i].Project < sorted[j].Project
+	})
+	return sorted
+}
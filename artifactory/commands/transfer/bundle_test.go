@@ -0,0 +1,128 @@
+package transfer
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	coretests "github.com/jfrog/jfrog-cli-core/v2/utils/tests"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBundleRoundTrip(t *testing.T) {
+	tempDirPath, createTempDirCallback := coretests.CreateTempDirWithCallbackAndAssert(t)
+	defer createTempDirCallback()
+	bundleDir := filepath.Join(tempDirPath, "bundle")
+	artifactDir := filepath.Join(bundleDir, bundleFilesDir, "repo", "a")
+	assert.NoError(t, os.MkdirAll(artifactDir, 0755))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(artifactDir, "b (1).txt"), []byte("content"), 0644))
+	checksums, err := calcFileChecksums(filepath.Join(artifactDir, "b (1).txt"))
+	assert.NoError(t, err)
+
+	index := newBundleIndex("http://localhost:8081/artifactory/")
+	index.Artifacts = append(index.Artifacts, &BundleArtifact{Path: "repo/a/b (1).txt", File: "files/repo/a/b (1).txt", Size: 7, Sha256: checksums.sha256(),
+		Properties: map[string][]string{"key": {"value"}}})
+	assert.NoError(t, writeBundleIndex(index, bundleDir))
+	archivePath := filepath.Join(tempDirPath, "bundle.tar.gz")
+	assert.NoError(t, writeDirTarArchive(archivePath, ArchiveTarGz, bundleDir))
+
+	archive, err := newArchive(archivePath)
+	assert.NoError(t, err)
+	destination := filepath.Join(tempDirPath, "out")
+	assert.NoError(t, unpackArchive(archive, archivePath, destination))
+	assert.FileExists(t, archivePath)
+	readIndex, err := readBundleIndex(destination)
+	if assert.NoError(t, err) && assert.Len(t, readIndex.Artifacts, 1) {
+		assert.Equal(t, index.Artifacts[0], readIndex.Artifacts[0])
+		localPath, err := getBundleFilePath(destination, readIndex.Artifacts[0].File)
+		assert.NoError(t, err)
+		assert.NoError(t, verifyBundleArtifact(readIndex.Artifacts[0], localPath))
+	}
+}
+
+func TestVerifyBundleArtifact(t *testing.T) {
+	tempDirPath, createTempDirCallback := coretests.CreateTempDirWithCallbackAndAssert(t)
+	defer createTempDirCallback()
+	localPath := filepath.Join(tempDirPath, "a.txt")
+	assert.NoError(t, ioutil.WriteFile(localPath, []byte("content"), 0644))
+	checksums, err := calcFileChecksums(localPath)
+	assert.NoError(t, err)
+
+	assert.NoError(t, verifyBundleArtifact(&BundleArtifact{Path: "repo/a.txt", Sha256: checksums.sha256()}, localPath))
+	assert.NoError(t, verifyBundleArtifact(&BundleArtifact{Path: "repo/a.txt", Sha1: checksums.sha1()}, localPath))
+	assert.Error(t, verifyBundleArtifact(&BundleArtifact{Path: "repo/a.txt", Sha256: checksums.sha1()}, localPath))
+	assert.Error(t, verifyBundleArtifact(&BundleArtifact{Path: "repo/a.txt"}, localPath))
+}
+
+func TestReadBundleIndexRejectsIllegalPaths(t *testing.T) {
+	tests := []struct {
+		name     string
+		artifact *BundleArtifact
+		build    *BundleBuild
+	}{
+		{"file outside of the bundle", &BundleArtifact{Path: "repo/a.txt", File: "../a.txt"}, nil},
+		{"absolute file", &BundleArtifact{Path: "repo/a.txt", File: "/etc/passwd"}, nil},
+		{"build outside of the bundle", nil, &BundleBuild{Name: "build", Number: "1", File: "builds/../../1.json"}},
+		{"absolute artifact path", &BundleArtifact{Path: "/repo/a.txt", File: "files/repo/a.txt"}, nil},
+		{"artifact path with parent", &BundleArtifact{Path: "repo/../other/a.txt", File: "files/repo/a.txt"}, nil},
+		{"artifact path without a repository", &BundleArtifact{Path: "a.txt", File: "files/a.txt"}, nil},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bundleDir, createTempDirCallback := coretests.CreateTempDirWithCallbackAndAssert(t)
+			defer createTempDirCallback()
+			index := newBundleIndex("")
+			if test.artifact != nil {
+				index.Artifacts = append(index.Artifacts, test.artifact)
+			}
+			if test.build != nil {
+				index.Builds = append(index.Builds, test.build)
+			}
+			assert.NoError(t, writeBundleIndex(index, bundleDir))
+			_, err := readBundleIndex(bundleDir)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestReadBundleIndexRejectsUnsupportedVersion(t *testing.T) {
+	bundleDir, createTempDirCallback := coretests.CreateTempDirWithCallbackAndAssert(t)
+	defer createTempDirCallback()
+	index := newBundleIndex("")
+	index.Version = bundleIndexVersion + 1
+	assert.NoError(t, writeBundleIndex(index, bundleDir))
+	_, err := readBundleIndex(bundleDir)
+	assert.Error(t, err)
+}
+
+func TestGetBundleFormat(t *testing.T) {
+	for archivePath, expected := range map[string]string{"a.tar": ArchiveTar, "a.tar.gz": ArchiveTarGz, "a.tgz": ArchiveTarGz, "a.tar.zst": ArchiveTarZst} {
+		format, err := getBundleFormat(archivePath)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, format, archivePath)
+	}
+	_, err := getBundleFormat("a.zip")
+	assert.Error(t, err)
+}
+
+func TestFormatBundleProperties(t *testing.T) {
+	properties := map[string][]string{"b": {"1", "2"}, "a": {"x;y", "z,w", "k=v"}, "empty": {""}}
+	formatted := formatBundleProperties(properties)
+	assert.Equal(t, `a=x\;y,z\,w,k=v;b=1,2`, formatted)
+
+	parsed, err := servicesutils.ParseProperties(formatted)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"x;y", "z,w", "k=v"}, parsed.ToMap()["a"])
+	assert.ElementsMatch(t, []string{"1", "2"}, parsed.ToMap()["b"])
+}
+
+func TestAddPropertiesBuilds(t *testing.T) {
+	exportCommand := NewBundleExportCommand().SetProject("proj")
+	exportCommand.addPropertiesBuilds(map[string][]string{buildNameProperty: {"a", "b"}, buildNumberProperty: {"1", "2"}})
+	exportCommand.addPropertiesBuilds(map[string][]string{buildNameProperty: {"a"}, buildNumberProperty: {"1"}})
+	exportCommand.addPropertiesBuilds(map[string][]string{buildNameProperty: {"c"}})
+	builds := sortedBundleBuilds(exportCommand.builds)
+	assert.Equal(t, []*BundleBuild{{Name: "a", Number: "1", Project: "proj"}, {Name: "b", Number: "2", Project: "proj"}}, builds)
+}
@@ -0,0 +1,288 @@
+package transfer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+
+	commandsutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	ioUtils "github.com/jfrog/jfrog-client-go/utils/io"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	buildNameProperty   = "build.name"
+	buildNumberProperty = "build.number"
+)
+
+// Exports the artifacts matched by a file spec into a bundle archive, together with their properties and checksums, and the build-info of their builds.
+// The artifacts are downloaded by the download command of this package, and verified against their checksums in Artifactory before they are packed.
+type BundleExportCommand struct {
+	serverDetails          *config.ServerDetails
+	spec                   *spec.SpecFiles
+	configuration          *utils.DownloadConfiguration
+	archivePath            string
+	includeBuilds          bool
+	project                string
+	retries                int
+	retryWaitTimeMilliSecs int
+	progress               ioUtils.ProgressMgr
+	result                 *commandsutils.Result
+	// The builds to export, by their name and number.
+	builds map[string]*BundleBuild
+}
+
+func NewBundleExportCommand() *BundleExportCommand {
+	return &BundleExportCommand{includeBuilds: true, result: new(commandsutils.Result), builds: make(map[string]*BundleBuild)}
+}
+
+func (bec *BundleExportCommand) SetServerDetails(serverDetails *config.ServerDetails) *BundleExportCommand {
+	bec.serverDetails = serverDetails
+	return bec
+}
+
+func (bec *BundleExportCommand) SetSpec(exportSpec *spec.SpecFiles) *BundleExportCommand {
+	bec.spec = exportSpec
+	return bec
+}
+
+func (bec *BundleExportCommand) SetConfiguration(configuration *utils.DownloadConfiguration) *BundleExportCommand {
+	bec.configuration = configuration
+	return bec
+}
+
+// The path of the bundle archive. Its format is determined by its extension.
+func (bec *BundleExportCommand) SetArchivePath(archivePath string) *BundleExportCommand {
+	bec.archivePath = archivePath
+	return bec
+}
+
+// The build-info of the builds of the spec, and of the builds the artifacts are attached to, is included in the bundle.
+func (bec *BundleExportCommand) SetIncludeBuilds(includeBuilds bool) *BundleExportCommand {
+	bec.includeBuilds = includeBuilds
+	return bec
+}
+
+// The project of the builds which are included in the bundle.
+func (bec *BundleExportCommand) SetProject(project string) *BundleExportCommand {
+	bec.project = project
+	return bec
+}
+
+func (bec *BundleExportCommand) SetRetries(retries int) *BundleExportCommand {
+	bec.retries = retries
+	return bec
+}
+
+func (bec *BundleExportCommand) SetRetryWaitMilliSecs(retryWaitMilliSecs int) *BundleExportCommand {
+	bec.retryWaitTimeMilliSecs = retryWaitMilliSecs
+	return bec
+}
+
+func (bec *BundleExportCommand) SetProgress(progress ioUtils.ProgressMgr) {
+	bec.progress = progress
+}
+
+func (bec *BundleExportCommand) ServerDetails() (*config.ServerDetails, error) {
+	return bec.serverDetails, nil
+}
+
+func (bec *BundleExportCommand) Result() *commandsutils.Result {
+	return bec.result
+}
+
+func (bec *BundleExportCommand) CommandName() string {
+	return "rt_bundle_export"
+}
+
+func (bec *BundleExportCommand) Run() (err error) {
+	format, err := getBundleFormat(bec.archivePath)
+	if err != nil {
+		return err
+	}
+	bundleDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if e := fileutils.RemoveTempDir(bundleDir); err == nil {
+			err = e
+		}
+	}()
+	servicesManager, err := utils.CreateServiceManager(bec.serverDetails, bec.retries, bec.retryWaitTimeMilliSecs, false)
+	if err != nil {
+		return err
+	}
+	log.Info("Collecting the artifacts to export...")
+	index := newBundleIndex(bec.serverDetails.ArtifactoryUrl)
+	entries, err := bec.collectArtifacts(servicesManager, index, bundleDir)
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		return errorutils.CheckErrorf("no artifacts were found to export")
+	}
+	if err = bec.download(entries); err != nil {
+		return err
+	}
+	log.Info("Verifying the checksums of", len(index.Artifacts), "exported artifacts...")
+	for _, artifact := range index.Artifacts {
+		if err = verifyBundleArtifact(artifact, filepath.Join(bundleDir, filepath.FromSlash(artifact.File))); err != nil {
+			return err
+		}
+	}
+	if bec.includeBuilds {
+		if err = bec.exportBuilds(servicesManager, index, bundleDir); err != nil {
+			return err
+		}
+	}
+	if err = writeBundleIndex(index, bundleDir); err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(bec.archivePath), 0755); err != nil {
+		return errorutils.CheckError(err)
+	}
+	log.Info("Packing the bundle into", bec.archivePath+"...")
+	if err = writeDirTarArchive(bec.archivePath, format, bundleDir); err != nil {
+		return err
+	}
+	log.Info(fmt.Sprintf("Exported %d artifacts and the build-info of %d builds to %s.", len(index.Artifacts), len(index.Builds), bec.archivePath))
+	return nil
+}
+
+// Adds the artifacts matched by the spec to the index, and returns the journal entries which download them into the bundle directory.
+// An artifact matched by several spec groups is exported once.
+func (bec *BundleExportCommand) collectArtifacts(servicesManager artifactory.ArtifactoryServicesManager, index *BundleIndex, bundleDir string) ([]*Entry, error) {
+	var entries []*Entry
+	collected := make(map[string]bool)
+	err := forEachDownloadTarget(servicesManager, bec.spec, func(item *servicesutils.ResultItem, _ string, _ int) error {
+		itemPath := item.GetItemRelativePath()
+		if collected[itemPath] {
+			return nil
+		}
+		collected[itemPath] = true
+		artifact := &BundleArtifact{Path: itemPath, File: path.Join(bundleFilesDir, itemPath), Size: item.Size,
+			Md5: item.Actual_Md5, Sha1: item.Actual_Sha1, Sha256: item.Sha256, Properties: make(map[string][]string)}
+		for _, property := range item.Properties {
+			artifact.Properties[property.Key] = append(artifact.Properties[property.Key], property.Value)
+		}
+		index.Artifacts = append(index.Artifacts, artifact)
+		bec.addPropertiesBuilds(artifact.Properties)
+		entries = append(entries, &Entry{Source: itemPath, Target: filepath.Join(bundleDir, filepath.FromSlash(artifact.File)), Size: item.Size, Sha256: item.Sha256, Status: Pending})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range bec.spec.Files {
+		if file.Build == "" {
+			continue
+		}
+		name, number, err := servicesutils.ParseNameAndVersion(file.Build, true)
+		if err != nil {
+			return nil, err
+		}
+		project := file.Project
+		if project == "" {
+			project = bec.project
+		}
+		bec.addBuild(name, number, project)
+	}
+	return entries, nil
+}
+
+// Adds the builds an artifact is attached to by its build properties.
+// An artifact attached to several builds has several build names and numbers, which are matched by their order.
+func (bec *BundleExportCommand) addPropertiesBuilds(properties map[string][]string) {
+	names, numbers := properties[buildNameProperty], properties[buildNumberProperty]
+	if len(names) != len(numbers) {
+		log.Debug("Skipping the build properties", names, numbers, "since the number of build names and build numbers is different.")
+		return
+	}
+	for i := range names {
+		bec.addBuild(names[i], numbers[i], bec.project)
+	}
+}
+
+func (bec *BundleExportCommand) addBuild(name, number, project string) {
+	if name == "" || number == "" {
+		return
+	}
+	key := name + "/" + number + "/" + project
+	if bec.builds[key] == nil {
+		bec.builds[key] = &BundleBuild{Name: name, Number: number, Project: project}
+	}
+}
+
+// Downloads the artifacts into the bundle directory, using the download command of this package.
+func (bec *BundleExportCommand) download(entries []*Entry) error {
+	downloadCommand := NewDownloadCommand()
+	downloadCommand.SetConfiguration(bec.configuration).SetSpec(&spec.SpecFiles{Files: []spec.File{{}}}).
+		SetServerDetails(bec.serverDetails).SetRetries(bec.retries).SetRetryWaitMilliSecs(bec.retryWaitTimeMilliSecs)
+	if bec.progress != nil {
+		downloadCommand.SetProgress(bec.progress)
+	}
+	journal := NewJournal("", downloadCommand.CommandName(), "")
+	journal.Entries = entries
+	err := downloadCommand.runJournal(journal, downloadCommand)
+	bec.result = downloadCommand.Result()
+	if err != nil {
+		return err
+	}
+	if bec.result.FailCount() > 0 {
+		return errorutils.CheckErrorf("failed downloading %d of the artifacts to export", bec.result.FailCount())
+	}
+	return nil
+}
+
+// Saves the build-info of the collected builds in the bundle directory, and adds them to the index.
+// A build whose build-info isn't found is skipped, since the artifacts may remain attached to a build which was deleted.
+func (bec *BundleExportCommand) exportBuilds(servicesManager artifactory.ArtifactoryServicesManager, index *BundleIndex, bundleDir string) error {
+	if len(bec.builds) == 0 {
+		return nil
+	}
+	log.Info("Exporting the build-info of", len(bec.builds), "builds...")
+	if err := os.MkdirAll(filepath.Join(bundleDir, bundleBuildsDir), 0755); err != nil {
+		return errorutils.CheckError(err)
+	}
+	exported := make(map[string]bool)
+	for _, build := range sortedBundleBuilds(bec.builds) {
+		publishedBuildInfo, found, err := servicesManager.GetBuildInfo(services.BuildInfoParams{BuildName: build.Name, BuildNumber: build.Number, ProjectKey: build.Project})
+		if err != nil {
+			return err
+		}
+		if !found {
+			log.Warn("The build-info of", build.Name+"/"+build.Number, "wasn't found, so it isn't included in the bundle.")
+			continue
+		}
+		buildInfo := publishedBuildInfo.BuildInfo
+		// The LATEST build number is resolved when the build-info is read, so the same build may be collected twice.
+		key := buildInfo.Name + "/" + buildInfo.Number + "/" + build.Project
+		if exported[key] {
+			continue
+		}
+		exported[key] = true
+		content, err := json.MarshalIndent(buildInfo, "", "  ")
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		file := path.Join(bundleBuildsDir, strconv.Itoa(len(index.Builds)+1)+".json")
+		if err = ioutil.WriteFile(filepath.Join(bundleDir, filepath.FromSlash(file)), content, 0644); err != nil {
+			return errorutils.CheckError(err)
+		}
+		index.Builds = append(index.Builds, &BundleBuild{Name: buildInfo.Name, Number: buildInfo.Number, Project: build.Project, File: file})
+	}
+	return nil
+}
@@ -0,0 +1,259 @@
+package transfer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	commandsutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	ioUtils "github.com/jfrog/jfrog-client-go/utils/io"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Imports a bundle archive, which was written by the bundle-export command, into Artifactory.
+// The artifacts are deployed to the same paths and with the same properties they had when they were exported, using the upload command of this package.
+// Their checksums are verified against the index before they are deployed, and against the checksums reported by Artifactory once they are deployed.
+// The build-info of the builds in the bundle is published once all of the artifacts are deployed.
+type BundleImportCommand struct {
+	serverDetails          *config.ServerDetails
+	configuration          *utils.UploadConfiguration
+	archivePath            string
+	project                string
+	retries                int
+	retryWaitTimeMilliSecs int
+	dryRun                 bool
+	progress               ioUtils.ProgressMgr
+	result                 *commandsutils.Result
+}
+
+func NewBundleImportCommand() *BundleImportCommand {
+	return &BundleImportCommand{result: new(commandsutils.Result)}
+}
+
+func (bic *BundleImportCommand) SetServerDetails(serverDetails *config.ServerDetails) *BundleImportCommand {
+	bic.serverDetails = serverDetails
+	return bic
+}
+
+func (bic *BundleImportCommand) SetConfiguration(configuration *utils.UploadConfiguration) *BundleImportCommand {
+	bic.configuration = configuration
+	return bic
+}
+
+func (bic *BundleImportCommand) SetArchivePath(archivePath string) *BundleImportCommand {
+	bic.archivePath = archivePath
+	return bic
+}
+
+// The project the builds are published to. When empty, each build is published to the project it was exported from.
+func (bic *BundleImportCommand) SetProject(project string) *BundleImportCommand {
+	bic.project = project
+	return bic
+}
+
+func (bic *BundleImportCommand) SetRetries(retries int) *BundleImportCommand {
+	bic.retries = retries
+	return bic
+}
+
+func (bic *BundleImportCommand) SetRetryWaitMilliSecs(retryWaitMilliSecs int) *BundleImportCommand {
+	bic.retryWaitTimeMilliSecs = retryWaitMilliSecs
+	return bic
+}
+
+// In a dry run, the bundle is extracted and verified, but nothing is deployed.
+func (bic *BundleImportCommand) SetDryRun(dryRun bool) *BundleImportCommand {
+	bic.dryRun = dryRun
+	return bic
+}
+
+func (bic *BundleImportCommand) SetProgress(progress ioUtils.ProgressMgr) {
+	bic.progress = progress
+}
+
+func (bic *BundleImportCommand) ServerDetails() (*config.ServerDetails, error) {
+	return bic.serverDetails, nil
+}
+
+func (bic *BundleImportCommand) Result() *commandsutils.Result {
+	return bic.result
+}
+
+func (bic *BundleImportCommand) CommandName() string {
+	return "rt_bundle_import"
+}
+
+func (bic *BundleImportCommand) Run() (err error) {
+	if _, err = getBundleFormat(bic.archivePath); err != nil {
+		return err
+	}
+	archive, err := newArchive(bic.archivePath)
+	if err != nil {
+		return err
+	}
+	bundleDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if e := fileutils.RemoveTempDir(bundleDir); err == nil {
+			err = e
+		}
+	}()
+	if err = unpackArchive(archive, bic.archivePath, bundleDir); err != nil {
+		return err
+	}
+	index, err := readBundleIndex(bundleDir)
+	if err != nil {
+		return err
+	}
+	entries, uploadSpec, err := bic.createEntries(index, bundleDir)
+	if err != nil {
+		return err
+	}
+	if bic.dryRun {
+		for _, entry := range entries {
+			log.Info("[Dry run] Deploying", entry.Target)
+		}
+		for _, build := range index.Builds {
+			log.Info("[Dry run] Publishing the build-info of", build.Name+"/"+build.Number)
+		}
+		bic.result.SetSuccessCount(len(entries))
+		return nil
+	}
+	if err = bic.upload(entries, uploadSpec); err != nil {
+		return err
+	}
+	if err = bic.verifyDeployed(index); err != nil {
+		return err
+	}
+	if err = bic.publishBuilds(index, bundleDir); err != nil {
+		return err
+	}
+	log.Info(fmt.Sprintf("Imported %d artifacts and the build-info of %d builds from %s.", len(index.Artifacts), len(index.Builds), bic.archivePath))
+	return nil
+}
+
+// Verifies the checksums of the extracted artifacts, and returns the journal entries which deploy them.
+// Each artifact has a spec group of its own, which holds its properties.
+func (bic *BundleImportCommand) createEntries(index *BundleIndex, bundleDir string) ([]*Entry, *spec.SpecFiles, error) {
+	log.Info("Verifying the checksums of", len(index.Artifacts), "artifacts in the bundle...")
+	var entries []*Entry
+	uploadSpec := &spec.SpecFiles{}
+	failed := 0
+	for _, artifact := range index.Artifacts {
+		localPath, err := getBundleFilePath(bundleDir, artifact.File)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err = verifyBundleArtifact(artifact, localPath); err != nil {
+			log.Error(err.Error())
+			failed++
+			continue
+		}
+		props := formatBundleProperties(artifact.Properties)
+		entries = append(entries, &Entry{Source: localPath, Target: artifact.Path, Size: artifact.Size, Sha256: artifact.Sha256, Props: props, SpecIndex: len(uploadSpec.Files), Status: Pending})
+		uploadSpec.Files = append(uploadSpec.Files, spec.File{Target: artifact.Path, TargetProps: props})
+	}
+	if failed > 0 {
+		return nil, nil, errorutils.CheckErrorf("the checksums of %d artifacts in the bundle don't match its index", failed)
+	}
+	return entries, uploadSpec, nil
+}
+
+// Deploys the artifacts using the upload command of this package.
+func (bic *BundleImportCommand) upload(entries []*Entry, uploadSpec *spec.SpecFiles) error {
+	uploadCommand := NewUploadCommand()
+	uploadCommand.SetUploadConfiguration(bic.configuration).SetSpec(uploadSpec).SetServerDetails(bic.serverDetails).
+		SetDetailedSummary(true).SetRetries(bic.retries).SetRetryWaitMilliSecs(bic.retryWaitTimeMilliSecs)
+	if bic.progress != nil {
+		uploadCommand.SetProgress(bic.progress)
+	}
+	journal := NewJournal("", uploadCommand.CommandName(), "")
+	journal.Entries = entries
+	err := uploadCommand.runJournal(journal, uploadCommand)
+	bic.result = uploadCommand.Result()
+	if err != nil {
+		return err
+	}
+	if bic.result.FailCount() > 0 {
+		return errorutils.CheckErrorf("failed deploying %d of the artifacts in the bundle. The build-info of the bundle isn't published", bic.result.FailCount())
+	}
+	return nil
+}
+
+// Compares the checksums reported by Artifactory for the deployed artifacts with the checksums in the index.
+func (bic *BundleImportCommand) verifyDeployed(index *BundleIndex) error {
+	reader := bic.result.Reader()
+	if reader == nil {
+		return nil
+	}
+	defer func() {
+		reader.Close()
+		bic.result.SetReader(nil)
+	}()
+	deployed := make(map[string]string)
+	for transferDetails := new(clientutils.FileTransferDetails); reader.NextRecord(transferDetails) == nil; transferDetails = new(clientutils.FileTransferDetails) {
+		deployedPath, err := getArtifactoryPath(bic.serverDetails.ArtifactoryUrl, transferDetails.TargetPath)
+		if err != nil {
+			return err
+		}
+		deployed[deployedPath] = transferDetails.Sha256
+	}
+	if err := reader.GetError(); err != nil {
+		return err
+	}
+	mismatched := 0
+	for _, artifact := range index.Artifacts {
+		sha256, found := deployed[artifact.Path]
+		if !found || sha256 == "" || artifact.Sha256 == "" || sha256 == artifact.Sha256 {
+			continue
+		}
+		log.Error(fmt.Sprintf("The sha256 checksum of the deployed %s is %s, while the expected checksum is %s", artifact.Path, sha256, artifact.Sha256))
+		mismatched++
+	}
+	if mismatched > 0 {
+		return errorutils.CheckErrorf("the checksums of %d deployed artifacts don't match the bundle index. The build-info of the bundle isn't published", mismatched)
+	}
+	return nil
+}
+
+func (bic *BundleImportCommand) publishBuilds(index *BundleIndex, bundleDir string) error {
+	if len(index.Builds) == 0 {
+		return nil
+	}
+	servicesManager, err := utils.CreateServiceManager(bic.serverDetails, bic.retries, bic.retryWaitTimeMilliSecs, false)
+	if err != nil {
+		return err
+	}
+	for _, build := range index.Builds {
+		buildPath, err := getBundleFilePath(bundleDir, build.File)
+		if err != nil {
+			return err
+		}
+		content, err := ioutil.ReadFile(buildPath)
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		buildInfo := new(buildinfo.BuildInfo)
+		if err = json.Unmarshal(content, buildInfo); err != nil {
+			return errorutils.CheckErrorf("failed parsing the build-info of %s/%s: %s", build.Name, build.Number, err.Error())
+		}
+		project := bic.project
+		if project == "" {
+			project = build.Project
+		}
+		log.Info("Publishing the build-info of", build.Name+"/"+build.Number+"...")
+		if _, err = servicesManager.PublishBuildInfo(buildInfo, project); err != nil {
+			return err
+		}
+	}
+	return nil
+}
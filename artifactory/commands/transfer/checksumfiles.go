@@ -0,0 +1,133 @@
+package transfer
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	// A .sha256 and a .md5 file are deployed next to each uploaded file.
+	ChecksumFilesSidecar = "sidecar"
+	// A SHA256SUMS file, listing the checksums of the uploaded files, is deployed to each folder.
+	ChecksumFilesManifest = "manifest"
+	ChecksumsManifestName = "SHA256SUMS"
+)
+
+func ValidateChecksumFilesMode(mode string) error {
+	if mode != ChecksumFilesSidecar && mode != ChecksumFilesManifest {
+		return errorutils.CheckErrorf("the --checksum-files option accepts one of the following values: %s, %s", ChecksumFilesSidecar, ChecksumFilesManifest)
+	}
+	return nil
+}
+
+// Deploys the checksum files of the files uploaded by an upload command, which are read from the detailed summary of the command.
+// The checksums are calculated from the local files, which were already verified by Artifactory during the upload.
+func DeployChecksumFiles(serverDetails *config.ServerDetails, mode string, reader *content.ContentReader, retries, retryWaitMilliSecs int) error {
+	files, err := readUploadedFiles(serverDetails.ArtifactoryUrl, reader)
+	if err != nil || len(files) == 0 {
+		return err
+	}
+	deployer, err := newSidecarDeployer(serverDetails, retries, retryWaitMilliSecs)
+	if err != nil {
+		return err
+	}
+	log.Info("Deploying the checksum files of", len(files), "uploaded files...")
+	if mode == ChecksumFilesManifest {
+		deployManifests(deployer, files)
+	} else {
+		deployChecksumSidecars(deployer, files)
+	}
+	if deployer.failed > 0 {
+		return errorutils.CheckErrorf("failed deploying %d checksum files", deployer.failed)
+	}
+	log.Info("Deployed", deployer.deployed, "checksum files.")
+	return nil
+}
+
+func deployChecksumSidecars(deployer *sidecarDeployer, files []*uploadedFile) {
+	for _, file := range files {
+		checksums, err := calcFileChecksums(file.source)
+		if err != nil {
+			deployer.fail(file.path+".sha256", err)
+			continue
+		}
+		name := path.Base(file.path)
+		deployer.deploy(file.path+".sha256", formatChecksumLine(checksums.sha256(), name))
+		deployer.deploy(file.path+".md5", formatChecksumLine(checksums.md5(), name))
+	}
+}
+
+// The checksums of the uploaded files are merged into the existing manifest of each folder, so that files uploaded by previous commands remain listed.
+func deployManifests(deployer *sidecarDeployer, files []*uploadedFile) {
+	folders := make(map[string][]*uploadedFile)
+	for _, file := range files {
+		folders[path.Dir(file.path)] = append(folders[path.Dir(file.path)], file)
+	}
+	for folder, folderFiles := range folders {
+		manifestPath := path.Join(folder, ChecksumsManifestName)
+		manifestContent, _, err := deployer.read(manifestPath)
+		if err != nil {
+			deployer.fail(manifestPath, err)
+			continue
+		}
+		manifest := parseChecksumsManifest(manifestContent)
+		for _, file := range folderFiles {
+			checksums, e := calcFileChecksums(file.source)
+			if e != nil {
+				err = e
+				break
+			}
+			manifest[path.Base(file.path)] = checksums.sha256()
+		}
+		if err != nil {
+			deployer.fail(manifestPath, err)
+			continue
+		}
+		deployer.deploy(manifestPath, formatChecksumsManifest(manifest))
+	}
+}
+
+// Checksum files use the format of the sha256sum and md5sum tools, so that they can be verified using these tools.
+func formatChecksumLine(checksum, name string) []byte {
+	return []byte(fmt.Sprintf("%s  %s\n", checksum, name))
+}
+
+func formatChecksumsManifest(checksums map[string]string) []byte {
+	names := make([]string, 0, len(checksums))
+	for name := range checksums {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var buffer bytes.Buffer
+	for _, name := range names {
+		buffer.Write(formatChecksumLine(checksums[name], name))
+	}
+	return buffer.Bytes()
+}
+
+// Parses a manifest in the format of the sha256sum tool. Lines which aren't in this format are ignored.
+func parseChecksumsManifest(manifest []byte) map[string]string {
+	checksums := make(map[string]string)
+	scanner := bufio.NewScanner(bytes.NewReader(manifest))
+	for scanner.Scan() {
+		fields := strings.SplitN(strings.TrimSpace(scanner.Text()), " ", 2)
+		if len(fields) != 2 {
+			continue
+		}
+		// A name starting with an asterisk marks a file which was read in binary mode.
+		name := strings.TrimPrefix(strings.TrimLeft(fields[1], " "), "*")
+		if name != "" {
+			checksums[name] = fields[0]
+		}
+	}
+	return checksums
+}
@@ -0,0 +1,36 @@
+package transfer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetUploadedPath(t *testing.T) {
+	artifactoryUrl := "http://localhost:8081/artifactory/"
+	uploadedPath, err := getArtifactoryPath(artifactoryUrl, artifactoryUrl+"repo/a%20b/c.txt")
+	assert.NoError(t, err)
+	assert.Equal(t, "repo/a b/c.txt", uploadedPath)
+	// The properties of the file are removed.
+	uploadedPath, err = getArtifactoryPath("http://localhost:8081/artifactory", artifactoryUrl+"repo/a;b/c.txt;key=value")
+	assert.NoError(t, err)
+	assert.Equal(t, "repo/a;b/c.txt", uploadedPath)
+}
+
+func TestIsSidecarFile(t *testing.T) {
+	assert.True(t, isSidecarFile("repo/a/c.txt.sha256"))
+	assert.True(t, isSidecarFile("repo/a/c.txt.md5"))
+	assert.True(t, isSidecarFile("repo/a/SHA256SUMS"))
+	assert.True(t, isSidecarFile("repo/a/c.txt.asc"))
+	assert.True(t, isSidecarFile("repo/a/c.txt.sig"))
+	assert.False(t, isSidecarFile("repo/a/c.txt"))
+}
+
+func TestChecksumsManifest(t *testing.T) {
+	checksums := parseChecksumsManifest([]byte("aaa  b.txt\nbbb *a.txt\n\ninvalid\nccc  name with spaces\n"))
+	assert.Equal(t, map[string]string{"a.txt": "bbb", "b.txt": "aaa", "name with spaces": "ccc"}, checksums)
+
+	checksums["b.txt"] = "ddd"
+	assert.Equal(t, "bbb  a.txt\nddd  b.txt\nccc  name with spaces\n", string(formatChecksumsManifest(checksums)))
+	assert.Equal(t, checksums, parseChecksumsManifest(formatChecksumsManifest(checksums)))
+}
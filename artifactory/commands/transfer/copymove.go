@@ -0,0 +1,204 @@
+package transfer
+
+import (
+	"net/http"
+	"path"
+	"strings"
+
+	commandsutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	rtutils "github.com/jfrog/jfrog-cli/artifactory/utils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+var movingMsgs = map[services.MoveType]string{
+	services.MOVE: "Moving",
+	services.COPY: "Copying",
+}
+
+// Copies or moves the artifacts matching a file spec, one artifact at a time.
+type CopyMoveCommand struct {
+	remoteCommand
+	moveType services.MoveType
+}
+
+func NewCopyCommand() *CopyMoveCommand {
+	return &CopyMoveCommand{remoteCommand: remoteCommand{progressLabel: movingMsgs[services.COPY], result: new(commandsutils.Result)}, moveType: services.COPY}
+}
+
+func NewMoveCommand() *CopyMoveCommand {
+	return &CopyMoveCommand{remoteCommand: remoteCommand{progressLabel: movingMsgs[services.MOVE], result: new(commandsutils.Result)}, moveType: services.MOVE}
+}
+
+func (cmc *CopyMoveCommand) CommandName() string {
+	return "rt_" + string(cmc.moveType)
+}
+
+func (cmc *CopyMoveCommand) Run() error {
+	servicesManager, err := cmc.createOperationsServiceManager()
+	if err != nil {
+		return err
+	}
+	return cmc.runOperations(func(addOperation addOperationFunc) error {
+		return cmc.collectMoveCopyItems(func(item servicesutils.ResultItem, target string) {
+			cmc.addMoveCopyOperation(servicesManager, item.GetItemRelativePath(), target, addOperation)
+		})
+	})
+}
+
+// Searches the artifacts matching the spec, and calls handleItem with each artifact and its destination.
+// A destination which ends with a slash is a folder, which is created before the folder is copied or moved.
+func (cmc *CopyMoveCommand) collectMoveCopyItems(handleItem func(item servicesutils.ResultItem, target string)) error {
+	searchServicesManager, err := utils.CreateServiceManager(cmc.serverDetails, cmc.retries, cmc.retryWaitTimeMilliSecs, false)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < len(cmc.spec.Files); i++ {
+		params, err := getMoveCopyParams(cmc.spec.Get(i))
+		if err != nil {
+			return err
+		}
+		reader, err := searchPathsToMoveCopy(params, rtutils.NewSearchConf(searchServicesManager))
+		if err != nil {
+			return err
+		}
+		for item := new(servicesutils.ResultItem); reader.NextRecord(item) == nil; item = new(servicesutils.ResultItem) {
+			var target string
+			if target, err = getMoveCopyItemTarget(params, *item); err != nil {
+				break
+			}
+			handleItem(*item, target)
+		}
+		if err == nil {
+			err = reader.GetError()
+		}
+		reader.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func getMoveCopyItemTarget(params services.MoveCopyParams, item servicesutils.ResultItem) (string, error) {
+	target, err := getDestinationPath(params.Target, params.Pattern, item.Path, item.GetItemRelativePath(), params.IsFlat())
+	if err != nil {
+		return "", err
+	}
+	if strings.HasSuffix(target, "/") && item.Type != "folder" {
+		target += item.Name
+	}
+	return target, nil
+}
+
+func (cmc *CopyMoveCommand) addMoveCopyOperation(servicesManager artifactory.ArtifactoryServicesManager, source, target string, addOperation addOperationFunc) {
+	addOperation(source, target, func(logMsgPrefix string) (bool, error) {
+		if strings.HasSuffix(target, "/") && !cmc.dryRun {
+			if shouldRetry, err := createPath(servicesManager, target); err != nil {
+				return shouldRetry, err
+			}
+		}
+		return cmc.moveCopy(servicesManager, source, target, logMsgPrefix)
+	})
+}
+
+func (cmc *CopyMoveCommand) moveCopy(servicesManager artifactory.ArtifactoryServicesManager, source, target, logMsgPrefix string) (bool, error) {
+	message := movingMsgs[cmc.moveType] + " artifact: " + source + " to: " + target
+	params := map[string]string{"to": target}
+	if cmc.dryRun {
+		log.Info(logMsgPrefix+"[Dry run]", message)
+		params["dry"] = "1"
+	} else {
+		log.Info(logMsgPrefix + message)
+	}
+	serviceDetails := servicesManager.GetConfig().GetServiceDetails()
+	requestFullUrl, err := servicesutils.BuildArtifactoryUrl(serviceDetails.GetUrl(), path.Join("api", string(cmc.moveType), source), params)
+	if err != nil {
+		return false, err
+	}
+	httpClientsDetails := serviceDetails.CreateHttpClientDetails()
+	resp, body, err := servicesManager.Client().SendPost(requestFullUrl, nil, &httpClientsDetails)
+	return checkOperationResponse(resp, body, err, http.StatusOK)
+}
+
+// Creates a folder in Artifactory.
+func createPath(servicesManager artifactory.ArtifactoryServicesManager, folderPath string) (bool, error) {
+	serviceDetails := servicesManager.GetConfig().GetServiceDetails()
+	requestFullUrl, err := servicesutils.BuildArtifactoryUrl(serviceDetails.GetUrl(), folderPath, map[string]string{})
+	if err != nil {
+		return false, err
+	}
+	httpClientsDetails := serviceDetails.CreateHttpClientDetails()
+	resp, body, err := servicesManager.Client().SendPut(requestFullUrl, nil, &httpClientsDetails)
+	return checkOperationResponse(resp, body, err, http.StatusCreated, http.StatusOK)
+}
+
+// Searches the artifacts to copy or move, the same way the copy and move services of the client do.
+func searchPathsToMoveCopy(params services.MoveCopyParams, conf servicesutils.CommonConf) (*content.ContentReader, error) {
+	log.Info("Searching artifacts...")
+	switch params.GetSpecType() {
+	case servicesutils.BUILD:
+		return servicesutils.SearchBySpecWithBuild(params.GetFile(), conf)
+	case servicesutils.AQL:
+		return servicesutils.SearchBySpecWithAql(params.GetFile(), conf, servicesutils.NONE)
+	default:
+		params.SetIncludeDir(true)
+		reader, err := servicesutils.SearchBySpecWithPattern(params.GetFile(), conf, servicesutils.NONE)
+		if err != nil {
+			return nil, err
+		}
+		defer reader.Close()
+		// When placeholders are used, the file path shouldn't be taken into account (or in other words, flat = true).
+		if params.IsFlat() || clientutils.PlaceholdersUserd(params.Pattern, params.Target) {
+			return servicesutils.ReduceBottomChainDirResult(servicesutils.ResultItem{}, reader)
+		}
+		return servicesutils.ReduceTopChainDirResult(servicesutils.ResultItem{}, reader)
+	}
+}
+
+// Returns the destination path of the copy or move, the same way the copy and move services of the client do.
+func getDestinationPath(specTarget, specPattern, sourceItemPath, sourceItemRelativePath string, isFlat bool) (string, error) {
+	// Apply placeholders.
+	destFile, placeholdersUsed, err := clientutils.BuildTargetPath(specPattern, sourceItemRelativePath, specTarget, true)
+	if err != nil {
+		return "", err
+	}
+	// When placeholders are used, the file path shouldn't be taken into account (or in other words, flat = true).
+	if isFlat || placeholdersUsed {
+		return destFile, nil
+	}
+	if strings.Contains(specTarget, "/") {
+		file, dir := fileutils.GetFileAndDirFromPath(specTarget)
+		return clientutils.TrimPath(dir + "/" + sourceItemPath + "/" + file), nil
+	}
+	return clientutils.TrimPath(specTarget + "/" + sourceItemPath + "/"), nil
+}
+
+func getMoveCopyParams(f *spec.File) (moveParams services.MoveCopyParams, err error) {
+	moveParams = services.NewMoveCopyParams()
+	moveParams.CommonParams, err = f.ToCommonParams()
+	if err != nil {
+		return
+	}
+	moveParams.Recursive, err = f.IsRecursive(true)
+	if err != nil {
+		return
+	}
+	moveParams.ExcludeArtifacts, err = f.IsExcludeArtifacts(false)
+	if err != nil {
+		return
+	}
+	moveParams.IncludeDeps, err = f.IsIncludeDeps(false)
+	if err != nil {
+		return
+	}
+	moveParams.Flat, err = f.IsFlat(false)
+	return
+}
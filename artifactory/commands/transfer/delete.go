@@ -0,0 +1,150 @@
+package transfer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/generic"
+	commandsutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	rtutils "github.com/jfrog/jfrog-cli/artifactory/utils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Deletes the artifacts matching a file spec, one artifact at a time.
+type DeleteCommand struct {
+	remoteCommand
+	items *content.ContentReader
+}
+
+func NewDeleteCommand() *DeleteCommand {
+	return &DeleteCommand{remoteCommand: remoteCommand{progressLabel: "Deleting", result: new(commandsutils.Result)}}
+}
+
+// Sets the items to delete, instead of the items matching the spec.
+// The reader is read by the command, but isn't closed by it.
+func (dc *DeleteCommand) SetItems(items *content.ContentReader) *DeleteCommand {
+	dc.items = items
+	return dc
+}
+
+func (dc *DeleteCommand) CommandName() string {
+	return "rt_delete"
+}
+
+// Returns the paths the command deletes, so that they can be confirmed before the command runs.
+// The paths are collected by the generic delete command, so that both commands delete the same paths.
+func (dc *DeleteCommand) GetPathsToDelete() (*content.ContentReader, error) {
+	genericDeleteCommand := generic.NewDeleteCommand()
+	genericDeleteCommand.SetServerDetails(dc.serverDetails).SetSpec(dc.spec).SetRetries(dc.retries).SetRetryWaitMilliSecs(dc.retryWaitTimeMilliSecs)
+	return genericDeleteCommand.GetPathsToDelete()
+}
+
+// Deletes the paths without asking for a confirmation. The caller should confirm them before running the command.
+func (dc *DeleteCommand) Run() error {
+	reader := dc.items
+	if reader == nil {
+		var err error
+		reader, err = dc.GetPathsToDelete()
+		if err != nil {
+			return err
+		}
+		defer reader.Close()
+	}
+	servicesManager, err := dc.createOperationsServiceManager()
+	if err != nil {
+		return err
+	}
+	if dc.detailedSummary {
+		if dc.reportedPaths, err = listFoldersFiles(servicesManager, reader); err != nil {
+			return err
+		}
+	}
+	return dc.runOperations(func(addOperation addOperationFunc) error {
+		for item := new(servicesutils.ResultItem); reader.NextRecord(item) == nil; item = new(servicesutils.ResultItem) {
+			itemPath := item.GetItemRelativePath()
+			addOperation(itemPath, "", func(logMsgPrefix string) (bool, error) {
+				log.Info(logMsgPrefix+"Deleting", itemPath)
+				if dc.dryRun {
+					return false, nil
+				}
+				return deleteArtifact(servicesManager, itemPath)
+			})
+		}
+		return reader.GetError()
+	})
+}
+
+// Returns the files under each of the folders to delete, by the path of the folder.
+// The paths to delete are folded into folders, while the detailed summary lists each deleted file, so the files are listed before they are deleted.
+func listFoldersFiles(servicesManager artifactory.ArtifactoryServicesManager, reader *content.ContentReader) (map[string][]string, error) {
+	foldersFiles := make(map[string][]string)
+	for item := new(servicesutils.ResultItem); reader.NextRecord(item) == nil; item = new(servicesutils.ResultItem) {
+		if item.Type != "folder" {
+			continue
+		}
+		files, err := listFolderFiles(servicesManager, item)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			foldersFiles[item.GetItemRelativePath()] = append(foldersFiles[item.GetItemRelativePath()], file.GetItemRelativePath())
+		}
+	}
+	if err := reader.GetError(); err != nil {
+		return nil, err
+	}
+	reader.Reset()
+	return foldersFiles, nil
+}
+
+// Returns the files under a folder, in all of its levels, with their sizes and sha256 checksums.
+func listFolderFiles(servicesManager artifactory.ArtifactoryServicesManager, folder *servicesutils.ResultItem) ([]servicesutils.ResultItem, error) {
+	folderPath := path.Join(folder.Path, folder.Name)
+	pathQuery := fmt.Sprintf(`"$or":[{"path":%s},{"path":{"$match":%s}}]`, quoteAqlValue(folderPath), quoteAqlValue(folderPath+"/*"))
+	if folderPath == "." {
+		pathQuery = `"path":{"$match":"*"}`
+	}
+	query := fmt.Sprintf(`items.find({"repo":%s,%s,"type":"file"}).include("repo","path","name","size","sha256")`, quoteAqlValue(folder.Repo), pathQuery)
+	reader, err := servicesutils.ExecAqlSaveToFile(query, rtutils.NewSearchConf(servicesManager))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	var files []servicesutils.ResultItem
+	for item := new(servicesutils.ResultItem); reader.NextRecord(item) == nil; item = new(servicesutils.ResultItem) {
+		// Wildcards in the path of the folder are matched by the query as well, so the files of other folders are filtered out.
+		if folderPath == "." || item.Path == folderPath || strings.HasPrefix(item.Path, folderPath+"/") {
+			files = append(files, *item)
+		}
+	}
+	return files, reader.GetError()
+}
+
+// Returns the value as a quoted AQL string, in which the quotes and backslashes of the value are escaped.
+func quoteAqlValue(value string) string {
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	// Encoding a string never fails.
+	_ = encoder.Encode(value)
+	return strings.TrimSuffix(buffer.String(), "\n")
+}
+
+// Deletes a single artifact or folder. Returns true if the deletion failed and should be retried.
+func deleteArtifact(servicesManager artifactory.ArtifactoryServicesManager, itemPath string) (bool, error) {
+	serviceDetails := servicesManager.GetConfig().GetServiceDetails()
+	deleteUrl, err := servicesutils.BuildArtifactoryUrl(serviceDetails.GetUrl(), itemPath, map[string]string{})
+	if err != nil {
+		return false, err
+	}
+	httpClientsDetails := serviceDetails.CreateHttpClientDetails()
+	resp, body, err := servicesManager.Client().SendDelete(deleteUrl, nil, &httpClientsDetails)
+	return checkOperationResponse(resp, body, err, http.StatusNoContent)
+}
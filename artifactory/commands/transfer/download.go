@@ -0,0 +1,338 @@
+package transfer
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/generic"
+	commandsutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-cli/utils/cache"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	ioUtils "github.com/jfrog/jfrog-client-go/utils/io"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Downloads the files of a file spec in batches, while keeping track of each file in a journal.
+// This allows resuming an interrupted download, and reporting the events of each file.
+type DownloadCommand struct {
+	transferCommand
+	configuration      *utils.DownloadConfiguration
+	buildConfiguration *utils.BuildConfiguration
+	cache              *cache.Cache
+}
+
+func NewDownloadCommand() *DownloadCommand {
+	return &DownloadCommand{transferCommand: transferCommand{result: new(commandsutils.Result)}}
+}
+
+func (rdc *DownloadCommand) SetConfiguration(configuration *utils.DownloadConfiguration) *DownloadCommand {
+	rdc.configuration = configuration
+	return rdc
+}
+
+func (rdc *DownloadCommand) SetBuildConfiguration(buildConfiguration *utils.BuildConfiguration) *DownloadCommand {
+	rdc.buildConfiguration = buildConfiguration
+	return rdc
+}
+
+// Files found in the cache are placed from it instead of being downloaded, and downloaded files are added to it.
+func (rdc *DownloadCommand) SetCache(downloadCache *cache.Cache) *DownloadCommand {
+	rdc.cache = downloadCache
+	return rdc
+}
+
+func (rdc *DownloadCommand) CommandName() string {
+	return "rt_download"
+}
+
+func (rdc *DownloadCommand) Run() error {
+	journal, err := rdc.loadOrCreateJournal(rdc.CommandName(), rdc.createEntries)
+	if err != nil {
+		return err
+	}
+	if rdc.resume {
+		rdc.markMissingFiles(journal)
+	}
+	err = rdc.runJournal(journal, rdc)
+	if rdc.cache != nil {
+		if files, size, e := rdc.cache.Prune(rdc.cache.MaxSize()); e != nil {
+			log.Warn("Failed pruning the download cache:", e.Error())
+		} else if files > 0 {
+			log.Debug(fmt.Sprintf("Removed %d files (%d bytes) from the download cache.", files, size))
+		}
+	}
+	return err
+}
+
+// Collects the files to download by searching Artifactory, once for each file spec group.
+func (rdc *DownloadCommand) createEntries() ([]*Entry, error) {
+	servicesManager, err := utils.CreateServiceManager(rdc.serverDetails, rdc.retries, rdc.retryWaitTimeMilliSecs, false)
+	if err != nil {
+		return nil, err
+	}
+	log.Info("Collecting the files to download...")
+	var entries []*Entry
+	err = forEachDownloadTarget(servicesManager, rdc.spec, func(item *servicesutils.ResultItem, localPath string, specIndex int) error {
+		entries = append(entries, &Entry{
+			Source:    item.GetItemRelativePath(),
+			Target:    localPath,
+			Size:      item.Size,
+			Sha256:    item.Sha256,
+			SpecIndex: specIndex,
+			Status:    Pending,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// Searches the files matching each file spec group, and calls the handler with the local path to which each file is downloaded.
+func forEachDownloadTarget(servicesManager artifactory.ArtifactoryServicesManager, downloadSpec *spec.SpecFiles,
+	handler func(item *servicesutils.ResultItem, localPath string, specIndex int) error) error {
+	for i := range downloadSpec.Files {
+		file := downloadSpec.Get(i)
+		searchParams, err := utils.GetSearchParams(file)
+		if err != nil {
+			return err
+		}
+		flat, err := file.IsFlat(false)
+		if err != nil {
+			return err
+		}
+		reader, err := servicesManager.SearchFiles(searchParams)
+		if err != nil {
+			return err
+		}
+		for item := new(servicesutils.ResultItem); reader.NextRecord(item) == nil; item = new(servicesutils.ResultItem) {
+			if item.Type == "folder" {
+				continue
+			}
+			target, placeholdersUsed, err := clientutils.BuildTargetPath(searchParams.GetPattern(), item.GetItemRelativePath(), file.Target, true)
+			if err == nil {
+				localPath, localFileName := fileutils.GetLocalPathAndFile(item.Name, item.Path, target, flat, placeholdersUsed)
+				err = handler(item, filepath.Join(localPath, localFileName), i)
+			}
+			if err != nil {
+				reader.Close()
+				return err
+			}
+		}
+		err = reader.GetError()
+		reader.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Downloaded files may have been removed since the journal was saved. Such files are downloaded again.
+func (rdc *DownloadCommand) markMissingFiles(journal *Journal) {
+	for _, entry := range journal.Entries {
+		if entry.Status != Done {
+			continue
+		}
+		if exists, err := fileutils.IsFileExists(entry.Target, false); err == nil && !exists {
+			entry.Status = Pending
+		}
+	}
+}
+
+func (rdc *DownloadCommand) resultKey(transferDetails *clientutils.FileTransferDetails) string {
+	return transferDetails.TargetPath
+}
+
+func (rdc *DownloadCommand) entryKey(entry *Entry) string {
+	return entry.Target
+}
+
+func (rdc *DownloadCommand) remotePath(entry *Entry) string {
+	return entry.Source
+}
+
+// The download progress is reported with the path of the downloaded file in Artifactory.
+func (rdc *DownloadCommand) progressPath(path string) string {
+	return path
+}
+
+func (rdc *DownloadCommand) runBatch(entries []*Entry, progress ioUtils.ProgressMgr) (*commandsutils.Result, error) {
+	if rdc.cache == nil {
+		return rdc.download(entries, progress)
+	}
+	var cached, toDownload []*Entry
+	for _, entry := range entries {
+		if rdc.isCacheable(entry) {
+			hit, err := rdc.cache.Get(entry.Sha256, entry.Target)
+			if err != nil {
+				log.Warn("Failed using the download cache for "+entry.Source+":", err.Error())
+			}
+			if hit {
+				log.Debug("Placed " + entry.Target + " from the download cache.")
+				cached = append(cached, entry)
+				continue
+			}
+		}
+		toDownload = append(toDownload, entry)
+	}
+	var result *commandsutils.Result
+	var err error
+	if len(toDownload) > 0 {
+		result, err = rdc.download(toDownload, progress)
+		for _, entry := range toDownload {
+			if !rdc.isCacheable(entry) {
+				continue
+			}
+			if exists, _ := fileutils.IsFileExists(entry.Target, false); !exists {
+				continue
+			}
+			if e := rdc.cache.Put(entry.Sha256, entry.Target); e != nil {
+				log.Warn("Failed adding "+entry.Target+" to the download cache:", e.Error())
+			}
+		}
+	}
+	if len(cached) == 0 {
+		return result, err
+	}
+	mergedResult, e := rdc.addCachedToResult(result, cached)
+	if err == nil {
+		err = e
+	}
+	return mergedResult, err
+}
+
+// Files which are extracted after the download are never cached, since the downloaded archive is removed once it's extracted.
+func (rdc *DownloadCommand) isCacheable(entry *Entry) bool {
+	return entry.Sha256 != "" && rdc.spec.Files[entry.SpecIndex].Explode != "true"
+}
+
+// Returns a result which includes the files placed from the cache, in addition to the downloaded files.
+func (rdc *DownloadCommand) addCachedToResult(result *commandsutils.Result, cached []*Entry) (*commandsutils.Result, error) {
+	var placed []clientutils.FileTransferDetails
+	for _, entry := range cached {
+		placed = append(placed, clientutils.FileTransferDetails{SourcePath: rdc.serverDetails.ArtifactoryUrl + entry.Source, TargetPath: entry.Target, Sha256: entry.Sha256})
+	}
+	return appendToResult(result, placed, 0)
+}
+
+// Downloads the entries with the generic download command.
+func (rdc *DownloadCommand) download(entries []*Entry, progress ioUtils.ProgressMgr) (*commandsutils.Result, error) {
+	batchSpec := rdc.createBatchSpec(entries)
+	downloadCmd := generic.NewDownloadCommand()
+	downloadCmd.SetConfiguration(rdc.configuration).SetBuildConfiguration(rdc.buildConfiguration).SetSpec(batchSpec).
+		SetServerDetails(rdc.serverDetails).SetDetailedSummary(true).SetRetries(rdc.retries).SetRetryWaitMilliSecs(rdc.retryWaitTimeMilliSecs)
+	if progress != nil {
+		downloadCmd.SetProgress(progress)
+	}
+	err := downloadCmd.Run()
+	if e := rdc.explodeArchives(entries, downloadCmd.Result()); err == nil {
+		err = e
+	}
+	return downloadCmd.Result(), err
+}
+
+// Returns the spec which downloads the entries of a batch.
+// The entries which are downloaded from the same spec group into the same directory are downloaded by a single spec group,
+// whose AQL query matches the exact paths of their files. This way, the files are searched once for each group,
+// and the wildcards and parentheses in their names aren't interpreted as a pattern.
+func (rdc *DownloadCommand) createBatchSpec(entries []*Entry) *spec.SpecFiles {
+	batchSpec := &spec.SpecFiles{}
+	groupsIndexes := make(map[downloadGroupKey]int)
+	var groupsItems [][]string
+	for _, entry := range entries {
+		key := getDownloadGroupKey(entry)
+		groupIndex, found := groupsIndexes[key]
+		if !found {
+			groupIndex = len(batchSpec.Files)
+			groupsIndexes[key] = groupIndex
+			groupsItems = append(groupsItems, nil)
+			batchSpec.Files = append(batchSpec.Files, rdc.createBatchFile(entry.SpecIndex, key.target))
+		}
+		groupsItems[groupIndex] = append(groupsItems[groupIndex], createAqlItemQuery(entry.Source))
+	}
+	for i := range batchSpec.Files {
+		batchSpec.Files[i].Aql = servicesutils.Aql{ItemsFind: `{"$or":[` + strings.Join(groupsItems[i], ",") + `]}`}
+	}
+	return batchSpec
+}
+
+// The entries of a batch which are downloaded by the same spec group.
+type downloadGroupKey struct {
+	specIndex int
+	// The directory of the downloaded files, ending with a separator,
+	// or the path of the downloaded file if it's downloaded under a different name.
+	target string
+}
+
+func getDownloadGroupKey(entry *Entry) downloadGroupKey {
+	target := entry.Target
+	if filepath.Base(entry.Target) == path.Base(entry.Source) {
+		target = filepath.Dir(entry.Target) + string(filepath.Separator)
+	}
+	return downloadGroupKey{specIndex: entry.SpecIndex, target: target}
+}
+
+// Returns a spec group which downloads files of the original spec group to the target.
+// The files are matched by the AQL query of the group, so the filters of the original group are cleared.
+func (rdc *DownloadCommand) createBatchFile(specIndex int, target string) spec.File {
+	file := rdc.spec.Files[specIndex]
+	file.Pattern = ""
+	file.Target = target
+	file.Exclusions = nil
+	file.Props = ""
+	file.ExcludeProps = ""
+	file.Build = ""
+	file.Bundle = ""
+	file.SortBy = nil
+	file.SortOrder = ""
+	file.Limit = 0
+	file.Offset = 0
+	file.Recursive = "false"
+	file.Flat = "true"
+	// The archives are extracted after the download, once they are inspected.
+	file.Explode = "false"
+	return file
+}
+
+// Returns the AQL criteria which match the exact path of a file, such as repo/a/b.zip.
+func createAqlItemQuery(itemPath string) string {
+	repo, relativePath := itemPath, ""
+	if slashIndex := strings.Index(itemPath, "/"); slashIndex >= 0 {
+		repo, relativePath = itemPath[:slashIndex], itemPath[slashIndex+1:]
+	}
+	return fmt.Sprintf(`{"repo":%s,"path":%s,"name":%s}`, quoteAqlValue(repo), quoteAqlValue(path.Dir(relativePath)), quoteAqlValue(path.Base(relativePath)))
+}
+
+// Extracts the downloaded archives of the spec groups which should be exploded.
+func (rdc *DownloadCommand) explodeArchives(entries []*Entry, result *commandsutils.Result) error {
+	reader := result.Reader()
+	if reader == nil {
+		return nil
+	}
+	downloaded := make(map[string]bool)
+	for transferDetails := new(clientutils.FileTransferDetails); reader.NextRecord(transferDetails) == nil; transferDetails = new(clientutils.FileTransferDetails) {
+		downloaded[filepath.Clean(transferDetails.TargetPath)] = true
+	}
+	if err := reader.GetError(); err != nil {
+		return err
+	}
+	reader.Reset()
+	for _, entry := range entries {
+		if rdc.spec.Files[entry.SpecIndex].Explode != "true" || !downloaded[filepath.Clean(entry.Target)] {
+			continue
+		}
+		if err := ExtractArchive(entry.Target, path.Base(entry.Source), filepath.Dir(entry.Target)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
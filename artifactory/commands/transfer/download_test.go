@@ -0,0 +1,66 @@
+package transfer
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	coretests "github.com/jfrog/jfrog-cli-core/v2/utils/tests"
+	"github.com/jfrog/jfrog-cli/utils/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDownloadFromCache(t *testing.T) {
+	tempDirPath, createTempDirCallback := coretests.CreateTempDirWithCallbackAndAssert(t)
+	defer createTempDirCallback()
+	downloadCache := cache.NewCache(filepath.Join(tempDirPath, "cache"), cache.DefaultMaxSize)
+	cachedPath := filepath.Join(tempDirPath, "cached.txt")
+	assert.NoError(t, ioutil.WriteFile(cachedPath, []byte("content"), 0644))
+	checksum, err := cache.CalcSha256(cachedPath)
+	assert.NoError(t, err)
+	assert.NoError(t, downloadCache.Put(checksum, cachedPath))
+
+	// Since all files are found in the cache, nothing is downloaded.
+	downloadCommand := NewDownloadCommand().SetCache(downloadCache)
+	downloadCommand.SetSpec(&spec.SpecFiles{Files: []spec.File{{Pattern: "repo/*.txt"}}}).SetServerDetails(&config.ServerDetails{ArtifactoryUrl: "http://localhost:8081/artifactory/"})
+	targetPath := filepath.Join(tempDirPath, "target", "a.txt")
+	journal := NewJournal("", downloadCommand.CommandName(), "")
+	journal.Entries = []*Entry{{Source: "repo/a.txt", Target: targetPath, Size: 7, Sha256: checksum, Status: Pending}}
+	assert.NoError(t, downloadCommand.runJournal(journal, downloadCommand))
+	assert.Equal(t, 1, downloadCommand.Result().SuccessCount())
+	assert.Equal(t, Done, journal.Entries[0].Status)
+	content, err := ioutil.ReadFile(targetPath)
+	assert.NoError(t, err)
+	assert.Equal(t, "content", string(content))
+}
+
+func TestCreateBatchSpec(t *testing.T) {
+	downloadCommand := NewDownloadCommand()
+	downloadCommand.SetSpec(&spec.SpecFiles{Files: []spec.File{{Pattern: "repo/*", Target: "out/", Props: "a=b"}, {Pattern: "repo/c.txt", Target: "renamed.txt"}}})
+	outDir := filepath.Join("out", "dir")
+	batchSpec := downloadCommand.createBatchSpec([]*Entry{
+		{Source: "repo/a (1).txt", Target: filepath.Join(outDir, "a (1).txt")},
+		{Source: "repo/sub/b*.txt", Target: filepath.Join(outDir, "b*.txt"), SpecIndex: 0},
+		{Source: "repo/c.txt", Target: "renamed.txt", SpecIndex: 1},
+	})
+	// The files downloaded into the same directory are matched by a single AQL query, with their exact paths.
+	if assert.Len(t, batchSpec.Files, 2) {
+		first := batchSpec.Files[0]
+		assert.Equal(t, `{"$or":[{"repo":"repo","path":".","name":"a (1).txt"},{"repo":"repo","path":"sub","name":"b*.txt"}]}`, first.Aql.ItemsFind)
+		assert.Equal(t, outDir+string(filepath.Separator), first.Target)
+		assert.Empty(t, first.Pattern)
+		assert.Empty(t, first.Props)
+		assert.Equal(t, "true", first.Flat)
+
+		second := batchSpec.Files[1]
+		assert.Equal(t, `{"$or":[{"repo":"repo","path":".","name":"c.txt"}]}`, second.Aql.ItemsFind)
+		assert.Equal(t, "renamed.txt", second.Target)
+	}
+}
+
+func TestCreateAqlItemQuery(t *testing.T) {
+	assert.Equal(t, `{"repo":"repo","path":"a/b","name":"c\"d.txt"}`, createAqlItemQuery(`repo/a/b/c"d.txt`))
+	assert.Equal(t, `{"repo":"repo","path":".","name":"<a>&b"}`, createAqlItemQuery("repo/<a>&b"))
+}
@@ -0,0 +1,104 @@
+package transfer
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/jfrog/jfrog-cli/utils/summary"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const EventsFormatNdjson = "ndjson"
+
+type EventType string
+
+const (
+	EventStarted   EventType = "started"
+	EventSucceeded EventType = "succeeded"
+	EventFailed    EventType = "failed"
+	EventSkipped   EventType = "skipped"
+	EventRetried   EventType = "retried"
+	EventSummary   EventType = "summary"
+)
+
+// A single line of the events stream.
+type Event struct {
+	Time    string           `json:"time"`
+	Command string           `json:"command"`
+	Type    EventType        `json:"event"`
+	Source  string           `json:"source,omitempty"`
+	Target  string           `json:"target,omitempty"`
+	Size    int64            `json:"size,omitempty"`
+	Attempt int              `json:"attempt,omitempty"`
+	Error   string           `json:"error,omitempty"`
+	Reason  string           `json:"reason,omitempty"`
+	Summary *summary.Summary `json:"summary,omitempty"`
+}
+
+// Writes the events of a command to the standard output, one JSON object per line.
+// The writer is safe for concurrent use. A nil writer writes nothing.
+type EventsWriter struct {
+	command string
+	mutex   sync.Mutex
+	// Used by tests to replace the standard output.
+	output func(line string)
+}
+
+func NewEventsWriter(command string) *EventsWriter {
+	return &EventsWriter{command: command, output: func(line string) { log.Output(line) }}
+}
+
+func (ew *EventsWriter) Started(source, target string, size int64) {
+	ew.write(Event{Type: EventStarted, Source: source, Target: target, Size: size, Attempt: 1})
+}
+
+func (ew *EventsWriter) Succeeded(source, target string, size int64) {
+	ew.write(Event{Type: EventSucceeded, Source: source, Target: target, Size: size})
+}
+
+func (ew *EventsWriter) Failed(source, target string, err error) {
+	event := Event{Type: EventFailed, Source: source, Target: target}
+	if err != nil {
+		event.Error = err.Error()
+	}
+	ew.write(event)
+}
+
+func (ew *EventsWriter) Skipped(source, target, reason string) {
+	ew.write(Event{Type: EventSkipped, Source: source, Target: target, Reason: reason})
+}
+
+func (ew *EventsWriter) Retried(source, target string, attempt int, err error) {
+	event := Event{Type: EventRetried, Source: source, Target: target, Attempt: attempt}
+	if err != nil {
+		event.Error = err.Error()
+	}
+	ew.write(event)
+}
+
+// Writes the final summary of the command as the last event of the stream.
+func (ew *EventsWriter) Summary(success, failed int, failNoOp bool, err error) {
+	event := Event{Type: EventSummary, Summary: summary.GetSummaryReport(success, failed, failNoOp, err)}
+	if err != nil {
+		event.Error = err.Error()
+	}
+	ew.write(event)
+}
+
+func (ew *EventsWriter) write(event Event) {
+	if ew == nil {
+		return
+	}
+	event.Time = time.Now().UTC().Format(time.RFC3339Nano)
+	event.Command = ew.command
+	content, err := json.Marshal(event)
+	if errorutils.CheckError(err) != nil {
+		log.Error(err)
+		return
+	}
+	ew.mutex.Lock()
+	defer ew.mutex.Unlock()
+	ew.output(string(content))
+}
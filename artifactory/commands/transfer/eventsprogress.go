@@ -0,0 +1,149 @@
+package transfer
+
+import (
+	"io"
+	"sync"
+
+	ioUtils "github.com/jfrog/jfrog-client-go/utils/io"
+)
+
+// A progress manager which reports the events of the journal entries.
+// The transfer client creates a progress reader for every attempt to transfer a file, so the first reader of a file
+// is reported as the start of its transfer, and every following reader is reported as a retry.
+// If another progress manager is provided, all calls are forwarded to it.
+type eventsProgress struct {
+	progress ioUtils.ProgressMgr
+	events   *EventsWriter
+	handler  transferHandler
+	// Maps the path of each entry in Artifactory to the entry.
+	entries map[string]*Entry
+	// The number of attempts made to transfer each entry.
+	attempts map[*Entry]int
+	mutex    sync.Mutex
+	lastId   int
+}
+
+func newEventsProgress(progress ioUtils.ProgressMgr, events *EventsWriter, entries []*Entry, handler transferHandler) *eventsProgress {
+	ep := &eventsProgress{progress: progress, events: events, handler: handler, entries: make(map[string]*Entry), attempts: make(map[*Entry]int)}
+	for _, entry := range entries {
+		ep.entries[handler.remotePath(entry)] = entry
+	}
+	return ep
+}
+
+func (ep *eventsProgress) NewProgressReader(total int64, label, path string) ioUtils.Progress {
+	ep.reportAttempt(ep.handler.progressPath(path))
+	if ep.progress != nil {
+		return ep.progress.NewProgressReader(total, label, path)
+	}
+	ep.mutex.Lock()
+	defer ep.mutex.Unlock()
+	ep.lastId++
+	return &nopProgress{id: ep.lastId}
+}
+
+func (ep *eventsProgress) reportAttempt(remotePath string) {
+	ep.mutex.Lock()
+	entry, ok := ep.entries[remotePath]
+	if !ok {
+		ep.mutex.Unlock()
+		return
+	}
+	ep.attempts[entry]++
+	attempt := ep.attempts[entry]
+	ep.mutex.Unlock()
+	if attempt == 1 {
+		ep.events.Started(entry.Source, entry.Target, entry.Size)
+		return
+	}
+	ep.events.Retried(entry.Source, entry.Target, attempt, nil)
+}
+
+// Reports the result of each entry of a transferred batch.
+// Files which were skipped by the transfer client, for example because their checksum was deployed, are reported as started first.
+func (ep *eventsProgress) reportBatch(batch []*Entry, batchErr error) {
+	for _, entry := range batch {
+		ep.mutex.Lock()
+		started := ep.attempts[entry] > 0
+		if !started {
+			ep.attempts[entry] = 1
+		}
+		ep.mutex.Unlock()
+		if !started {
+			ep.events.Started(entry.Source, entry.Target, entry.Size)
+		}
+		if entry.Status == Done {
+			ep.events.Succeeded(entry.Source, entry.Target, entry.Size)
+			continue
+		}
+		err := batchErr
+		if err == nil {
+			err = errTransferFailed
+		}
+		ep.events.Failed(entry.Source, entry.Target, err)
+	}
+}
+
+func (ep *eventsProgress) SetProgressState(id int, state string) {
+	if ep.progress != nil {
+		ep.progress.SetProgressState(id, state)
+	}
+}
+
+func (ep *eventsProgress) GetProgress(id int) ioUtils.Progress {
+	if ep.progress != nil {
+		return ep.progress.GetProgress(id)
+	}
+	return nil
+}
+
+func (ep *eventsProgress) RemoveProgress(id int) {
+	if ep.progress != nil {
+		ep.progress.RemoveProgress(id)
+	}
+}
+
+func (ep *eventsProgress) Quit() {
+	if ep.progress != nil {
+		ep.progress.Quit()
+	}
+}
+
+func (ep *eventsProgress) IncGeneralProgressTotalBy(n int64) {
+	if ep.progress != nil {
+		ep.progress.IncGeneralProgressTotalBy(n)
+	}
+}
+
+func (ep *eventsProgress) SetHeadlineMsg(msg string) {
+	if ep.progress != nil {
+		ep.progress.SetHeadlineMsg(msg)
+	}
+}
+
+func (ep *eventsProgress) ClearHeadlineMsg() {
+	if ep.progress != nil {
+		ep.progress.ClearHeadlineMsg()
+	}
+}
+
+func (ep *eventsProgress) InitProgressReaders() {
+	if ep.progress != nil {
+		ep.progress.InitProgressReaders()
+	}
+}
+
+// A progress indicator which displays nothing.
+type nopProgress struct {
+	id int
+}
+
+func (np *nopProgress) ActionWithProgress(reader io.Reader) io.Reader {
+	return reader
+}
+
+func (np *nopProgress) Abort() {}
+
+func (np *nopProgress) GetId() int {
+	return np.id
+}
@@ -0,0 +1,160 @@
+package transfer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+)
+
+type Status string
+
+const (
+	// The transfer hasn't started yet, or it failed and should be retried.
+	Pending Status = "pending"
+	// The transfer was in progress when the journal was last saved.
+	Partial Status = "partial"
+	// The transfer completed successfully.
+	Done Status = "done"
+
+	journalSuffix   = ".journal"
+	journalsDirName = "journals"
+)
+
+// A single file transfer recorded in the journal.
+type Entry struct {
+	Source    string `json:"source"`
+	Target    string `json:"target"`
+	Size      int64  `json:"size,omitempty"`
+	Sha256    string `json:"sha256,omitempty"`
+	Props     string `json:"props,omitempty"`
+	SpecIndex int    `json:"specIndex"`
+	Status    Status `json:"status"`
+	// The state of the multipart upload of a large file, if it's uploaded in parts.
+	Multipart *MultipartState `json:"multipart,omitempty"`
+}
+
+// The journal keeps track of the files transferred by a single command, so that an interrupted transfer can be resumed.
+type Journal struct {
+	Command     string   `json:"command"`
+	Fingerprint string   `json:"fingerprint"`
+	Entries     []*Entry `json:"entries"`
+	path        string
+}
+
+func NewJournal(path, command, fingerprint string) *Journal {
+	return &Journal{Command: command, Fingerprint: fingerprint, path: path}
+}
+
+// Loads the journal from the provided path.
+// Returns nil if the journal doesn't exist.
+func LoadJournal(path string) (*Journal, error) {
+	exists, err := fileutils.IsFileExists(path, false)
+	if err != nil || !exists {
+		return nil, err
+	}
+	content, err := ioutil.ReadFile(path)
+	if errorutils.CheckError(err) != nil {
+		return nil, err
+	}
+	journal := &Journal{path: path}
+	err = errorutils.CheckError(json.Unmarshal(content, journal))
+	if err != nil {
+		return nil, err
+	}
+	return journal, nil
+}
+
+func (j *Journal) Path() string {
+	return j.path
+}
+
+// Writes the journal to a temp file first and then renames it, so that an interruption never leaves a corrupted journal behind.
+// A journal without a path is kept in memory only.
+func (j *Journal) Save() error {
+	if j.path == "" {
+		return nil
+	}
+	content, err := json.Marshal(j)
+	if errorutils.CheckError(err) != nil {
+		return err
+	}
+	err = os.MkdirAll(filepath.Dir(j.path), 0777)
+	if errorutils.CheckError(err) != nil {
+		return err
+	}
+	tempPath := j.path + ".tmp"
+	err = ioutil.WriteFile(tempPath, content, 0600)
+	if errorutils.CheckError(err) != nil {
+		return err
+	}
+	return errorutils.CheckError(os.Rename(tempPath, j.path))
+}
+
+func (j *Journal) Remove() error {
+	if j.path == "" {
+		return nil
+	}
+	return errorutils.CheckError(os.Remove(j.path))
+}
+
+// Returns the entries which weren't transferred yet.
+// Partial entries are returned as well, since their transfer was interrupted and should start over.
+func (j *Journal) Remaining() (remaining []*Entry) {
+	for _, entry := range j.Entries {
+		if entry.Status != Done {
+			remaining = append(remaining, entry)
+		}
+	}
+	return
+}
+
+// Returns the number of entries in each status.
+func (j *Journal) Counts() (done, partial, pending int) {
+	for _, entry := range j.Entries {
+		switch entry.Status {
+		case Done:
+			done++
+		case Partial:
+			partial++
+		default:
+			pending++
+		}
+	}
+	return
+}
+
+// Creates a fingerprint which identifies the command the journal was created for.
+// A journal is only resumed by a command with the same fingerprint.
+func CreateFingerprint(command, serverUrl string, specFiles *spec.SpecFiles) (string, error) {
+	content, err := json.Marshal(struct {
+		Command   string
+		ServerUrl string
+		Files     []spec.File
+	}{command, serverUrl, specFiles.Files})
+	if errorutils.CheckError(err) != nil {
+		return "", err
+	}
+	checksum := sha256.Sum256(content)
+	return hex.EncodeToString(checksum[:]), nil
+}
+
+// Returns the path of the journal.
+// When the command uses a file spec, the journal is stored next to it. Otherwise, it is stored under the JFrog home directory.
+func GetJournalPath(specPath, fingerprint string) (string, error) {
+	if specPath != "" {
+		return specPath + journalSuffix, nil
+	}
+	homeDir, err := coreutils.GetJfrogHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, journalsDirName, fingerprint+journalSuffix), nil
+}
@@ -0,0 +1,66 @@
+package transfer
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	coretests "github.com/jfrog/jfrog-cli-core/v2/utils/tests"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJournalSaveAndLoad(t *testing.T) {
+	tempDirPath, createTempDirCallback := coretests.CreateTempDirWithCallbackAndAssert(t)
+	defer createTempDirCallback()
+	journalPath := filepath.Join(tempDirPath, "upload.json"+journalSuffix)
+	journal := NewJournal(journalPath, "rt_upload", "fingerprint")
+	journal.Entries = []*Entry{
+		{Source: "a.bin", Target: "repo/a.bin", Size: 1, Status: Done},
+		{Source: "b.bin", Target: "repo/b.bin", Size: 2, Status: Partial},
+		{Source: "c.bin", Target: "repo/c.bin", Size: 3, Status: Pending},
+	}
+	assert.NoError(t, journal.Save())
+
+	loaded, err := LoadJournal(journalPath)
+	assert.NoError(t, err)
+	assert.Equal(t, journal, loaded)
+	done, partial, pending := loaded.Counts()
+	assert.Equal(t, []int{1, 1, 1}, []int{done, partial, pending})
+	remaining := loaded.Remaining()
+	assert.Len(t, remaining, 2)
+	assert.Equal(t, "b.bin", remaining[0].Source)
+	assert.Equal(t, "c.bin", remaining[1].Source)
+
+	assert.NoError(t, loaded.Remove())
+	loaded, err = LoadJournal(journalPath)
+	assert.NoError(t, err)
+	assert.Nil(t, loaded)
+}
+
+func TestCreateFingerprint(t *testing.T) {
+	specFiles := &spec.SpecFiles{Files: []spec.File{{Pattern: "a/*", Target: "repo/"}}}
+	fingerprint, err := CreateFingerprint("rt_upload", "http://localhost:8081/artifactory/", specFiles)
+	assert.NoError(t, err)
+	same, err := CreateFingerprint("rt_upload", "http://localhost:8081/artifactory/", specFiles)
+	assert.NoError(t, err)
+	assert.Equal(t, fingerprint, same)
+
+	otherCommand, err := CreateFingerprint("rt_download", "http://localhost:8081/artifactory/", specFiles)
+	assert.NoError(t, err)
+	assert.NotEqual(t, fingerprint, otherCommand)
+
+	otherSpec, err := CreateFingerprint("rt_upload", "http://localhost:8081/artifactory/", &spec.SpecFiles{Files: []spec.File{{Pattern: "b/*", Target: "repo/"}}})
+	assert.NoError(t, err)
+	assert.NotEqual(t, fingerprint, otherSpec)
+}
+
+func TestGetJournalPath(t *testing.T) {
+	journalPath, err := GetJournalPath(filepath.Join("specs", "upload.json"), "fingerprint")
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join("specs", "upload.json"+journalSuffix), journalPath)
+
+	journalPath, err = GetJournalPath("", "fingerprint")
+	assert.NoError(t, err)
+	assert.Equal(t, journalsDirName, filepath.Base(filepath.Dir(journalPath)))
+	assert.Equal(t, "fingerprint"+journalSuffix, filepath.Base(journalPath))
+}
@@ -0,0 +1,389 @@
+package transfer
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"sort"
+	"sync"
+	"time"
+
+	rtutils "github.com/jfrog/jfrog-cli/artifactory/utils"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/auth"
+	"github.com/jfrog/jfrog-client-go/http/jfroghttpclient"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	ioUtils "github.com/jfrog/jfrog-client-go/utils/io"
+	"github.com/jfrog/jfrog-client-go/utils/io/httputils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	uploadsApi        = "api/v1/uploads/"
+	uploadTokenHeader = "X-JFrog-Upload-Token"
+	// The interval between two checks of the status of a multipart upload, while Artifactory merges its parts.
+	multipartStatusInterval = 5 * time.Second
+	// The maximum time to wait for Artifactory to merge the parts of a file.
+	multipartMergeTimeout = time.Hour
+
+	SizeMiB = 1024 * 1024
+)
+
+// The statuses of a multipart upload, after all of its parts were uploaded.
+const (
+	multipartProcessing = "PROCESSING"
+	multipartFinished   = "FINISHED"
+	multipartAborted    = "ABORTED"
+)
+
+// The settings of the multipart upload of large files.
+// They complement the upload configuration of jfrog-cli-core, which doesn't include them.
+type MultipartConfiguration struct {
+	// Files of at least this size in bytes are uploaded in parts. A negative value disables the multipart upload.
+	MinSplitSize int64
+	// The number of parts of a file which are uploaded concurrently.
+	SplitCount int
+	// The size in bytes of each part. Artifactory accepts whole megabytes only.
+	ChunkSize int64
+}
+
+// The state of the multipart upload of a journal entry.
+// It's saved in the journal after each part is uploaded, so that a resumed upload continues from the missing parts.
+type MultipartState struct {
+	Token         string `json:"token"`
+	PartSize      int64  `json:"partSize"`
+	UploadedParts []int  `json:"uploadedParts,omitempty"`
+}
+
+// Returns the numbers of the parts of a file which weren't uploaded yet. Part numbers start from 1.
+func (ms *MultipartState) missingParts(fileSize int64) []int {
+	uploaded := make(map[int]bool)
+	for _, part := range ms.UploadedParts {
+		uploaded[part] = true
+	}
+	var missing []int
+	for part := 1; part <= partsCount(fileSize, ms.PartSize); part++ {
+		if !uploaded[part] {
+			missing = append(missing, part)
+		}
+	}
+	return missing
+}
+
+func partsCount(fileSize, partSize int64) int {
+	return int((fileSize + partSize - 1) / partSize)
+}
+
+// Uploads files in parts using the multipart upload API of Artifactory.
+// Artifactory returns a pre-signed URL of its storage for each part, and merges the parts once all of them are uploaded.
+type multipartUploader struct {
+	client             *jfroghttpclient.JfrogHttpClient
+	serviceDetails     auth.ServiceDetails
+	configuration      *MultipartConfiguration
+	retries            int
+	retryWaitMilliSecs int
+	progress           ioUtils.ProgressMgr
+	// Called after each uploaded part, while holding the lock of the state.
+	saveState func() error
+	// Protects the states of the uploads.
+	mutex *sync.Mutex
+}
+
+// Returns true if the multipart upload is enabled in Artifactory.
+// It's not available in older versions of Artifactory, and requires its storage to be a cloud storage.
+func (mu *multipartUploader) isSupported() bool {
+	httpClientDetails := mu.serviceDetails.CreateHttpClientDetails()
+	resp, body, _, err := mu.client.SendGet(mu.serviceDetails.GetUrl()+uploadsApi+"config", true, &httpClientDetails)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		return false
+	}
+	config := struct {
+		Supported bool `json:"supported"`
+	}{}
+	return json.Unmarshal(body, &config) == nil && config.Supported
+}
+
+// Uploads a file to its target in Artifactory. The state of the upload is kept in the entry.
+// A resumed upload whose token was rejected by Artifactory, for example because it expired, starts over.
+func (mu *multipartUploader) upload(entry *Entry) error {
+	if entry.Multipart != nil {
+		log.Info(fmt.Sprintf("Resuming the multipart upload of %s. %d parts were already uploaded.", entry.Source, len(entry.Multipart.UploadedParts)))
+		err := mu.uploadParts(entry)
+		if _, rejected := err.(*tokenRejectedError); !rejected {
+			return err
+		}
+		log.Warn("The multipart upload of " + entry.Source + " can't be resumed: " + err.Error() + ". Starting it over.")
+		mu.setState(entry, nil)
+	}
+	token, err := mu.create(entry.Target)
+	if err != nil {
+		return err
+	}
+	mu.setState(entry, &MultipartState{Token: token, PartSize: mu.configuration.ChunkSize})
+	return mu.uploadParts(entry)
+}
+
+func (mu *multipartUploader) setState(entry *Entry, state *MultipartState) {
+	mu.mutex.Lock()
+	defer mu.mutex.Unlock()
+	entry.Multipart = state
+	if err := mu.saveState(); err != nil {
+		log.Warn("Failed saving the state of the multipart upload of " + entry.Source + ": " + err.Error())
+	}
+}
+
+// Uploads the missing parts of the file, and completes the upload once all the parts are uploaded.
+func (mu *multipartUploader) uploadParts(entry *Entry) error {
+	missing := entry.Multipart.missingParts(entry.Size)
+	if mu.progress != nil {
+		mu.progress.IncGeneralProgressTotalBy(int64(len(missing)))
+	}
+	parts := make(chan int, len(missing))
+	for _, part := range missing {
+		parts <- part
+	}
+	close(parts)
+	var wg sync.WaitGroup
+	var errMutex sync.Mutex
+	var firstErr error
+	for i := 0; i < mu.configuration.SplitCount && i < len(missing); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for part := range parts {
+				errMutex.Lock()
+				failed := firstErr != nil
+				errMutex.Unlock()
+				if failed {
+					return
+				}
+				if err := mu.uploadPartWithRetries(entry, part); err != nil {
+					errMutex.Lock()
+					if firstErr == nil {
+						firstErr = err
+					}
+					errMutex.Unlock()
+					return
+				}
+				mu.mutex.Lock()
+				entry.Multipart.UploadedParts = append(entry.Multipart.UploadedParts, part)
+				sort.Ints(entry.Multipart.UploadedParts)
+				err := mu.saveState()
+				mu.mutex.Unlock()
+				if err != nil {
+					log.Warn("Failed saving the state of the multipart upload of " + entry.Source + ": " + err.Error())
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return firstErr
+	}
+	checksum, err := calcSha1(entry.Source)
+	if err != nil {
+		return err
+	}
+	if err = mu.complete(entry.Multipart.Token, checksum); err != nil {
+		return err
+	}
+	return mu.waitForCompletion(entry)
+}
+
+// Uploads a single part, retrying on failure. A new URL is requested for every attempt, since the URLs expire.
+func (mu *multipartUploader) uploadPartWithRetries(entry *Entry, part int) (err error) {
+	for attempt := 0; attempt <= mu.retries; attempt++ {
+		if attempt > 0 {
+			log.Warn(fmt.Sprintf("Failed uploading part %d of %s: %s. Retrying...", part, entry.Source, err.Error()))
+			time.Sleep(time.Duration(mu.retryWaitMilliSecs) * time.Millisecond)
+		}
+		var partUrl string
+		if partUrl, err = mu.getPartUrl(entry.Multipart.Token, part); err != nil {
+			if _, rejected := err.(*tokenRejectedError); rejected {
+				return
+			}
+			continue
+		}
+		if err = mu.uploadPart(entry, part, partUrl); err == nil {
+			return
+		}
+	}
+	return
+}
+
+func (mu *multipartUploader) uploadPart(entry *Entry, part int, partUrl string) error {
+	file, err := os.Open(entry.Source)
+	if errorutils.CheckError(err) != nil {
+		return err
+	}
+	defer file.Close()
+	offset := int64(part-1) * entry.Multipart.PartSize
+	size := entry.Multipart.PartSize
+	if offset+size > entry.Size {
+		size = entry.Size - offset
+	}
+	var reader io.Reader = io.NewSectionReader(file, offset, size)
+	if mu.progress != nil {
+		progress := mu.progress.NewProgressReader(size, "Uploading", fmt.Sprintf("%s (part %d/%d)", entry.Target, part, partsCount(entry.Size, entry.Multipart.PartSize)))
+		reader = progress.ActionWithProgress(reader)
+		defer mu.progress.RemoveProgress(progress.GetId())
+	}
+	// The URL is pre-signed by Artifactory, so the credentials of Artifactory aren't sent with the part.
+	resp, body, err := mu.client.GetHttpClient().UploadFileFromReader(reader, partUrl, httputils.HttpClientDetails{}, size)
+	if err != nil {
+		return err
+	}
+	if err = errorutils.CheckResponseStatus(resp, http.StatusOK, http.StatusCreated); err != nil {
+		return errorutils.CheckError(errorutils.GenerateResponseError(resp.Status, string(body)))
+	}
+	return nil
+}
+
+// Creates a multipart upload of a file to a path in Artifactory, and returns the token which identifies the upload.
+func (mu *multipartUploader) create(target string) (string, error) {
+	repoKey, repoPath := rtutils.SplitRepoPath(target)
+	query := fmt.Sprintf("create?repoKey=%s&repoPath=%s&partSizeMB=%d", url.QueryEscape(repoKey), url.QueryEscape(repoPath), mu.configuration.ChunkSize/SizeMiB)
+	body, err := mu.send(query, "", http.StatusOK)
+	if err != nil {
+		return "", err
+	}
+	response := struct {
+		Token string `json:"token"`
+	}{}
+	if err = errorutils.CheckError(json.Unmarshal(body, &response)); err != nil {
+		return "", err
+	}
+	return response.Token, nil
+}
+
+func (mu *multipartUploader) getPartUrl(token string, part int) (string, error) {
+	body, err := mu.send(fmt.Sprintf("urlPart?partNumber=%d", part), token, http.StatusOK)
+	if err != nil {
+		return "", err
+	}
+	response := struct {
+		Url string `json:"url"`
+	}{}
+	if err = errorutils.CheckError(json.Unmarshal(body, &response)); err != nil {
+		return "", err
+	}
+	return response.Url, nil
+}
+
+// Asks Artifactory to merge the uploaded parts. The checksum of the entire file is verified once they are merged.
+func (mu *multipartUploader) complete(token, sha1 string) error {
+	_, err := mu.send("complete?sha1="+sha1, token, http.StatusAccepted)
+	return err
+}
+
+// Waits for Artifactory to merge the parts of the file.
+func (mu *multipartUploader) waitForCompletion(entry *Entry) error {
+	log.Info("Waiting for Artifactory to merge the parts of " + entry.Target + "...")
+	start := time.Now()
+	for {
+		body, err := mu.send("status", entry.Multipart.Token, http.StatusOK)
+		if err != nil {
+			return err
+		}
+		status := struct {
+			Status string `json:"status"`
+			Error  string `json:"error"`
+		}{}
+		if err = errorutils.CheckError(json.Unmarshal(body, &status)); err != nil {
+			return err
+		}
+		switch {
+		case status.Status == multipartFinished:
+			return nil
+		case status.Status == multipartAborted:
+			return errorutils.CheckErrorf("the multipart upload of %s was aborted by Artifactory: %s", entry.Source, status.Error)
+		case status.Status != multipartProcessing && status.Error != "":
+			return errorutils.CheckErrorf("the multipart upload of %s failed: %s", entry.Source, status.Error)
+		}
+		if time.Since(start) > multipartMergeTimeout {
+			return errorutils.CheckErrorf("the parts of %s weren't merged by Artifactory within %s. The status of the upload is: %s", entry.Source, multipartMergeTimeout, status.Status)
+		}
+		time.Sleep(multipartStatusInterval)
+	}
+}
+
+// Aborts a multipart upload, so that Artifactory removes its uploaded parts.
+func (mu *multipartUploader) abort(entry *Entry) {
+	if entry.Multipart == nil {
+		return
+	}
+	if _, err := mu.send("abort", entry.Multipart.Token, http.StatusNoContent); err != nil {
+		log.Warn("Failed aborting the multipart upload of " + entry.Source + ": " + err.Error())
+	}
+	mu.setState(entry, nil)
+}
+
+// Sets the properties of a file uploaded in parts. They're set once the file is uploaded, in the same way as by the set-props command.
+func (mu *multipartUploader) setProps(target, props string) error {
+	properties, err := servicesutils.ParseProperties(props)
+	if err != nil {
+		return err
+	}
+	propsUrl, err := servicesutils.BuildArtifactoryUrl(mu.serviceDetails.GetUrl(), path.Join("api", "storage", target), make(map[string]string))
+	if err != nil {
+		return err
+	}
+	propsUrl += "?properties=" + properties.ToEncodedString(true) + "&recursive=0"
+	httpClientDetails := mu.serviceDetails.CreateHttpClientDetails()
+	resp, body, err := mu.client.SendPut(propsUrl, nil, &httpClientDetails)
+	if err != nil {
+		return err
+	}
+	if err = errorutils.CheckResponseStatus(resp, http.StatusNoContent); err != nil {
+		return errorutils.CheckError(errorutils.GenerateResponseError(resp.Status, clientutils.IndentJson(body)))
+	}
+	return nil
+}
+
+// Sends a request to the multipart upload API and returns the body of the response.
+func (mu *multipartUploader) send(query, token string, expectedStatus int) ([]byte, error) {
+	httpClientDetails := mu.serviceDetails.CreateHttpClientDetails()
+	if token != "" {
+		servicesutils.AddHeader(uploadTokenHeader, token, &httpClientDetails.Headers)
+	}
+	resp, body, err := mu.client.SendPost(mu.serviceDetails.GetUrl()+uploadsApi+query, nil, &httpClientDetails)
+	if err != nil {
+		return nil, err
+	}
+	if token != "" && (resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden) {
+		return nil, &tokenRejectedError{status: resp.Status}
+	}
+	if err = errorutils.CheckResponseStatus(resp, expectedStatus); err != nil {
+		return nil, errorutils.CheckError(errorutils.GenerateResponseError(resp.Status, clientutils.IndentJson(body)))
+	}
+	return body, nil
+}
+
+// Returned when Artifactory doesn't recognize the token of a multipart upload.
+type tokenRejectedError struct {
+	status string
+}
+
+func (e *tokenRejectedError) Error() string {
+	return "the upload token was rejected by Artifactory (" + e.status + ")"
+}
+
+func calcSha1(path string) (string, error) {
+	file, err := os.Open(path)
+	if errorutils.CheckError(err) != nil {
+		return "", err
+	}
+	defer file.Close()
+	hash := sha1.New()
+	if _, err = io.Copy(hash, file); errorutils.CheckError(err) != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
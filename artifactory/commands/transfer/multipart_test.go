@@ -0,0 +1,58 @@
+package transfer
+
+import (
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMissingParts(t *testing.T) {
+	state := &MultipartState{PartSize: 10}
+	assert.Equal(t, []int{1, 2, 3}, state.missingParts(25))
+	assert.Equal(t, []int{1, 2}, state.missingParts(20))
+
+	state.UploadedParts = []int{1, 3}
+	assert.Equal(t, []int{2}, state.missingParts(25))
+	state.UploadedParts = []int{1, 2, 3}
+	assert.Empty(t, state.missingParts(25))
+}
+
+func TestIsMultipart(t *testing.T) {
+	uploadCommand := NewUploadCommand().SetUploadConfiguration(&utils.UploadConfiguration{}).
+		SetMultipartConfiguration(&MultipartConfiguration{MinSplitSize: 100, SplitCount: 2, ChunkSize: 10})
+	uploadCommand.SetSpec(&spec.SpecFiles{Files: []spec.File{{Pattern: "a/*"}, {Pattern: "b/*", Explode: "true"}}})
+
+	assert.True(t, uploadCommand.isMultipart(&Entry{Size: 100}))
+	assert.False(t, uploadCommand.isMultipart(&Entry{Size: 99}))
+	// Archives which are extracted by Artifactory are uploaded by the client.
+	assert.False(t, uploadCommand.isMultipart(&Entry{Size: 100, SpecIndex: 1}))
+	// Files attached to a build are uploaded by the client, which adds them to the build info.
+	uploadCommand.SetBuildConfiguration(utils.NewBuildConfiguration("build", "1", "", ""))
+	assert.False(t, uploadCommand.isMultipart(&Entry{Size: 100}))
+
+	uploadCommand.SetBuildConfiguration(nil).SetMultipartConfiguration(&MultipartConfiguration{MinSplitSize: -1})
+	assert.False(t, uploadCommand.isMultipart(&Entry{Size: 100}))
+}
+
+func TestAppendToResult(t *testing.T) {
+	transferred := []clientutils.FileTransferDetails{{SourcePath: "a.bin", TargetPath: "http://localhost:8081/artifactory/repo/a.bin"}}
+	result, err := appendToResult(nil, transferred, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.SuccessCount())
+	assert.Equal(t, 1, result.FailCount())
+
+	result, err = appendToResult(result, []clientutils.FileTransferDetails{{SourcePath: "b.bin"}}, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.SuccessCount())
+	assert.Equal(t, 1, result.FailCount())
+	reader := result.Reader()
+	defer reader.Close()
+	var sources []string
+	for transferDetails := new(clientutils.FileTransferDetails); reader.NextRecord(transferDetails) == nil; transferDetails = new(clientutils.FileTransferDetails) {
+		sources = append(sources, transferDetails.SourcePath)
+	}
+	assert.ElementsMatch(t, []string{"a.bin", "b.bin"}, sources)
+}
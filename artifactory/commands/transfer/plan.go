@@ -0,0 +1,347 @@
+package transfer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	commandsutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli/utils/cache"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// A plan lists every file a command handles. It is created by a dry run, so that it can be reviewed, and applied later as is.
+type Plan struct {
+	Command   string          `json:"command"`
+	ServerUrl string          `json:"serverUrl"`
+	Created   string          `json:"created"`
+	Spec      *spec.SpecFiles `json:"spec"`
+	Items     []*PlanItem     `json:"items"`
+}
+
+// A single file of the plan. The target is empty for deletions.
+type PlanItem struct {
+	Source    string `json:"source"`
+	Target    string `json:"target,omitempty"`
+	Size      int64  `json:"size,omitempty"`
+	Sha256    string `json:"sha256,omitempty"`
+	SpecIndex int    `json:"specIndex"`
+}
+
+// Implemented by the commands of this package which can create a plan.
+type PlanCreator interface {
+	CreatePlan() (*Plan, error)
+}
+
+func newPlan(command string, serverDetails *config.ServerDetails, specFiles *spec.SpecFiles) *Plan {
+	return &Plan{Command: command, ServerUrl: serverDetails.ArtifactoryUrl, Created: time.Now().Format(time.RFC3339), Spec: specFiles}
+}
+
+func LoadPlan(path string) (*Plan, error) {
+	content, err := ioutil.ReadFile(path)
+	if errorutils.CheckError(err) != nil {
+		return nil, err
+	}
+	plan := new(Plan)
+	if err = errorutils.CheckError(json.Unmarshal(content, plan)); err != nil {
+		return nil, err
+	}
+	if plan.Spec == nil || plan.Spec.Files == nil {
+		return nil, errorutils.CheckErrorf("the plan at %s doesn't include the spec it was created with", path)
+	}
+	// The files were already found when the plan was created, so the AQL of the spec isn't needed.
+	// It is cleared, since the AQL of a spec isn't unmarshalled the same way it is marshalled.
+	for i := range plan.Spec.Files {
+		plan.Spec.Files[i].Aql = servicesutils.Aql{}
+	}
+	for _, item := range plan.Items {
+		if item.SpecIndex < 0 || item.SpecIndex >= len(plan.Spec.Files) {
+			return nil, errorutils.CheckErrorf("the plan at %s includes an item of a missing spec group: %s", path, item.Source)
+		}
+	}
+	return plan, nil
+}
+
+// The plan is indented, since it is meant to be reviewed.
+func (p *Plan) Save(path string) error {
+	content, err := json.MarshalIndent(p, "", "  ")
+	if errorutils.CheckError(err) != nil {
+		return err
+	}
+	return errorutils.CheckError(ioutil.WriteFile(path, content, 0644))
+}
+
+func (ruc *UploadCommand) CreatePlan() (*Plan, error) {
+	entries, err := ruc.createEntries()
+	if err != nil {
+		return nil, err
+	}
+	plan := newPlan(ruc.CommandName(), ruc.serverDetails, ruc.spec)
+	for _, entry := range entries {
+		// The checksums calculated by the dry run don't include sha256.
+		if entry.Sha256 == "" {
+			if entry.Sha256, err = cache.CalcSha256(entry.Source); err != nil {
+				return nil, err
+			}
+		}
+		plan.Items = append(plan.Items, newPlanItem(entry))
+	}
+	return plan, nil
+}
+
+func (rdc *DownloadCommand) CreatePlan() (*Plan, error) {
+	entries, err := rdc.createEntries()
+	if err != nil {
+		return nil, err
+	}
+	plan := newPlan(rdc.CommandName(), rdc.serverDetails, rdc.spec)
+	for _, entry := range entries {
+		plan.Items = append(plan.Items, newPlanItem(entry))
+	}
+	return plan, nil
+}
+
+func (cmc *CopyMoveCommand) CreatePlan() (*Plan, error) {
+	plan := newPlan(cmc.CommandName(), cmc.serverDetails, cmc.spec)
+	err := cmc.collectMoveCopyItems(func(item servicesutils.ResultItem, target string) {
+		plan.Items = append(plan.Items, &PlanItem{Source: item.GetItemRelativePath(), Target: target, Size: item.Size, Sha256: item.Sha256})
+	})
+	return plan, err
+}
+
+// The paths to delete are folded into folders, so the files of each folder are listed, and the plan includes each file with its checksum.
+// This way, files which are added to the folders after the plan is reviewed aren't deleted when it's applied.
+func (dc *DeleteCommand) CreatePlan() (*Plan, error) {
+	reader, err := dc.GetPathsToDelete()
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	servicesManager, err := dc.createOperationsServiceManager()
+	if err != nil {
+		return nil, err
+	}
+	plan := newPlan(dc.CommandName(), dc.serverDetails, dc.spec)
+	for item := new(servicesutils.ResultItem); reader.NextRecord(item) == nil; item = new(servicesutils.ResultItem) {
+		if item.Type != "folder" {
+			plan.Items = append(plan.Items, &PlanItem{Source: item.GetItemRelativePath(), Size: item.Size, Sha256: item.Sha256})
+			continue
+		}
+		files, err := listFolderFiles(servicesManager, item)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			plan.Items = append(plan.Items, &PlanItem{Source: file.GetItemRelativePath(), Size: file.Size, Sha256: file.Sha256})
+		}
+	}
+	return plan, reader.GetError()
+}
+
+func newPlanItem(entry *Entry) *PlanItem {
+	return &PlanItem{Source: entry.Source, Target: entry.Target, Size: entry.Size, Sha256: entry.Sha256, SpecIndex: entry.SpecIndex}
+}
+
+// Applies a plan created by a dry run. Only the files listed in the plan are handled, using the same commands which created the plan.
+type ApplyPlanCommand struct {
+	plan                   *Plan
+	serverDetails          *config.ServerDetails
+	uploadConfiguration    *utils.UploadConfiguration
+	downloadConfiguration  *utils.DownloadConfiguration
+	threads                int
+	retries                int
+	retryWaitTimeMilliSecs int
+	quiet                  bool
+	result                 *commandsutils.Result
+}
+
+func NewApplyPlanCommand() *ApplyPlanCommand {
+	return &ApplyPlanCommand{result: new(commandsutils.Result)}
+}
+
+func (apc *ApplyPlanCommand) SetPlan(plan *Plan) *ApplyPlanCommand {
+	apc.plan = plan
+	return apc
+}
+
+func (apc *ApplyPlanCommand) SetServerDetails(serverDetails *config.ServerDetails) *ApplyPlanCommand {
+	apc.serverDetails = serverDetails
+	return apc
+}
+
+func (apc *ApplyPlanCommand) SetUploadConfiguration(uploadConfiguration *utils.UploadConfiguration) *ApplyPlanCommand {
+	apc.uploadConfiguration = uploadConfiguration
+	return apc
+}
+
+func (apc *ApplyPlanCommand) SetDownloadConfiguration(downloadConfiguration *utils.DownloadConfiguration) *ApplyPlanCommand {
+	apc.downloadConfiguration = downloadConfiguration
+	return apc
+}
+
+func (apc *ApplyPlanCommand) SetThreads(threads int) *ApplyPlanCommand {
+	apc.threads = threads
+	return apc
+}
+
+func (apc *ApplyPlanCommand) SetRetries(retries int) *ApplyPlanCommand {
+	apc.retries = retries
+	return apc
+}
+
+func (apc *ApplyPlanCommand) SetRetryWaitMilliSecs(retryWaitMilliSecs int) *ApplyPlanCommand {
+	apc.retryWaitTimeMilliSecs = retryWaitMilliSecs
+	return apc
+}
+
+func (apc *ApplyPlanCommand) SetQuiet(quiet bool) *ApplyPlanCommand {
+	apc.quiet = quiet
+	return apc
+}
+
+func (apc *ApplyPlanCommand) ServerDetails() (*config.ServerDetails, error) {
+	return apc.serverDetails, nil
+}
+
+func (apc *ApplyPlanCommand) Result() *commandsutils.Result {
+	return apc.result
+}
+
+func (apc *ApplyPlanCommand) CommandName() string {
+	return "rt_apply_plan"
+}
+
+func (apc *ApplyPlanCommand) Run() error {
+	if clientutils.AddTrailingSlashIfNeeded(apc.plan.ServerUrl) != clientutils.AddTrailingSlashIfNeeded(apc.serverDetails.ArtifactoryUrl) {
+		return errorutils.CheckErrorf("the plan was created for %s, but the configured Artifactory URL is %s", apc.plan.ServerUrl, apc.serverDetails.ArtifactoryUrl)
+	}
+	log.Info(fmt.Sprintf("Applying a %s plan of %d files, created at %s.", apc.plan.Command, len(apc.plan.Items), apc.plan.Created))
+	switch apc.plan.Command {
+	case "rt_upload":
+		return apc.applyUpload()
+	case "rt_download":
+		return apc.applyDownload()
+	case "rt_copy":
+		return apc.applyMoveCopy(NewCopyCommand())
+	case "rt_move":
+		return apc.applyMoveCopy(NewMoveCommand())
+	case "rt_delete":
+		return apc.applyDelete()
+	default:
+		return errorutils.CheckErrorf("plans of the '%s' command are not supported", apc.plan.Command)
+	}
+}
+
+// Files which changed since the plan was created aren't uploaded, since the plan was reviewed with their previous content.
+func (apc *ApplyPlanCommand) applyUpload() error {
+	uploadCommand := NewUploadCommand()
+	uploadCommand.SetUploadConfiguration(apc.uploadConfiguration).SetSpec(apc.plan.Spec).SetServerDetails(apc.serverDetails).
+		SetRetries(apc.retries).SetRetryWaitMilliSecs(apc.retryWaitTimeMilliSecs)
+	var entries []*Entry
+	changed := 0
+	for _, item := range apc.plan.Items {
+		checksum, err := cache.CalcSha256(item.Source)
+		if err != nil || checksum != item.Sha256 {
+			log.Error(fmt.Sprintf("%s changed since the plan was created, and will not be uploaded.", item.Source))
+			changed++
+			continue
+		}
+		entries = append(entries, newPlanEntry(item))
+	}
+	journal := NewJournal("", uploadCommand.CommandName(), "")
+	journal.Entries = entries
+	err := uploadCommand.runJournal(journal, uploadCommand)
+	apc.result = uploadCommand.Result()
+	apc.result.SetFailCount(apc.result.FailCount() + changed)
+	return err
+}
+
+func (apc *ApplyPlanCommand) applyDownload() error {
+	downloadCommand := NewDownloadCommand()
+	downloadCommand.SetConfiguration(apc.downloadConfiguration).SetSpec(apc.plan.Spec).SetServerDetails(apc.serverDetails).
+		SetRetries(apc.retries).SetRetryWaitMilliSecs(apc.retryWaitTimeMilliSecs)
+	journal := NewJournal("", downloadCommand.CommandName(), "")
+	for _, item := range apc.plan.Items {
+		journal.Entries = append(journal.Entries, newPlanEntry(item))
+	}
+	err := downloadCommand.runJournal(journal, downloadCommand)
+	apc.result = downloadCommand.Result()
+	return err
+}
+
+func (apc *ApplyPlanCommand) applyMoveCopy(moveCopyCommand *CopyMoveCommand) error {
+	moveCopyCommand.SetServerDetails(apc.serverDetails).SetThreads(apc.threads).SetRetries(apc.retries).SetRetryWaitMilliSecs(apc.retryWaitTimeMilliSecs)
+	apc.result = moveCopyCommand.Result()
+	servicesManager, err := moveCopyCommand.createOperationsServiceManager()
+	if err != nil {
+		return err
+	}
+	return moveCopyCommand.runOperations(func(addOperation addOperationFunc) error {
+		for _, item := range apc.plan.Items {
+			moveCopyCommand.addMoveCopyOperation(servicesManager, item.Source, item.Target, addOperation)
+		}
+		return nil
+	})
+}
+
+func (apc *ApplyPlanCommand) applyDelete() error {
+	if !apc.quiet && !coreutils.AskYesNo(fmt.Sprintf("The plan deletes %d artifacts. Are you sure you want to continue?", len(apc.plan.Items)), false) {
+		return nil
+	}
+	deleteCommand := NewDeleteCommand()
+	deleteCommand.SetServerDetails(apc.serverDetails).SetThreads(apc.threads).SetRetries(apc.retries).SetRetryWaitMilliSecs(apc.retryWaitTimeMilliSecs)
+	apc.result = deleteCommand.Result()
+	servicesManager, err := deleteCommand.createOperationsServiceManager()
+	if err != nil {
+		return err
+	}
+	return deleteCommand.runOperations(func(addOperation addOperationFunc) error {
+		for _, item := range apc.plan.Items {
+			itemPath, sha256 := item.Source, item.Sha256
+			addOperation(itemPath, "", func(logMsgPrefix string) (bool, error) {
+				if err := checkArtifactUnchanged(servicesManager, itemPath, sha256); err != nil {
+					return false, err
+				}
+				log.Info(logMsgPrefix+"Deleting", itemPath)
+				return deleteArtifact(servicesManager, itemPath)
+			})
+		}
+		return nil
+	})
+}
+
+// Artifacts which changed since the plan was created aren't deleted, since the plan was reviewed with their previous content.
+// An item without a checksum, such as a folder of a plan created by a previous version, isn't deleted either.
+func checkArtifactUnchanged(servicesManager artifactory.ArtifactoryServicesManager, itemPath, sha256 string) error {
+	if sha256 == "" {
+		return errorutils.CheckErrorf("the plan doesn't include the checksum of %s, so it will not be deleted", itemPath)
+	}
+	stream, err := servicesManager.Aql(fmt.Sprintf(`items.find(%s).include("sha256")`, createAqlItemQuery(itemPath)))
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+	var result struct {
+		Results []servicesutils.ResultItem `json:"results"`
+	}
+	if err = errorutils.CheckError(json.NewDecoder(stream).Decode(&result)); err != nil {
+		return err
+	}
+	if len(result.Results) == 0 || result.Results[0].Sha256 != sha256 {
+		return errorutils.CheckErrorf("%s changed since the plan was created, and will not be deleted", itemPath)
+	}
+	return nil
+}
+
+func newPlanEntry(item *PlanItem) *Entry {
+	return &Entry{Source: item.Source, Target: item.Target, Size: item.Size, Sha256: item.Sha256, SpecIndex: item.SpecIndex, Status: Pending}
+}
@@ -0,0 +1,64 @@
+package transfer
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	coretests "github.com/jfrog/jfrog-cli-core/v2/utils/tests"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlanSaveAndLoad(t *testing.T) {
+	tempDirPath, createTempDirCallback := coretests.CreateTempDirWithCallbackAndAssert(t)
+	defer createTempDirCallback()
+	planPath := filepath.Join(tempDirPath, "plan.json")
+	plan := newPlan("rt_delete", &config.ServerDetails{ArtifactoryUrl: "http://localhost:8081/artifactory/"}, &spec.SpecFiles{Files: []spec.File{{Pattern: "repo/*.zip"}}})
+	plan.Items = []*PlanItem{{Source: "repo/a.zip", Size: 1, Sha256: "abc"}}
+	assert.NoError(t, plan.Save(planPath))
+
+	loaded, err := LoadPlan(planPath)
+	assert.NoError(t, err)
+	assert.Equal(t, plan, loaded)
+
+	assert.NoError(t, ioutil.WriteFile(planPath, []byte(`{"command":"rt_delete","items":[{"source":"repo/a.zip"}]}`), 0644))
+	_, err = LoadPlan(planPath)
+	assert.EqualError(t, err, "the plan at "+planPath+" doesn't include the spec it was created with")
+
+	assert.NoError(t, ioutil.WriteFile(planPath, []byte(`{"command":"rt_delete","spec":{"files":[]},"items":[{"source":"repo/a.zip"}]}`), 0644))
+	_, err = LoadPlan(planPath)
+	assert.EqualError(t, err, "the plan at "+planPath+" includes an item of a missing spec group: repo/a.zip")
+}
+
+func TestApplyPlan(t *testing.T) {
+	tempDirPath, createTempDirCallback := coretests.CreateTempDirWithCallbackAndAssert(t)
+	defer createTempDirCallback()
+	localPath := filepath.Join(tempDirPath, "a.txt")
+	assert.NoError(t, ioutil.WriteFile(localPath, []byte("content"), 0644))
+	serverDetails := &config.ServerDetails{ArtifactoryUrl: "http://localhost:8081/artifactory/"}
+	plan := newPlan("rt_upload", serverDetails, &spec.SpecFiles{Files: []spec.File{{Pattern: localPath, Target: "repo/"}}})
+	plan.Items = []*PlanItem{{Source: localPath, Target: "repo/a.txt", Sha256: "outdated"}}
+
+	// The plan should only be applied to the server it was created for.
+	err := NewApplyPlanCommand().SetPlan(plan).SetServerDetails(&config.ServerDetails{ArtifactoryUrl: "http://other:8081/artifactory/"}).Run()
+	assert.EqualError(t, err, "the plan was created for http://localhost:8081/artifactory/, but the configured Artifactory URL is http://other:8081/artifactory/")
+
+	// Files which changed since the plan was created aren't uploaded.
+	applyPlanCommand := NewApplyPlanCommand().SetPlan(plan).SetServerDetails(serverDetails)
+	assert.NoError(t, applyPlanCommand.Run())
+	assert.Equal(t, 0, applyPlanCommand.Result().SuccessCount())
+	assert.Equal(t, 1, applyPlanCommand.Result().FailCount())
+
+	// Artifacts without a checksum in the plan aren't deleted.
+	plan.Command = "rt_delete"
+	plan.Items = []*PlanItem{{Source: "repo/a.txt"}}
+	applyPlanCommand = NewApplyPlanCommand().SetPlan(plan).SetServerDetails(serverDetails).SetQuiet(true)
+	assert.NoError(t, applyPlanCommand.Run())
+	assert.Equal(t, 0, applyPlanCommand.Result().SuccessCount())
+	assert.Equal(t, 1, applyPlanCommand.Result().FailCount())
+
+	plan.Command = "rt_search"
+	assert.EqualError(t, NewApplyPlanCommand().SetPlan(plan).SetServerDetails(serverDetails).Run(), "plans of the 'rt_search' command are not supported")
+}
@@ -0,0 +1,127 @@
+package transfer
+
+import (
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	commandsutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Sets or deletes the properties of the artifacts matching a file spec, one artifact at a time.
+type PropsCommand struct {
+	remoteCommand
+	props    string
+	isDelete bool
+}
+
+func NewSetPropsCommand() *PropsCommand {
+	return &PropsCommand{remoteCommand: remoteCommand{progressLabel: "Setting properties", result: new(commandsutils.Result)}}
+}
+
+func NewDeletePropsCommand() *PropsCommand {
+	return &PropsCommand{remoteCommand: remoteCommand{progressLabel: "Deleting properties", result: new(commandsutils.Result)}, isDelete: true}
+}
+
+// The properties to set, such as "a=1;b=2", or the comma separated keys of the properties to delete.
+func (pc *PropsCommand) SetProps(props string) *PropsCommand {
+	pc.props = props
+	return pc
+}
+
+func (pc *PropsCommand) CommandName() string {
+	if pc.isDelete {
+		return "rt_delete_properties"
+	}
+	return "rt_set_properties"
+}
+
+func (pc *PropsCommand) Run() error {
+	encodedProps, err := pc.encodeProps()
+	if err != nil {
+		return err
+	}
+	searchServicesManager, err := utils.CreateServiceManager(pc.serverDetails, pc.retries, pc.retryWaitTimeMilliSecs, false)
+	if err != nil {
+		return err
+	}
+	servicesManager, err := pc.createOperationsServiceManager()
+	if err != nil {
+		return err
+	}
+	return pc.runOperations(func(addOperation addOperationFunc) error {
+		// Like the properties commands of jfrog-cli-core, a spec group which fails to be searched is logged, and the other groups are still handled.
+		errorOccurred := false
+		for i := 0; i < len(pc.spec.Files); i++ {
+			searchParams, err := utils.GetSearchParams(pc.spec.Get(i))
+			if err != nil {
+				errorOccurred = true
+				log.Error(err)
+				continue
+			}
+			reader, err := searchServicesManager.SearchFiles(searchParams)
+			if err != nil {
+				errorOccurred = true
+				log.Error(err)
+				continue
+			}
+			for item := new(servicesutils.ResultItem); reader.NextRecord(item) == nil; item = new(servicesutils.ResultItem) {
+				itemPath := item.GetItemRelativePath()
+				addOperation(itemPath, "", func(logMsgPrefix string) (bool, error) {
+					return pc.updateProps(servicesManager, itemPath, encodedProps, logMsgPrefix)
+				})
+			}
+			err = reader.GetError()
+			reader.Close()
+			if err != nil {
+				errorOccurred = true
+				log.Error(err)
+			}
+		}
+		if errorOccurred {
+			return errorutils.CheckErrorf("Operation finished with errors, please review the logs.")
+		}
+		return nil
+	})
+}
+
+// Encodes the properties the same way the props service of the client does.
+func (pc *PropsCommand) encodeProps() (string, error) {
+	if !pc.isDelete {
+		props, err := servicesutils.ParseProperties(pc.props)
+		if err != nil {
+			return "", err
+		}
+		return props.ToEncodedString(true), nil
+	}
+	var keys []string
+	for _, key := range strings.Split(pc.props, ",") {
+		keys = append(keys, url.QueryEscape(key))
+	}
+	return strings.Join(keys, ","), nil
+}
+
+func (pc *PropsCommand) updateProps(servicesManager artifactory.ArtifactoryServicesManager, itemPath, encodedProps, logMsgPrefix string) (bool, error) {
+	serviceDetails := servicesManager.GetConfig().GetServiceDetails()
+	requestFullUrl, err := servicesutils.BuildArtifactoryUrl(serviceDetails.GetUrl(), path.Join("api", "storage", itemPath), map[string]string{})
+	if err != nil {
+		return false, err
+	}
+	// The search results already take the recursive option into account, so the request itself shouldn't be recursive.
+	requestFullUrl += "?properties=" + encodedProps + "&recursive=0"
+	httpClientsDetails := serviceDetails.CreateHttpClientDetails()
+	if pc.isDelete {
+		log.Info(logMsgPrefix+"Deleting properties on:", itemPath)
+		resp, body, err := servicesManager.Client().SendDelete(requestFullUrl, nil, &httpClientsDetails)
+		return checkOperationResponse(resp, body, err, http.StatusNoContent)
+	}
+	log.Info(logMsgPrefix+"Setting properties on:", itemPath)
+	resp, body, err := servicesManager.Client().SendPut(requestFullUrl, nil, &httpClientsDetails)
+	return checkOperationResponse(resp, body, err, http.StatusNoContent)
+}
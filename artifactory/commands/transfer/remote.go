@@ -0,0 +1,224 @@
+package transfer
+
+import (
+	"net/http"
+	"sync"
+	"sync/atomic"
+
+	"github.com/jfrog/gofrog/parallel"
+	commandsutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	ioUtils "github.com/jfrog/jfrog-client-go/utils/io"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// An operation on a single artifact in Artifactory.
+// Returns true if the operation failed and should be retried.
+type remoteOperation func(logMsgPrefix string) (shouldRetry bool, err error)
+
+// Adds an operation on a single artifact to the queue of operations.
+type addOperationFunc func(source, target string, operation remoteOperation)
+
+// The base of the commands of this package which operate on artifacts in Artifactory, such as copy, move and delete.
+// Unlike the generic commands, the artifacts are handled one by one, so that the result of each artifact can be reported.
+type remoteCommand struct {
+	spec                   *spec.SpecFiles
+	serverDetails          *config.ServerDetails
+	threads                int
+	retries                int
+	retryWaitTimeMilliSecs int
+	dryRun                 bool
+	quiet                  bool
+	detailedSummary        bool
+	events                 *EventsWriter
+	progress               ioUtils.ProgressMgr
+	// The label of the progress indicator of each operation, such as "Deleting".
+	progressLabel string
+	// The paths reported in the result instead of the source of an operation, such as the files of a deleted folder.
+	// The source of an operation which isn't in the map, or is mapped to no paths, is reported as is.
+	reportedPaths map[string][]string
+	result        *commandsutils.Result
+}
+
+func (rc *remoteCommand) SetSpec(spec *spec.SpecFiles) *remoteCommand {
+	rc.spec = spec
+	return rc
+}
+
+func (rc *remoteCommand) SetServerDetails(serverDetails *config.ServerDetails) *remoteCommand {
+	rc.serverDetails = serverDetails
+	return rc
+}
+
+func (rc *remoteCommand) SetThreads(threads int) *remoteCommand {
+	rc.threads = threads
+	return rc
+}
+
+func (rc *remoteCommand) SetRetries(retries int) *remoteCommand {
+	rc.retries = retries
+	return rc
+}
+
+func (rc *remoteCommand) SetRetryWaitMilliSecs(retryWaitMilliSecs int) *remoteCommand {
+	rc.retryWaitTimeMilliSecs = retryWaitMilliSecs
+	return rc
+}
+
+func (rc *remoteCommand) SetDryRun(dryRun bool) *remoteCommand {
+	rc.dryRun = dryRun
+	return rc
+}
+
+func (rc *remoteCommand) SetQuiet(quiet bool) *remoteCommand {
+	rc.quiet = quiet
+	return rc
+}
+
+func (rc *remoteCommand) SetDetailedSummary(detailedSummary bool) *remoteCommand {
+	rc.detailedSummary = detailedSummary
+	return rc
+}
+
+func (rc *remoteCommand) SetEvents(events *EventsWriter) *remoteCommand {
+	rc.events = events
+	return rc
+}
+
+func (rc *remoteCommand) SetProgress(progress ioUtils.ProgressMgr) {
+	rc.progress = progress
+}
+
+func (rc *remoteCommand) ServerDetails() (*config.ServerDetails, error) {
+	return rc.serverDetails, nil
+}
+
+func (rc *remoteCommand) Result() *commandsutils.Result {
+	return rc.result
+}
+
+// Creates a services manager without HTTP retries, for running the operations.
+// The operations are retried by runOperations, so that each retry can be reported.
+func (rc *remoteCommand) createOperationsServiceManager() (artifactory.ArtifactoryServicesManager, error) {
+	return utils.CreateServiceManager(rc.serverDetails, 0, 0, rc.dryRun)
+}
+
+// Runs the operations added by produceOperations in parallel, and sets the result of the command.
+// When the detailed summary is enabled, the source and target of each succeeded operation are added to the result.
+// An operation whose source has reported paths is counted, and added to the result, once for each of its reported paths.
+func (rc *remoteCommand) runOperations(produceOperations func(addOperation addOperationFunc) error) (err error) {
+	var succeeded, failed int32
+	var summaryWriter *content.ContentWriter
+	// The writer is shared by all threads, so writing to it is synchronized.
+	var summaryMutex sync.Mutex
+	if rc.detailedSummary {
+		summaryWriter, err = content.NewContentWriter(content.DefaultKey, true, false)
+		if err != nil {
+			return
+		}
+		defer func() {
+			e := summaryWriter.Close()
+			if err == nil {
+				err = e
+			}
+			if !summaryWriter.IsEmpty() {
+				rc.result.SetReader(content.NewContentReader(summaryWriter.GetFilePath(), content.DefaultKey))
+			}
+		}()
+	}
+	if rc.progress != nil {
+		rc.progress.InitProgressReaders()
+	}
+	runner := parallel.NewBounedRunner(rc.threads, false)
+	errorsQueue := clientutils.NewErrorsQueue(1)
+	go func() {
+		defer runner.Done()
+		err := produceOperations(func(source, target string, operation remoteOperation) {
+			if rc.progress != nil {
+				rc.progress.IncGeneralProgressTotalBy(1)
+			}
+			runner.AddTask(func(threadId int) error {
+				if rc.progress != nil {
+					progress := rc.progress.NewProgressReader(0, rc.progressLabel, source)
+					defer rc.progress.RemoveProgress(progress.GetId())
+				}
+				reportedPaths := rc.getReportedPaths(source)
+				if !rc.runOperation(source, target, operation, clientutils.GetLogMsgPrefix(threadId, rc.dryRun)) {
+					atomic.AddInt32(&failed, int32(len(reportedPaths)))
+					return nil
+				}
+				atomic.AddInt32(&succeeded, int32(len(reportedPaths)))
+				if summaryWriter != nil {
+					summaryMutex.Lock()
+					defer summaryMutex.Unlock()
+					for _, reportedPath := range reportedPaths {
+						summaryWriter.Write(clientutils.FileTransferDetails{SourcePath: reportedPath, TargetPath: target})
+					}
+				}
+				return nil
+			})
+		})
+		if err != nil {
+			errorsQueue.AddError(err)
+		}
+	}()
+	runner.Run()
+	rc.result.SetSuccessCount(int(succeeded))
+	rc.result.SetFailCount(int(failed))
+	return errorsQueue.GetError()
+}
+
+func (rc *remoteCommand) getReportedPaths(source string) []string {
+	if reportedPaths := rc.reportedPaths[source]; len(reportedPaths) > 0 {
+		return reportedPaths
+	}
+	return []string{source}
+}
+
+// Runs a single operation with retries, and reports its events.
+// Returns true if the operation succeeded.
+func (rc *remoteCommand) runOperation(source, target string, operation remoteOperation, logMsgPrefix string) bool {
+	rc.events.Started(source, target, 0)
+	attempt := 0
+	var lastErr error
+	retryExecutor := clientutils.RetryExecutor{
+		MaxRetries:               rc.retries,
+		RetriesIntervalMilliSecs: rc.retryWaitTimeMilliSecs,
+		ErrorMessage:             "Failed handling " + source,
+		LogMsgPrefix:             logMsgPrefix,
+		ExecutionHandler: func() (bool, error) {
+			attempt++
+			if attempt > 1 {
+				rc.events.Retried(source, target, attempt, lastErr)
+			}
+			shouldRetry, err := operation(logMsgPrefix)
+			lastErr = err
+			return shouldRetry, err
+		},
+	}
+	if err := retryExecutor.Execute(); err != nil {
+		log.Error(logMsgPrefix + err.Error())
+		rc.events.Failed(source, target, err)
+		return false
+	}
+	rc.events.Succeeded(source, target, 0)
+	return true
+}
+
+// Checks the response of an operation.
+// Server errors are retried, since they are usually temporary.
+func checkOperationResponse(resp *http.Response, body []byte, err error, expectedStatusCodes ...int) (shouldRetry bool, _ error) {
+	if err != nil {
+		return true, err
+	}
+	if err = errorutils.CheckResponseStatus(resp, expectedStatusCodes...); err != nil {
+		return resp.StatusCode >= http.StatusInternalServerError, errorutils.CheckError(errorutils.GenerateResponseError(resp.Status, clientutils.IndentJson(body)))
+	}
+	return false, nil
+}
@@ -0,0 +1,225 @@
+package transfer
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+
+	commandsutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	ioUtils "github.com/jfrog/jfrog-client-go/utils/io"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunOperations(t *testing.T) {
+	var lines []string
+	var mutex sync.Mutex
+	events := NewEventsWriter("rt_copy")
+	events.output = func(line string) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		lines = append(lines, line)
+	}
+	rc := &remoteCommand{threads: 2, retries: 2, events: events, result: new(commandsutils.Result)}
+	var flakyAttempts int
+	err := rc.runOperations(func(addOperation addOperationFunc) error {
+		addOperation("repo/a", "target/a", func(string) (bool, error) {
+			return false, nil
+		})
+		// Fails once with a retryable error, then succeeds.
+		addOperation("repo/b", "target/b", func(string) (bool, error) {
+			flakyAttempts++
+			if flakyAttempts == 1 {
+				return true, errors.New("connection reset")
+			}
+			return false, nil
+		})
+		addOperation("repo/c", "target/c", func(string) (bool, error) {
+			return false, errors.New("not found")
+		})
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, rc.Result().SuccessCount())
+	assert.Equal(t, 1, rc.Result().FailCount())
+
+	var actual []string
+	for _, line := range lines {
+		event := Event{}
+		assert.NoError(t, json.Unmarshal([]byte(line), &event))
+		actual = append(actual, event.Source+" "+string(event.Type)+" "+event.Error)
+	}
+	// The operations run in parallel, so the order of the events of different artifacts isn't deterministic.
+	sort.Strings(actual)
+	expected := []string{
+		"repo/a started ",
+		"repo/a succeeded ",
+		"repo/b retried connection reset",
+		"repo/b started ",
+		"repo/b succeeded ",
+		"repo/c failed not found",
+		"repo/c started ",
+	}
+	assert.Equal(t, expected, actual)
+}
+
+func TestRunOperationsProducerError(t *testing.T) {
+	rc := &remoteCommand{threads: 1, result: new(commandsutils.Result)}
+	err := rc.runOperations(func(addOperation addOperationFunc) error {
+		addOperation("repo/a", "", func(string) (bool, error) {
+			return false, nil
+		})
+		return errors.New("search failed")
+	})
+	assert.EqualError(t, err, "search failed")
+	assert.Equal(t, 1, rc.Result().SuccessCount())
+}
+
+func TestRunOperationsDetailedSummaryAndProgress(t *testing.T) {
+	progress := &testProgressMgr{}
+	rc := &remoteCommand{threads: 2, detailedSummary: true, progress: progress, progressLabel: "Deleting", result: new(commandsutils.Result)}
+	err := rc.runOperations(func(addOperation addOperationFunc) error {
+		addOperation("repo/a", "", func(string) (bool, error) {
+			return false, nil
+		})
+		addOperation("repo/b", "", func(string) (bool, error) {
+			return false, errors.New("forbidden")
+		})
+		addOperation("repo/c", "", func(string) (bool, error) {
+			return false, nil
+		})
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, rc.Result().SuccessCount())
+	assert.Equal(t, 1, rc.Result().FailCount())
+
+	// Only the succeeded operations are included in the detailed summary.
+	reader := rc.Result().Reader()
+	if assert.NotNil(t, reader) {
+		defer reader.Close()
+		var sources []string
+		for transferDetails := new(clientutils.FileTransferDetails); reader.NextRecord(transferDetails) == nil; transferDetails = new(clientutils.FileTransferDetails) {
+			sources = append(sources, transferDetails.SourcePath)
+		}
+		assert.NoError(t, reader.GetError())
+		sort.Strings(sources)
+		assert.Equal(t, []string{"repo/a", "repo/c"}, sources)
+	}
+
+	assert.True(t, progress.initialized)
+	assert.Equal(t, int64(3), progress.total)
+	assert.Equal(t, 3, progress.removed)
+	sort.Strings(progress.labels)
+	assert.Equal(t, []string{"Deleting repo/a", "Deleting repo/b", "Deleting repo/c"}, progress.labels)
+}
+
+func TestRunOperationsReportedPaths(t *testing.T) {
+	rc := &remoteCommand{threads: 2, detailedSummary: true, result: new(commandsutils.Result),
+		reportedPaths: map[string][]string{"repo/dir/": {"repo/dir/a", "repo/dir/sub/b"}, "repo/empty/": nil}}
+	err := rc.runOperations(func(addOperation addOperationFunc) error {
+		for _, source := range []string{"repo/dir/", "repo/empty/", "repo/c"} {
+			addOperation(source, "", func(string) (bool, error) {
+				return false, nil
+			})
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 4, rc.Result().SuccessCount())
+
+	// The files of a folder are reported instead of the folder. A folder without files is reported as is.
+	reader := rc.Result().Reader()
+	if assert.NotNil(t, reader) {
+		defer reader.Close()
+		var sources []string
+		for transferDetails := new(clientutils.FileTransferDetails); reader.NextRecord(transferDetails) == nil; transferDetails = new(clientutils.FileTransferDetails) {
+			sources = append(sources, transferDetails.SourcePath)
+		}
+		assert.NoError(t, reader.GetError())
+		sort.Strings(sources)
+		assert.Equal(t, []string{"repo/c", "repo/dir/a", "repo/dir/sub/b", "repo/empty/"}, sources)
+	}
+}
+
+func TestRunOperationsWithoutDetailedSummary(t *testing.T) {
+	rc := &remoteCommand{threads: 1, result: new(commandsutils.Result)}
+	err := rc.runOperations(func(addOperation addOperationFunc) error {
+		addOperation("repo/a", "target/a", func(string) (bool, error) {
+			return false, nil
+		})
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, rc.Result().SuccessCount())
+	assert.Nil(t, rc.Result().Reader())
+}
+
+// Records the calls of runOperations to the progress manager.
+type testProgressMgr struct {
+	ioUtils.ProgressMgr
+	mutex       sync.Mutex
+	initialized bool
+	total       int64
+	labels      []string
+	removed     int
+}
+
+func (tpm *testProgressMgr) InitProgressReaders() {
+	tpm.initialized = true
+}
+
+func (tpm *testProgressMgr) IncGeneralProgressTotalBy(n int64) {
+	tpm.mutex.Lock()
+	defer tpm.mutex.Unlock()
+	tpm.total += n
+}
+
+func (tpm *testProgressMgr) NewProgressReader(total int64, label, path string) ioUtils.Progress {
+	tpm.mutex.Lock()
+	defer tpm.mutex.Unlock()
+	tpm.labels = append(tpm.labels, label+" "+path)
+	return &testProgress{id: len(tpm.labels)}
+}
+
+func (tpm *testProgressMgr) RemoveProgress(id int) {
+	tpm.mutex.Lock()
+	defer tpm.mutex.Unlock()
+	tpm.removed++
+}
+
+type testProgress struct {
+	ioUtils.Progress
+	id int
+}
+
+func (tp *testProgress) GetId() int {
+	return tp.id
+}
+
+func TestGetDestinationPath(t *testing.T) {
+	tests := []struct {
+		name         string
+		target       string
+		pattern      string
+		itemPath     string
+		relativePath string
+		flat         bool
+		expected     string
+	}{
+		{"targetRepo", "repo2", "repo1/a/*", "a/b", "repo1/a/b/file.zip", false, "repo2/a/b/"},
+		{"targetDir", "repo2/dir/", "repo1/a/*", "a/b", "repo1/a/b/file.zip", false, "repo2/dir/a/b/"},
+		{"targetFile", "repo2/dir/renamed.zip", "repo1/a/*", "a/b", "repo1/a/b/file.zip", false, "repo2/dir/a/b/renamed.zip"},
+		{"flat", "repo2/dir/", "repo1/a/*", "a/b", "repo1/a/b/file.zip", true, "repo2/dir/"},
+		{"placeholders", "repo2/{1}.zip", "repo1/a/b/(*).zip", "a/b", "repo1/a/b/file.zip", false, "repo2/file.zip"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual, err := getDestinationPath(test.target, test.pattern, test.itemPath, test.relativePath, test.flat)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package transfer
+
+import (
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// A file which was uploaded to Artifactory, and its local source.
+type uploadedFile struct {
+	path   string
+	source string
+}
+
+// Reads the files uploaded by an upload command from its detailed summary. Sidecar files, which describe other files, are skipped.
+func readUploadedFiles(artifactoryUrl string, reader *content.ContentReader) ([]*uploadedFile, error) {
+	if reader == nil {
+		return nil, nil
+	}
+	defer reader.Reset()
+	var files []*uploadedFile
+	for transferDetails := new(clientutils.FileTransferDetails); reader.NextRecord(transferDetails) == nil; transferDetails = new(clientutils.FileTransferDetails) {
+		uploadedPath, err := getArtifactoryPath(artifactoryUrl, transferDetails.TargetPath)
+		if err != nil {
+			return nil, err
+		}
+		if isSidecarFile(uploadedPath) {
+			continue
+		}
+		files = append(files, &uploadedFile{path: uploadedPath, source: transferDetails.SourcePath})
+	}
+	return files, reader.GetError()
+}
+
+// Returns the path in Artifactory of a transferred file, from its URL in the detailed summary. The URL of an uploaded file may include its properties.
+func getArtifactoryPath(artifactoryUrl, targetUrl string) (string, error) {
+	uploadedPath := strings.TrimPrefix(targetUrl, clientutils.AddTrailingSlashIfNeeded(artifactoryUrl))
+	if propsIndex := strings.Index(uploadedPath[strings.LastIndex(uploadedPath, "/")+1:], ";"); propsIndex >= 0 {
+		uploadedPath = uploadedPath[:strings.LastIndex(uploadedPath, "/")+1+propsIndex]
+	}
+	uploadedPath, err := url.PathUnescape(uploadedPath)
+	return uploadedPath, errorutils.CheckError(err)
+}
+
+// Checksum files and signatures which are uploaded like any other file don't get checksum files and signatures of their own.
+func isSidecarFile(filePath string) bool {
+	if path.Base(filePath) == ChecksumsManifestName {
+		return true
+	}
+	for _, extension := range []string{".sha256", ".md5", "." + SignatureArmored, "." + SignatureBinary} {
+		if strings.HasSuffix(filePath, extension) {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns true if a file is the checksum file or signature of another one of the files, or the checksums manifest of their folder.
+// Files which only have the name of a sidecar file are handled like any other file.
+func isSidecarOf(filePath string, filePaths map[string]bool) bool {
+	if !isSidecarFile(filePath) {
+		return false
+	}
+	if path.Base(filePath) == ChecksumsManifestName {
+		for otherPath := range filePaths {
+			if otherPath != filePath && path.Dir(otherPath) == path.Dir(filePath) {
+				return true
+			}
+		}
+		return false
+	}
+	return filePaths[filePath[:strings.LastIndex(filePath, ".")]]
+}
+
+// Reads and deploys small files, such as checksum files and signatures, next to other files in Artifactory.
+// Failures are logged and counted, so that the sidecars of the other files are still deployed.
+type sidecarDeployer struct {
+	servicesManager artifactory.ArtifactoryServicesManager
+	deployed        int
+	failed          int
+}
+
+func newSidecarDeployer(serverDetails *config.ServerDetails, retries, retryWaitMilliSecs int) (*sidecarDeployer, error) {
+	servicesManager, err := utils.CreateServiceManager(serverDetails, retries, retryWaitMilliSecs, false)
+	if err != nil {
+		return nil, err
+	}
+	return &sidecarDeployer{servicesManager: servicesManager}, nil
+}
+
+// Returns the content of a file in Artifactory, and false if the file doesn't exist.
+func (sd *sidecarDeployer) read(filePath string) ([]byte, bool, error) {
+	serviceDetails := sd.servicesManager.GetConfig().GetServiceDetails()
+	fileUrl, err := servicesutils.BuildArtifactoryUrl(serviceDetails.GetUrl(), filePath, make(map[string]string))
+	if err != nil {
+		return nil, false, err
+	}
+	httpClientDetails := serviceDetails.CreateHttpClientDetails()
+	resp, body, _, err := sd.servicesManager.Client().SendGet(fileUrl, true, &httpClientDetails)
+	if err != nil {
+		return nil, false, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, false, nil
+	}
+	if err = errorutils.CheckResponseStatus(resp, http.StatusOK); err != nil {
+		return nil, false, errorutils.CheckError(errorutils.GenerateResponseError(resp.Status, clientutils.IndentJson(body)))
+	}
+	return body, true, nil
+}
+
+func (sd *sidecarDeployer) deploy(filePath string, sidecarContent []byte) {
+	serviceDetails := sd.servicesManager.GetConfig().GetServiceDetails()
+	fileUrl, err := servicesutils.BuildArtifactoryUrl(serviceDetails.GetUrl(), filePath, make(map[string]string))
+	if err == nil {
+		log.Debug("Deploying", filePath)
+		httpClientDetails := serviceDetails.CreateHttpClientDetails()
+		var resp *http.Response
+		var body []byte
+		resp, body, err = sd.servicesManager.Client().SendPut(fileUrl, sidecarContent, &httpClientDetails)
+		if err == nil {
+			if err = errorutils.CheckResponseStatus(resp, http.StatusOK, http.StatusCreated); err != nil {
+				err = errorutils.CheckError(errorutils.GenerateResponseError(resp.Status, clientutils.IndentJson(body)))
+			}
+		}
+	}
+	if err != nil {
+		sd.fail(filePath, err)
+		return
+	}
+	sd.deployed++
+}
+
+func (sd *sidecarDeployer) fail(filePath string, err error) {
+	log.Error("Failed deploying " + filePath + ": " + err.Error())
+	sd.failed++
+}
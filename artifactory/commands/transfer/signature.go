@@ -0,0 +1,255 @@
+package transfer
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	commandsutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	// An ASCII armored detached signature.
+	SignatureArmored = "asc"
+	// A binary detached signature.
+	SignatureBinary = "sig"
+)
+
+func ValidateSignatureFormat(format string) error {
+	if format != SignatureArmored && format != SignatureBinary {
+		return errorutils.CheckErrorf("the --signature-format option accepts one of the following values: %s, %s", SignatureArmored, SignatureBinary)
+	}
+	return nil
+}
+
+// Creates detached OpenPGP signatures of local files, using a local private key.
+type Signer struct {
+	entity *openpgp.Entity
+	format string
+}
+
+// Reads the first private key from an armored or binary key file. An encrypted key is decrypted using the passphrase.
+func NewSigner(keyPath, passphrase, format string) (*Signer, error) {
+	keyRing, err := readKeyRing(keyPath)
+	if err != nil {
+		return nil, err
+	}
+	for _, entity := range keyRing {
+		if entity.PrivateKey == nil {
+			continue
+		}
+		if err = decryptEntity(entity, passphrase); err != nil {
+			return nil, err
+		}
+		return &Signer{entity: entity, format: format}, nil
+	}
+	return nil, errorutils.CheckErrorf("the key file %s doesn't include a private key", keyPath)
+}
+
+func decryptEntity(entity *openpgp.Entity, passphrase string) error {
+	privateKeys := []*openpgp.Subkey{{PrivateKey: entity.PrivateKey}}
+	for i := range entity.Subkeys {
+		privateKeys = append(privateKeys, &entity.Subkeys[i])
+	}
+	for _, key := range privateKeys {
+		if key.PrivateKey == nil || !key.PrivateKey.Encrypted {
+			continue
+		}
+		if passphrase == "" {
+			return errorutils.CheckErrorf("the signing key is encrypted. Use the --sign-passphrase option to provide its passphrase")
+		}
+		if err := key.PrivateKey.Decrypt([]byte(passphrase)); err != nil {
+			return errorutils.CheckErrorf("failed decrypting the signing key: %s", err.Error())
+		}
+	}
+	return nil
+}
+
+// Returns the detached signature of a local file.
+func (s *Signer) sign(filePath string) ([]byte, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	defer file.Close()
+	var signature bytes.Buffer
+	if s.format == SignatureBinary {
+		err = openpgp.DetachSign(&signature, s.entity, file, nil)
+	} else {
+		err = openpgp.ArmoredDetachSign(&signature, s.entity, file, nil)
+	}
+	return signature.Bytes(), errorutils.CheckError(err)
+}
+
+// Deploys the detached signatures of the files uploaded by an upload command, which are read from the detailed summary of the command.
+// The signature of each file is deployed next to it, with the extension of the signature format.
+func DeploySignatures(serverDetails *config.ServerDetails, signer *Signer, reader *content.ContentReader, retries, retryWaitMilliSecs int) error {
+	files, err := readUploadedFiles(serverDetails.ArtifactoryUrl, reader)
+	if err != nil || len(files) == 0 {
+		return err
+	}
+	deployer, err := newSidecarDeployer(serverDetails, retries, retryWaitMilliSecs)
+	if err != nil {
+		return err
+	}
+	log.Info("Deploying the signatures of", len(files), "uploaded files...")
+	for _, file := range files {
+		signaturePath := file.path + "." + signer.format
+		signature, err := signer.sign(file.source)
+		if err != nil {
+			deployer.fail(signaturePath, err)
+			continue
+		}
+		deployer.deploy(signaturePath, signature)
+	}
+	if deployer.failed > 0 {
+		return errorutils.CheckErrorf("failed deploying %d signatures", deployer.failed)
+	}
+	log.Info("Deployed", deployer.deployed, "signatures.")
+	return nil
+}
+
+// Verifies the detached signatures of the files downloaded by a download command, which are read from the detailed summary of the command.
+// The signature of each file is expected next to it in Artifactory, in one of the signature formats.
+// A file whose signature is missing or isn't signed by one of the public keys is removed, and counted as failed in the result.
+// Returns an error if the signature of any of the files is missing or invalid.
+func VerifyDownloadedSignatures(serverDetails *config.ServerDetails, publicKeyPath string, result *commandsutils.Result, retries, retryWaitMilliSecs int) error {
+	if result.Reader() == nil {
+		return nil
+	}
+	keyRing, err := readKeyRing(publicKeyPath)
+	if err != nil {
+		return err
+	}
+	deployer, err := newSidecarDeployer(serverDetails, retries, retryWaitMilliSecs)
+	if err != nil {
+		return err
+	}
+	return verifyDownloadedFiles(serverDetails.ArtifactoryUrl, result, func(remotePath, localPath string) error {
+		return verifySignature(deployer, keyRing, remotePath, localPath)
+	})
+}
+
+// Verifies each of the downloaded files in the result. The files which fail the verification are removed from the local file system and from the result.
+// Checksum files and signatures of other downloaded files aren't verified.
+func verifyDownloadedFiles(artifactoryUrl string, result *commandsutils.Result, verify func(remotePath, localPath string) error) error {
+	reader := result.Reader()
+	downloadedPaths, err := readDownloadedPaths(artifactoryUrl, reader)
+	if err != nil {
+		return err
+	}
+	writer, err := content.NewContentWriter(content.DefaultKey, true, false)
+	if err != nil {
+		return err
+	}
+	verified, failed := 0, 0
+	for transferDetails := new(clientutils.FileTransferDetails); err == nil && reader.NextRecord(transferDetails) == nil; transferDetails = new(clientutils.FileTransferDetails) {
+		var remotePath string
+		if remotePath, err = getArtifactoryPath(artifactoryUrl, transferDetails.SourcePath); err != nil {
+			break
+		}
+		if isSidecarOf(remotePath, downloadedPaths) {
+			writer.Write(*transferDetails)
+			continue
+		}
+		if e := verify(remotePath, transferDetails.TargetPath); e != nil {
+			log.Error("Failed verifying the signature of " + remotePath + ": " + e.Error())
+			if e = os.Remove(transferDetails.TargetPath); e != nil {
+				log.Warn("Failed removing " + transferDetails.TargetPath + ": " + e.Error())
+			} else {
+				log.Info("Removed", transferDetails.TargetPath, "since its signature couldn't be verified.")
+			}
+			failed++
+			continue
+		}
+		log.Debug("Verified the signature of", remotePath)
+		writer.Write(*transferDetails)
+		verified++
+	}
+	if err == nil {
+		err = reader.GetError()
+	}
+	if e := writer.Close(); err == nil {
+		err = e
+	}
+	if err != nil {
+		reader.Reset()
+		return err
+	}
+	reader.Close()
+	result.SetReader(content.NewContentReader(writer.GetFilePath(), content.DefaultKey))
+	result.SetSuccessCount(result.SuccessCount() - failed)
+	result.SetFailCount(result.FailCount() + failed)
+	if failed > 0 {
+		return errorutils.CheckErrorf("the signatures of %d downloaded files are missing or invalid", failed)
+	}
+	if verified > 0 {
+		log.Info("Verified the signatures of", verified, "downloaded files.")
+	}
+	return nil
+}
+
+// Returns the paths in Artifactory of the files downloaded by a download command, from its detailed summary.
+func readDownloadedPaths(artifactoryUrl string, reader *content.ContentReader) (map[string]bool, error) {
+	defer reader.Reset()
+	downloadedPaths := make(map[string]bool)
+	for transferDetails := new(clientutils.FileTransferDetails); reader.NextRecord(transferDetails) == nil; transferDetails = new(clientutils.FileTransferDetails) {
+		remotePath, err := getArtifactoryPath(artifactoryUrl, transferDetails.SourcePath)
+		if err != nil {
+			return nil, err
+		}
+		downloadedPaths[remotePath] = true
+	}
+	return downloadedPaths, reader.GetError()
+}
+
+func verifySignature(deployer *sidecarDeployer, keyRing openpgp.EntityList, remotePath, localPath string) error {
+	for _, format := range []string{SignatureArmored, SignatureBinary} {
+		signature, found, err := deployer.read(remotePath + "." + format)
+		if err != nil {
+			return err
+		}
+		if !found {
+			continue
+		}
+		return checkDetachedSignature(keyRing, localPath, signature, format)
+	}
+	return errorutils.CheckErrorf("neither %s nor %s was found", path.Base(remotePath)+"."+SignatureArmored, path.Base(remotePath)+"."+SignatureBinary)
+}
+
+func checkDetachedSignature(keyRing openpgp.EntityList, localPath string, signature []byte, format string) error {
+	file, err := os.Open(localPath)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer file.Close()
+	if format == SignatureBinary {
+		_, err = openpgp.CheckDetachedSignature(keyRing, file, bytes.NewReader(signature), nil)
+	} else {
+		_, err = openpgp.CheckArmoredDetachedSignature(keyRing, file, bytes.NewReader(signature), nil)
+	}
+	return errorutils.CheckError(err)
+}
+
+// Reads the keys of an armored or binary key file.
+func readKeyRing(keyPath string) (openpgp.EntityList, error) {
+	keyContent, err := ioutil.ReadFile(keyPath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	keyRing, err := openpgp.ReadArmoredKeyRing(bytes.NewReader(keyContent))
+	if err != nil {
+		keyRing, err = openpgp.ReadKeyRing(bytes.NewReader(keyContent))
+	}
+	if err != nil {
+		return nil, errorutils.CheckErrorf("failed reading the keys of %s: %s", keyPath, err.Error())
+	}
+	return keyRing, nil
+}
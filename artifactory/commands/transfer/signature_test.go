@@ -0,0 +1,159 @@
+package transfer
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	coretests "github.com/jfrog/jfrog-cli-core/v2/utils/tests"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignAndVerify(t *testing.T) {
+	tempDirPath, createTempDirCallback := coretests.CreateTempDirWithCallbackAndAssert(t)
+	defer createTempDirCallback()
+	entity, err := openpgp.NewEntity("test", "", "test@example.com", nil)
+	assert.NoError(t, err)
+	privateKeyPath, publicKeyPath := writeTestKeys(t, tempDirPath, entity, "")
+	keyRing, err := readKeyRing(publicKeyPath)
+	assert.NoError(t, err)
+
+	filePath := filepath.Join(tempDirPath, "a.bin")
+	assert.NoError(t, ioutil.WriteFile(filePath, []byte("content"), 0644))
+	tamperedPath := filepath.Join(tempDirPath, "b.bin")
+	assert.NoError(t, ioutil.WriteFile(tamperedPath, []byte("tampered content"), 0644))
+	for _, format := range []string{SignatureArmored, SignatureBinary} {
+		t.Run(format, func(t *testing.T) {
+			signer, err := NewSigner(privateKeyPath, "", format)
+			assert.NoError(t, err)
+			signature, err := signer.sign(filePath)
+			assert.NoError(t, err)
+			assert.Equal(t, format == SignatureArmored, bytes.HasPrefix(signature, []byte("-----BEGIN PGP SIGNATURE-----")))
+
+			assert.NoError(t, checkDetachedSignature(keyRing, filePath, signature, format))
+			assert.Error(t, checkDetachedSignature(keyRing, tamperedPath, signature, format))
+		})
+	}
+
+	// A signature of another key is rejected.
+	otherEntity, err := openpgp.NewEntity("other", "", "other@example.com", nil)
+	assert.NoError(t, err)
+	otherKeyPath, _ := writeTestKeys(t, filepath.Join(tempDirPath, "other"), otherEntity, "")
+	otherSigner, err := NewSigner(otherKeyPath, "", SignatureArmored)
+	assert.NoError(t, err)
+	signature, err := otherSigner.sign(filePath)
+	assert.NoError(t, err)
+	assert.Error(t, checkDetachedSignature(keyRing, filePath, signature, SignatureArmored))
+}
+
+func TestNewSignerWithEncryptedKey(t *testing.T) {
+	tempDirPath, createTempDirCallback := coretests.CreateTempDirWithCallbackAndAssert(t)
+	defer createTempDirCallback()
+	entity, err := openpgp.NewEntity("test", "", "test@example.com", nil)
+	assert.NoError(t, err)
+	privateKeyPath, publicKeyPath := writeTestKeys(t, tempDirPath, entity, "passphrase")
+
+	_, err = NewSigner(privateKeyPath, "", SignatureArmored)
+	assert.Error(t, err)
+	_, err = NewSigner(privateKeyPath, "wrong", SignatureArmored)
+	assert.Error(t, err)
+	signer, err := NewSigner(privateKeyPath, "passphrase", SignatureArmored)
+	assert.NoError(t, err)
+	filePath := filepath.Join(tempDirPath, "a.bin")
+	assert.NoError(t, ioutil.WriteFile(filePath, []byte("content"), 0644))
+	signature, err := signer.sign(filePath)
+	assert.NoError(t, err)
+	keyRing, err := readKeyRing(publicKeyPath)
+	assert.NoError(t, err)
+	assert.NoError(t, checkDetachedSignature(keyRing, filePath, signature, SignatureArmored))
+
+	// A public key can't be used for signing.
+	_, err = NewSigner(publicKeyPath, "", SignatureArmored)
+	assert.Error(t, err)
+}
+
+// Writes the private key of the entity as an armored file, and its public key as a binary file.
+func writeTestKeys(t *testing.T, dirPath string, entity *openpgp.Entity, passphrase string) (privateKeyPath, publicKeyPath string) {
+	var privateKey bytes.Buffer
+	armorWriter, err := armor.Encode(&privateKey, openpgp.PrivateKeyType, nil)
+	assert.NoError(t, err)
+	// The identity is signed before the key is encrypted.
+	assert.NoError(t, entity.SerializePrivate(ioutil.Discard, nil))
+	if passphrase != "" {
+		assert.NoError(t, entity.PrivateKey.Encrypt([]byte(passphrase)))
+		for _, subkey := range entity.Subkeys {
+			assert.NoError(t, subkey.PrivateKey.Encrypt([]byte(passphrase)))
+		}
+	}
+	assert.NoError(t, entity.SerializePrivateWithoutSigning(armorWriter, nil))
+	assert.NoError(t, armorWriter.Close())
+	var publicKey bytes.Buffer
+	assert.NoError(t, entity.Serialize(&publicKey))
+
+	assert.NoError(t, os.MkdirAll(dirPath, 0755))
+	privateKeyPath = filepath.Join(dirPath, "private.asc")
+	assert.NoError(t, ioutil.WriteFile(privateKeyPath, privateKey.Bytes(), 0600))
+	publicKeyPath = filepath.Join(dirPath, "public.gpg")
+	assert.NoError(t, ioutil.WriteFile(publicKeyPath, publicKey.Bytes(), 0644))
+	return
+}
+
+func TestVerifyDownloadedFilesRemovesFailed(t *testing.T) {
+	tempDirPath, createTempDirCallback := coretests.CreateTempDirWithCallbackAndAssert(t)
+	defer createTempDirCallback()
+	artifactoryUrl := "http://localhost:8081/artifactory/"
+	validPath, invalidPath := filepath.Join(tempDirPath, "valid.bin"), filepath.Join(tempDirPath, "invalid.bin")
+	assert.NoError(t, ioutil.WriteFile(validPath, []byte("valid"), 0644))
+	assert.NoError(t, ioutil.WriteFile(invalidPath, []byte("invalid"), 0644))
+	result, err := appendToResult(nil, []clientutils.FileTransferDetails{
+		{SourcePath: artifactoryUrl + "repo/valid.bin", TargetPath: validPath},
+		{SourcePath: artifactoryUrl + "repo/invalid.bin", TargetPath: invalidPath},
+	}, 0)
+	assert.NoError(t, err)
+
+	err = verifyDownloadedFiles(artifactoryUrl, result, func(remotePath, localPath string) error {
+		if remotePath == "repo/invalid.bin" {
+			return errors.New("bad signature")
+		}
+		return nil
+	})
+	assert.Error(t, err)
+	// The file which failed the verification is removed, and reported as failed.
+	assert.FileExists(t, validPath)
+	assert.NoFileExists(t, invalidPath)
+	assert.Equal(t, 1, result.SuccessCount())
+	assert.Equal(t, 1, result.FailCount())
+	reader := result.Reader()
+	defer reader.Close()
+	var targets []string
+	for transferDetails := new(clientutils.FileTransferDetails); reader.NextRecord(transferDetails) == nil; transferDetails = new(clientutils.FileTransferDetails) {
+		targets = append(targets, transferDetails.TargetPath)
+	}
+	assert.Equal(t, []string{validPath}, targets)
+}
+
+func TestVerifyDownloadedFilesSkipsSidecars(t *testing.T) {
+	artifactoryUrl := "http://localhost:8081/artifactory/"
+	var transfers []clientutils.FileTransferDetails
+	for _, remotePath := range []string{"repo/a.bin", "repo/a.bin.asc", "repo/a.bin.sha256", "repo/SHA256SUMS", "repo/notes.md5", "other/SHA256SUMS"} {
+		transfers = append(transfers, clientutils.FileTransferDetails{SourcePath: artifactoryUrl + remotePath, TargetPath: remotePath})
+	}
+	result, err := appendToResult(nil, transfers, 0)
+	assert.NoError(t, err)
+	defer result.Reader().Close()
+
+	// Only the sidecars of other downloaded files are skipped. Files which only have the name of a sidecar file are verified.
+	var verified []string
+	assert.NoError(t, verifyDownloadedFiles(artifactoryUrl, result, func(remotePath, localPath string) error {
+		verified = append(verified, remotePath)
+		return nil
+	}))
+	assert.Equal(t, []string{"repo/a.bin", "repo/notes.md5", "other/SHA256SUMS"}, verified)
+	assert.Equal(t, len(transfers), result.SuccessCount())
+}
@@ -0,0 +1,306 @@
+package transfer
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"hash"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	commandsutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	ioUtils "github.com/jfrog/jfrog-client-go/utils/io"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Used instead of a local path, to upload from the standard input or download to the standard output.
+const StdioPath = "-"
+
+// Uploads the content of a reader, such as the standard input, to a single file in Artifactory.
+// Since the content can be read only once, a failed upload isn't retried.
+type StreamUploadCommand struct {
+	serverDetails *config.ServerDetails
+	reader        io.Reader
+	target        string
+	size          int64
+	targetProps   string
+	progress      ioUtils.ProgressMgr
+	result        *commandsutils.Result
+}
+
+func NewStreamUploadCommand() *StreamUploadCommand {
+	return &StreamUploadCommand{size: -1, result: new(commandsutils.Result)}
+}
+
+func (suc *StreamUploadCommand) SetServerDetails(serverDetails *config.ServerDetails) *StreamUploadCommand {
+	suc.serverDetails = serverDetails
+	return suc
+}
+
+func (suc *StreamUploadCommand) SetReader(reader io.Reader) *StreamUploadCommand {
+	suc.reader = reader
+	return suc
+}
+
+// The path of the uploaded file in Artifactory, in the following format: <repository name>/<repository path>.
+func (suc *StreamUploadCommand) SetTarget(target string) *StreamUploadCommand {
+	suc.target = target
+	return suc
+}
+
+// The size of the content in bytes, or -1 if it's unknown. When the size is known, the progress of the upload is displayed.
+func (suc *StreamUploadCommand) SetSize(size int64) *StreamUploadCommand {
+	suc.size = size
+	return suc
+}
+
+// Properties in the form of "key1=value1;key2=value2,...", which are attached to the uploaded file.
+func (suc *StreamUploadCommand) SetTargetProps(targetProps string) *StreamUploadCommand {
+	suc.targetProps = targetProps
+	return suc
+}
+
+func (suc *StreamUploadCommand) SetProgress(progress ioUtils.ProgressMgr) {
+	suc.progress = progress
+}
+
+func (suc *StreamUploadCommand) ServerDetails() (*config.ServerDetails, error) {
+	return suc.serverDetails, nil
+}
+
+func (suc *StreamUploadCommand) Result() *commandsutils.Result {
+	return suc.result
+}
+
+func (suc *StreamUploadCommand) CommandName() string {
+	return "rt_upload"
+}
+
+func (suc *StreamUploadCommand) Run() error {
+	if suc.target == "" || strings.HasSuffix(suc.target, "/") {
+		return errorutils.CheckErrorf("the target of an upload from the standard input should be the path of a file, rather than a folder")
+	}
+	servicesManager, err := utils.CreateServiceManager(suc.serverDetails, 0, 0, false)
+	if err != nil {
+		return err
+	}
+	serviceDetails := servicesManager.GetConfig().GetServiceDetails()
+	targetUrl, err := servicesutils.BuildArtifactoryUrl(serviceDetails.GetUrl(), suc.target, make(map[string]string))
+	if err != nil {
+		return err
+	}
+	uploadUrl := targetUrl
+	if suc.targetProps != "" {
+		props, err := servicesutils.ParseProperties(suc.targetProps)
+		if err != nil {
+			return err
+		}
+		uploadUrl += ";" + props.ToEncodedString(false)
+	}
+
+	checksums := newStreamChecksums()
+	reader := io.TeeReader(suc.reader, checksums)
+	if suc.progress != nil && suc.size >= 0 {
+		progress := suc.progress.NewProgressReader(suc.size, "Uploading", suc.target)
+		reader = progress.ActionWithProgress(reader)
+		defer suc.progress.RemoveProgress(progress.GetId())
+	}
+	log.Info("Uploading to " + suc.target + "...")
+	httpClientDetails := serviceDetails.CreateHttpClientDetails()
+	resp, body, err := servicesManager.Client().UploadFileFromReader(reader, uploadUrl, &httpClientDetails, suc.size)
+	if err == nil {
+		if err = errorutils.CheckResponseStatus(resp, http.StatusOK, http.StatusCreated); err != nil {
+			err = errorutils.CheckError(errorutils.GenerateResponseError(resp.Status, clientutils.IndentJson(body)))
+		}
+	}
+	if err == nil {
+		err = verifyDeployedChecksum(body, checksums.sha1())
+	}
+	if err != nil {
+		suc.result.SetFailCount(1)
+		return err
+	}
+	log.Info("Uploaded", checksums.size, "bytes to", suc.target+".")
+	return setStreamResult(suc.result, clientutils.FileTransferDetails{SourcePath: StdioPath, TargetPath: targetUrl, Sha256: checksums.sha256()})
+}
+
+// Artifactory returns the checksums of the deployed file. Since the content isn't available before it's uploaded,
+// its checksum is verified after the upload instead.
+func verifyDeployedChecksum(body []byte, uploadedSha1 string) error {
+	response := struct {
+		Checksums struct {
+			Sha1 string `json:"sha1"`
+		} `json:"checksums"`
+	}{}
+	if err := json.Unmarshal(body, &response); err != nil || response.Checksums.Sha1 == "" {
+		log.Debug("Artifactory didn't return the checksum of the uploaded file.")
+		return nil
+	}
+	if response.Checksums.Sha1 != uploadedSha1 {
+		return errorutils.CheckErrorf("the sha1 checksum of the uploaded file in Artifactory (%s) is different from the checksum of the uploaded content (%s)", response.Checksums.Sha1, uploadedSha1)
+	}
+	return nil
+}
+
+// Downloads a single file from Artifactory to a writer, such as the standard output.
+// Nothing but the content of the file is written to the writer, so the logs and the progress are written to the standard error.
+type StreamDownloadCommand struct {
+	serverDetails *config.ServerDetails
+	source        string
+	writer        io.Writer
+	progress      ioUtils.ProgressMgr
+	result        *commandsutils.Result
+}
+
+func NewStreamDownloadCommand() *StreamDownloadCommand {
+	return &StreamDownloadCommand{result: new(commandsutils.Result)}
+}
+
+func (sdc *StreamDownloadCommand) SetServerDetails(serverDetails *config.ServerDetails) *StreamDownloadCommand {
+	sdc.serverDetails = serverDetails
+	return sdc
+}
+
+// The path of the downloaded file in Artifactory, in the following format: <repository name>/<repository path>.
+func (sdc *StreamDownloadCommand) SetSource(source string) *StreamDownloadCommand {
+	sdc.source = source
+	return sdc
+}
+
+func (sdc *StreamDownloadCommand) SetWriter(writer io.Writer) *StreamDownloadCommand {
+	sdc.writer = writer
+	return sdc
+}
+
+func (sdc *StreamDownloadCommand) SetProgress(progress ioUtils.ProgressMgr) {
+	sdc.progress = progress
+}
+
+func (sdc *StreamDownloadCommand) ServerDetails() (*config.ServerDetails, error) {
+	return sdc.serverDetails, nil
+}
+
+func (sdc *StreamDownloadCommand) Result() *commandsutils.Result {
+	return sdc.result
+}
+
+func (sdc *StreamDownloadCommand) CommandName() string {
+	return "rt_download"
+}
+
+func (sdc *StreamDownloadCommand) Run() error {
+	if sdc.source == "" || strings.HasSuffix(sdc.source, "/") || strings.ContainsAny(sdc.source, "*?") {
+		return errorutils.CheckErrorf("only the path of a single file can be downloaded to the standard output")
+	}
+	servicesManager, err := utils.CreateServiceManager(sdc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	serviceDetails := servicesManager.GetConfig().GetServiceDetails()
+	sourceUrl, err := servicesutils.BuildArtifactoryUrl(serviceDetails.GetUrl(), sdc.source, make(map[string]string))
+	if err != nil {
+		return err
+	}
+	httpClientDetails := serviceDetails.CreateHttpClientDetails()
+	body, resp, err := servicesManager.Client().ReadRemoteFile(sourceUrl, &httpClientDetails)
+	if err != nil {
+		sdc.result.SetFailCount(1)
+		return err
+	}
+	defer body.Close()
+	if err = errorutils.CheckResponseStatus(resp, http.StatusOK); err != nil {
+		sdc.result.SetFailCount(1)
+		return errorutils.CheckError(errorutils.GenerateResponseError(resp.Status, ""))
+	}
+
+	checksums := newStreamChecksums()
+	var reader io.Reader = io.TeeReader(body, checksums)
+	if sdc.progress != nil && resp.ContentLength >= 0 {
+		progress := sdc.progress.NewProgressReader(resp.ContentLength, "Downloading", sdc.source)
+		reader = progress.ActionWithProgress(reader)
+		defer sdc.progress.RemoveProgress(progress.GetId())
+	}
+	log.Info("Downloading " + sdc.source + "...")
+	_, err = io.Copy(sdc.writer, reader)
+	if err == nil {
+		// The file was already written by the time its checksum is verified, so a mismatch fails the command rather than preventing the write.
+		if expected := resp.Header.Get("X-Checksum-Sha1"); expected != "" && expected != checksums.sha1() {
+			err = errorutils.CheckErrorf("the sha1 checksum of the downloaded content (%s) is different from the checksum of %s in Artifactory (%s)", checksums.sha1(), sdc.source, expected)
+		}
+	}
+	if err != nil {
+		sdc.result.SetFailCount(1)
+		return errorutils.CheckError(err)
+	}
+	log.Info("Downloaded", checksums.size, "bytes from", sdc.source+".")
+	return setStreamResult(sdc.result, clientutils.FileTransferDetails{SourcePath: sourceUrl, TargetPath: StdioPath, Sha256: checksums.sha256()})
+}
+
+// Sets the result of a command which transferred a single file.
+func setStreamResult(result *commandsutils.Result, transferDetails clientutils.FileTransferDetails) error {
+	writer, err := content.NewContentWriter(content.DefaultKey, true, false)
+	if err != nil {
+		return err
+	}
+	writer.Write(transferDetails)
+	if err = writer.Close(); err != nil {
+		return err
+	}
+	result.SetSuccessCount(1)
+	result.SetReader(content.NewContentReader(writer.GetFilePath(), content.DefaultKey))
+	return nil
+}
+
+// Calculates the checksums and size of the content written to it, while the content is streamed.
+type streamChecksums struct {
+	md5Hash    hash.Hash
+	sha1Hash   hash.Hash
+	sha256Hash hash.Hash
+	size       int64
+}
+
+func newStreamChecksums() *streamChecksums {
+	return &streamChecksums{md5Hash: md5.New(), sha1Hash: sha1.New(), sha256Hash: sha256.New()}
+}
+
+// Calculates the checksums of a local file.
+func calcFileChecksums(filePath string) (*streamChecksums, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	defer file.Close()
+	checksums := newStreamChecksums()
+	_, err = io.Copy(checksums, file)
+	return checksums, errorutils.CheckError(err)
+}
+
+func (sc *streamChecksums) Write(p []byte) (int, error) {
+	sc.md5Hash.Write(p)
+	sc.sha1Hash.Write(p)
+	sc.sha256Hash.Write(p)
+	sc.size += int64(len(p))
+	return len(p), nil
+}
+
+func (sc *streamChecksums) md5() string {
+	return hex.EncodeToString(sc.md5Hash.Sum(nil))
+}
+
+func (sc *streamChecksums) sha1() string {
+	return hex.EncodeToString(sc.sha1Hash.Sum(nil))
+}
+
+func (sc *streamChecksums) sha256() string {
+	return hex.EncodeToString(sc.sha256Hash.Sum(nil))
+}
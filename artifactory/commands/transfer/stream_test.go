@@ -0,0 +1,24 @@
+package transfer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStreamChecksums(t *testing.T) {
+	checksums := newStreamChecksums()
+	_, err := checksums.Write([]byte("content"))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), checksums.size)
+	assert.Equal(t, "040f06fd774092478d450774f5ba30c5da78acc8", checksums.sha1())
+	assert.Equal(t, "ed7002b439e9ac845f22357d822bac1444730fbdb6016d3ec9432297b9ec9f73", checksums.sha256())
+}
+
+func TestVerifyDeployedChecksum(t *testing.T) {
+	body := []byte(`{"checksums":{"sha1":"040f06fd774092478d450774f5ba30c5da78acc8"}}`)
+	assert.NoError(t, verifyDeployedChecksum(body, "040f06fd774092478d450774f5ba30c5da78acc8"))
+	assert.Error(t, verifyDeployedChecksum(body, "da39a3ee5e6b4b0d3255bfef95601890afd80709"))
+	// Responses without checksums aren't verified.
+	assert.NoError(t, verifyDeployedChecksum([]byte("{}"), "da39a3ee5e6b4b0d3255bfef95601890afd80709"))
+}
@@ -0,0 +1,532 @@
+package transfer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	commandsutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli/utils/cache"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Decides which side wins when a file was changed on both sides since the last sync.
+type ConflictRule string
+
+const (
+	// The side which was modified last wins.
+	NewerWins ConflictRule = "newer-wins"
+	// The local file wins.
+	LocalWins ConflictRule = "local-wins"
+	// The file in Artifactory wins.
+	RemoteWins ConflictRule = "remote-wins"
+
+	syncStatesDirName = "sync"
+)
+
+var ConflictRules = []string{string(NewerWins), string(LocalWins), string(RemoteWins)}
+
+type SyncAction string
+
+const (
+	SyncUpload       SyncAction = "upload"
+	SyncDownload     SyncAction = "download"
+	SyncDeleteRemote SyncAction = "delete-remote"
+	SyncDeleteLocal  SyncAction = "delete-local"
+)
+
+// A single change applied by the sync. The path is relative to the synced directory and repository path.
+type SyncPlanItem struct {
+	Path   string
+	Action SyncAction
+	Reason string
+}
+
+// A file on one side of the sync.
+type SyncFile struct {
+	Sha256   string
+	Modified time.Time
+}
+
+// Keeps the checksums of the files which were in sync when the last sync completed.
+// This allows telling which side changed a file, or deleted it, since then.
+type syncState struct {
+	Files map[string]string `json:"files"`
+	path  string
+}
+
+// Loads the state from the provided path. Returns an empty state if it doesn't exist.
+func loadSyncState(path string) (*syncState, error) {
+	state := &syncState{Files: make(map[string]string), path: path}
+	exists, err := fileutils.IsFileExists(path, false)
+	if err != nil || !exists {
+		return state, err
+	}
+	content, err := ioutil.ReadFile(path)
+	if errorutils.CheckError(err) != nil {
+		return nil, err
+	}
+	if err = errorutils.CheckError(json.Unmarshal(content, state)); err != nil {
+		return nil, err
+	}
+	if state.Files == nil {
+		state.Files = make(map[string]string)
+	}
+	return state, nil
+}
+
+// Writes the state to a temp file first and then renames it, the same way the journal is saved.
+func (ss *syncState) save() error {
+	content, err := json.Marshal(ss)
+	if errorutils.CheckError(err) != nil {
+		return err
+	}
+	err = os.MkdirAll(filepath.Dir(ss.path), 0777)
+	if errorutils.CheckError(err) != nil {
+		return err
+	}
+	tempPath := ss.path + ".tmp"
+	err = ioutil.WriteFile(tempPath, content, 0600)
+	if errorutils.CheckError(err) != nil {
+		return err
+	}
+	return errorutils.CheckError(os.Rename(tempPath, ss.path))
+}
+
+// Syncs a local directory with a path in Artifactory in both directions.
+// The checksums of both sides are compared with the state saved by the last sync, in order to plan which files to upload, download or delete.
+// The uploads and downloads are done by the upload and download commands of this package.
+type SyncCommand struct {
+	localDir               string
+	remotePath             string
+	serverDetails          *config.ServerDetails
+	uploadConfiguration    *utils.UploadConfiguration
+	downloadConfiguration  *utils.DownloadConfiguration
+	conflictRule           ConflictRule
+	threads                int
+	retries                int
+	retryWaitTimeMilliSecs int
+	dryRun                 bool
+	quiet                  bool
+	result                 *commandsutils.Result
+}
+
+func NewSyncCommand() *SyncCommand {
+	return &SyncCommand{conflictRule: NewerWins, result: new(commandsutils.Result)}
+}
+
+func (sc *SyncCommand) SetLocalDir(localDir string) *SyncCommand {
+	sc.localDir = localDir
+	return sc
+}
+
+func (sc *SyncCommand) SetRemotePath(remotePath string) *SyncCommand {
+	sc.remotePath = remotePath
+	return sc
+}
+
+func (sc *SyncCommand) SetServerDetails(serverDetails *config.ServerDetails) *SyncCommand {
+	sc.serverDetails = serverDetails
+	return sc
+}
+
+func (sc *SyncCommand) SetUploadConfiguration(uploadConfiguration *utils.UploadConfiguration) *SyncCommand {
+	sc.uploadConfiguration = uploadConfiguration
+	return sc
+}
+
+func (sc *SyncCommand) SetDownloadConfiguration(downloadConfiguration *utils.DownloadConfiguration) *SyncCommand {
+	sc.downloadConfiguration = downloadConfiguration
+	return sc
+}
+
+func (sc *SyncCommand) SetConflictRule(conflictRule ConflictRule) *SyncCommand {
+	sc.conflictRule = conflictRule
+	return sc
+}
+
+func (sc *SyncCommand) SetThreads(threads int) *SyncCommand {
+	sc.threads = threads
+	return sc
+}
+
+func (sc *SyncCommand) SetRetries(retries int) *SyncCommand {
+	sc.retries = retries
+	return sc
+}
+
+func (sc *SyncCommand) SetRetryWaitMilliSecs(retryWaitMilliSecs int) *SyncCommand {
+	sc.retryWaitTimeMilliSecs = retryWaitMilliSecs
+	return sc
+}
+
+func (sc *SyncCommand) SetDryRun(dryRun bool) *SyncCommand {
+	sc.dryRun = dryRun
+	return sc
+}
+
+func (sc *SyncCommand) SetQuiet(quiet bool) *SyncCommand {
+	sc.quiet = quiet
+	return sc
+}
+
+func (sc *SyncCommand) ServerDetails() (*config.ServerDetails, error) {
+	return sc.serverDetails, nil
+}
+
+func (sc *SyncCommand) Result() *commandsutils.Result {
+	return sc.result
+}
+
+func (sc *SyncCommand) CommandName() string {
+	return "rt_sync"
+}
+
+func (sc *SyncCommand) Run() error {
+	localDir, err := filepath.Abs(sc.localDir)
+	if errorutils.CheckError(err) != nil {
+		return err
+	}
+	remotePath := strings.Trim(sc.remotePath, "/")
+	state, err := sc.loadState(localDir, remotePath)
+	if err != nil {
+		return err
+	}
+	log.Info("Comparing the local directory with Artifactory...")
+	localFiles, err := collectLocalFiles(localDir)
+	if err != nil {
+		return err
+	}
+	servicesManager, err := utils.CreateServiceManager(sc.serverDetails, sc.retries, sc.retryWaitTimeMilliSecs, false)
+	if err != nil {
+		return err
+	}
+	remoteFiles, err := collectRemoteFiles(servicesManager, remotePath)
+	if err != nil {
+		return err
+	}
+
+	plan := CreateSyncPlan(localFiles, remoteFiles, state.Files, sc.conflictRule)
+	if len(plan) == 0 {
+		log.Info("The local directory and Artifactory are already in sync.")
+	}
+	for _, item := range plan {
+		log.Output(fmt.Sprintf("%-13s %s (%s)", item.Action, item.Path, item.Reason))
+	}
+	if sc.dryRun {
+		return nil
+	}
+	if !sc.quiet && hasDeletions(plan) && !coreutils.AskYesNo("The sync deletes some of the files. Are you sure you want to continue?", false) {
+		return nil
+	}
+
+	succeeded, err := sc.applyPlan(plan, localDir, remotePath)
+	updateSyncState(state.Files, localFiles, remoteFiles, plan, succeeded)
+	if e := state.save(); err == nil {
+		err = e
+	}
+	return err
+}
+
+// The state is identified by the server, the local directory and the repository path, so that each synced pair has its own state.
+func (sc *SyncCommand) loadState(localDir, remotePath string) (*syncState, error) {
+	fingerprint, err := CreateFingerprint(sc.CommandName(), sc.serverDetails.ArtifactoryUrl, &spec.SpecFiles{Files: []spec.File{{Pattern: localDir, Target: remotePath}}})
+	if err != nil {
+		return nil, err
+	}
+	homeDir, err := coreutils.GetJfrogHomeDir()
+	if err != nil {
+		return nil, err
+	}
+	return loadSyncState(filepath.Join(homeDir, syncStatesDirName, fingerprint+".json"))
+}
+
+// Applies the plan and returns the paths of the plan items which were applied successfully.
+func (sc *SyncCommand) applyPlan(plan []SyncPlanItem, localDir, remotePath string) (map[string]bool, error) {
+	var uploads, downloads []*Entry
+	var remoteDeletions, localDeletions []string
+	for _, item := range plan {
+		localPath := filepath.Join(localDir, filepath.FromSlash(item.Path))
+		itemRemotePath := path.Join(remotePath, item.Path)
+		switch item.Action {
+		case SyncUpload:
+			uploads = append(uploads, &Entry{Source: localPath, Target: itemRemotePath, Status: Pending})
+		case SyncDownload:
+			downloads = append(downloads, &Entry{Source: itemRemotePath, Target: localPath, Status: Pending})
+		case SyncDeleteRemote:
+			remoteDeletions = append(remoteDeletions, item.Path)
+		case SyncDeleteLocal:
+			localDeletions = append(localDeletions, item.Path)
+		}
+	}
+
+	succeeded := make(map[string]bool)
+	var errs []error
+	if len(uploads) > 0 {
+		uploadCommand := NewUploadCommand()
+		uploadCommand.SetUploadConfiguration(sc.uploadConfiguration).SetSpec(&spec.SpecFiles{Files: []spec.File{{Target: remotePath + "/"}}}).
+			SetServerDetails(sc.serverDetails).SetRetries(sc.retries).SetRetryWaitMilliSecs(sc.retryWaitTimeMilliSecs)
+		journal := NewJournal("", uploadCommand.CommandName(), "")
+		journal.Entries = uploads
+		errs = append(errs, uploadCommand.runJournal(journal, uploadCommand))
+		sc.addResult(uploadCommand.Result())
+		collectDoneEntries(uploads, func(entry *Entry) string { return entry.Target }, remotePath, succeeded)
+	}
+	if len(downloads) > 0 {
+		downloadCommand := NewDownloadCommand()
+		downloadCommand.SetConfiguration(sc.downloadConfiguration).SetSpec(&spec.SpecFiles{Files: []spec.File{{Pattern: remotePath + "/", Target: localDir}}}).
+			SetServerDetails(sc.serverDetails).SetRetries(sc.retries).SetRetryWaitMilliSecs(sc.retryWaitTimeMilliSecs)
+		journal := NewJournal("", downloadCommand.CommandName(), "")
+		journal.Entries = downloads
+		errs = append(errs, downloadCommand.runJournal(journal, downloadCommand))
+		sc.addResult(downloadCommand.Result())
+		collectDoneEntries(downloads, func(entry *Entry) string { return entry.Source }, remotePath, succeeded)
+	}
+	if len(remoteDeletions) > 0 {
+		errs = append(errs, sc.deleteRemoteFiles(remoteDeletions, remotePath, succeeded))
+	}
+	for _, relativePath := range localDeletions {
+		localPath := filepath.Join(localDir, filepath.FromSlash(relativePath))
+		log.Info("Deleting", localPath)
+		if err := os.Remove(localPath); err != nil {
+			log.Error(errorutils.CheckError(err))
+			sc.result.SetFailCount(sc.result.FailCount() + 1)
+			continue
+		}
+		succeeded[relativePath] = true
+		sc.result.SetSuccessCount(sc.result.SuccessCount() + 1)
+	}
+	for _, err := range errs {
+		if err != nil {
+			return succeeded, err
+		}
+	}
+	return succeeded, nil
+}
+
+// Deletes the files from Artifactory one by one, the same way the delete command of this package does.
+func (sc *SyncCommand) deleteRemoteFiles(relativePaths []string, remotePath string, succeeded map[string]bool) error {
+	deleteCommand := &remoteCommand{serverDetails: sc.serverDetails, threads: sc.threads, retries: sc.retries,
+		retryWaitTimeMilliSecs: sc.retryWaitTimeMilliSecs, result: new(commandsutils.Result)}
+	servicesManager, err := deleteCommand.createOperationsServiceManager()
+	if err != nil {
+		return err
+	}
+	var mutex sync.Mutex
+	err = deleteCommand.runOperations(func(addOperation addOperationFunc) error {
+		for _, relativePath := range relativePaths {
+			relativePath := relativePath
+			itemPath := path.Join(remotePath, relativePath)
+			addOperation(itemPath, "", func(logMsgPrefix string) (bool, error) {
+				log.Info(logMsgPrefix+"Deleting", itemPath)
+				shouldRetry, err := deleteArtifact(servicesManager, itemPath)
+				if err == nil {
+					mutex.Lock()
+					succeeded[relativePath] = true
+					mutex.Unlock()
+				}
+				return shouldRetry, err
+			})
+		}
+		return nil
+	})
+	sc.addResult(deleteCommand.Result())
+	return err
+}
+
+func (sc *SyncCommand) addResult(result *commandsutils.Result) {
+	sc.result.SetSuccessCount(sc.result.SuccessCount() + result.SuccessCount())
+	sc.result.SetFailCount(sc.result.FailCount() + result.FailCount())
+}
+
+// Marks the plan items of the entries which were transferred successfully.
+func collectDoneEntries(entries []*Entry, getRemotePath func(entry *Entry) string, remotePath string, succeeded map[string]bool) {
+	for _, entry := range entries {
+		if entry.Status == Done {
+			succeeded[strings.TrimPrefix(getRemotePath(entry), remotePath+"/")] = true
+		}
+	}
+}
+
+// Collects the files under the local directory, mapped by their path relative to it.
+func collectLocalFiles(localDir string) (map[string]SyncFile, error) {
+	files := make(map[string]SyncFile)
+	err := filepath.Walk(localDir, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		relativePath, err := filepath.Rel(localDir, filePath)
+		if err != nil {
+			return err
+		}
+		checksum, err := cache.CalcSha256(filePath)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(relativePath)] = SyncFile{Sha256: checksum, Modified: info.ModTime()}
+		return nil
+	})
+	return files, errorutils.CheckError(err)
+}
+
+// Collects the files under the repository path, mapped by their path relative to it.
+func collectRemoteFiles(servicesManager artifactory.ArtifactoryServicesManager, remotePath string) (map[string]SyncFile, error) {
+	searchParams, err := utils.GetSearchParams(spec.NewBuilder().Pattern(remotePath + "/").Recursive(true).BuildSpec().Get(0))
+	if err != nil {
+		return nil, err
+	}
+	reader, err := servicesManager.SearchFiles(searchParams)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	files := make(map[string]SyncFile)
+	for item := new(servicesutils.ResultItem); reader.NextRecord(item) == nil; item = new(servicesutils.ResultItem) {
+		if item.Type == "folder" {
+			continue
+		}
+		modified, err := time.Parse(time.RFC3339, item.Modified)
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		files[strings.TrimPrefix(item.GetItemRelativePath(), remotePath+"/")] = SyncFile{Sha256: item.Sha256, Modified: modified}
+	}
+	return files, reader.GetError()
+}
+
+// Plans the changes which bring both sides in sync, sorted by path.
+// The state holds the checksums of the files as they were in the last sync. A file which matches the state on one side
+// was changed on the other side only, so the change is propagated. A file which changed on both sides is a conflict,
+// which is resolved by the conflict rule.
+func CreateSyncPlan(localFiles, remoteFiles map[string]SyncFile, state map[string]string, conflictRule ConflictRule) []SyncPlanItem {
+	paths := make(map[string]bool)
+	for filePath := range localFiles {
+		paths[filePath] = true
+	}
+	for filePath := range remoteFiles {
+		paths[filePath] = true
+	}
+	var plan []SyncPlanItem
+	for filePath := range paths {
+		localFile, inLocal := localFiles[filePath]
+		remoteFile, inRemote := remoteFiles[filePath]
+		stateSha256, inState := state[filePath]
+		var action SyncAction
+		var reason string
+		switch {
+		case inLocal && inRemote:
+			switch {
+			case localFile.Sha256 == remoteFile.Sha256:
+				continue
+			case inState && stateSha256 == remoteFile.Sha256:
+				action, reason = SyncUpload, "modified locally"
+			case inState && stateSha256 == localFile.Sha256:
+				action, reason = SyncDownload, "modified in Artifactory"
+			default:
+				action = resolveConflict(conflictRule, localFile.Modified.After(remoteFile.Modified))
+				reason = "modified on both sides, " + string(conflictRule)
+			}
+		case inLocal:
+			switch {
+			case !inState:
+				action, reason = SyncUpload, "added locally"
+			case stateSha256 == localFile.Sha256:
+				action, reason = SyncDeleteLocal, "deleted from Artifactory"
+			case resolveConflict(conflictRule, true) == SyncUpload:
+				action, reason = SyncUpload, "modified locally and deleted from Artifactory, "+string(conflictRule)
+			default:
+				action, reason = SyncDeleteLocal, "modified locally and deleted from Artifactory, "+string(conflictRule)
+			}
+		default:
+			switch {
+			case !inState:
+				action, reason = SyncDownload, "added to Artifactory"
+			case stateSha256 == remoteFile.Sha256:
+				action, reason = SyncDeleteRemote, "deleted locally"
+			case resolveConflict(conflictRule, false) == SyncDownload:
+				action, reason = SyncDownload, "modified in Artifactory and deleted locally, "+string(conflictRule)
+			default:
+				action, reason = SyncDeleteRemote, "modified in Artifactory and deleted locally, "+string(conflictRule)
+			}
+		}
+		plan = append(plan, SyncPlanItem{Path: filePath, Action: action, Reason: reason})
+	}
+	sort.Slice(plan, func(i, j int) bool {
+		return plan[i].Path < plan[j].Path
+	})
+	return plan
+}
+
+// Returns the action which applies the winning side of a conflict.
+// With newer-wins, a modification always wins over a deletion, since the time of the deletion is unknown.
+func resolveConflict(conflictRule ConflictRule, localIsNewer bool) SyncAction {
+	switch conflictRule {
+	case LocalWins:
+		return SyncUpload
+	case RemoteWins:
+		return SyncDownload
+	default:
+		if localIsNewer {
+			return SyncUpload
+		}
+		return SyncDownload
+	}
+}
+
+func hasDeletions(plan []SyncPlanItem) bool {
+	for _, item := range plan {
+		if item.Action == SyncDeleteRemote || item.Action == SyncDeleteLocal {
+			return true
+		}
+	}
+	return false
+}
+
+// Updates the state with the files which are in sync after the plan was applied.
+// Files whose plan item failed keep their previous state, so that the next sync plans them again.
+func updateSyncState(state map[string]string, localFiles, remoteFiles map[string]SyncFile, plan []SyncPlanItem, succeeded map[string]bool) {
+	for filePath, localFile := range localFiles {
+		if remoteFile, ok := remoteFiles[filePath]; ok && remoteFile.Sha256 == localFile.Sha256 {
+			state[filePath] = localFile.Sha256
+		}
+	}
+	for _, item := range plan {
+		if !succeeded[item.Path] {
+			continue
+		}
+		switch item.Action {
+		case SyncUpload:
+			state[item.Path] = localFiles[item.Path].Sha256
+		case SyncDownload:
+			state[item.Path] = remoteFiles[item.Path].Sha256
+		default:
+			delete(state, item.Path)
+		}
+	}
+	// Files which no longer exist on either side are no longer tracked.
+	for filePath := range state {
+		if _, inLocal := localFiles[filePath]; !inLocal {
+			if _, inRemote := remoteFiles[filePath]; !inRemote {
+				delete(state, filePath)
+			}
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package transfer
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	coretests "github.com/jfrog/jfrog-cli-core/v2/utils/tests"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateSyncPlan(t *testing.T) {
+	older := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	newer := older.Add(time.Hour)
+	localFiles := map[string]SyncFile{
+		"same.txt":            {Sha256: "1"},
+		"new-local.txt":       {Sha256: "2"},
+		"modified-local.txt":  {Sha256: "3-local"},
+		"modified-remote.txt": {Sha256: "4"},
+		"conflict.txt":        {Sha256: "5-local", Modified: older},
+		"deleted-remote.txt":  {Sha256: "6"},
+	}
+	remoteFiles := map[string]SyncFile{
+		"same.txt":            {Sha256: "1"},
+		"new-remote.txt":      {Sha256: "7"},
+		"modified-local.txt":  {Sha256: "3"},
+		"modified-remote.txt": {Sha256: "4-remote"},
+		"conflict.txt":        {Sha256: "5-remote", Modified: newer},
+		"deleted-local.txt":   {Sha256: "8"},
+	}
+	state := map[string]string{
+		"same.txt":            "1",
+		"modified-local.txt":  "3",
+		"modified-remote.txt": "4",
+		"conflict.txt":        "5",
+		"deleted-remote.txt":  "6",
+		"deleted-local.txt":   "8",
+	}
+
+	plan := CreateSyncPlan(localFiles, remoteFiles, state, NewerWins)
+	assert.Equal(t, map[string]SyncAction{
+		"conflict.txt":        SyncDownload,
+		"deleted-local.txt":   SyncDeleteRemote,
+		"deleted-remote.txt":  SyncDeleteLocal,
+		"modified-local.txt":  SyncUpload,
+		"modified-remote.txt": SyncDownload,
+		"new-local.txt":       SyncUpload,
+		"new-remote.txt":      SyncDownload,
+	}, getPlanActions(plan))
+	assert.Equal(t, "conflict.txt", plan[0].Path)
+	assert.Equal(t, "new-remote.txt", plan[len(plan)-1].Path)
+
+	plan = CreateSyncPlan(localFiles, remoteFiles, state, LocalWins)
+	assert.Equal(t, SyncUpload, getPlanActions(plan)["conflict.txt"])
+
+	// Without a state, nothing is deleted and the conflicts are resolved by the rule.
+	plan = CreateSyncPlan(localFiles, remoteFiles, nil, RemoteWins)
+	actions := getPlanActions(plan)
+	assert.Equal(t, SyncUpload, actions["deleted-remote.txt"])
+	assert.Equal(t, SyncDownload, actions["deleted-local.txt"])
+	assert.Equal(t, SyncDownload, actions["modified-local.txt"])
+	assert.False(t, hasDeletions(plan))
+}
+
+func TestCreateSyncPlanModifiedAndDeleted(t *testing.T) {
+	localFiles := map[string]SyncFile{"a.txt": {Sha256: "1-local"}}
+	state := map[string]string{"a.txt": "1"}
+	assert.Equal(t, SyncUpload, CreateSyncPlan(localFiles, nil, state, NewerWins)[0].Action)
+	assert.Equal(t, SyncDeleteLocal, CreateSyncPlan(localFiles, nil, state, RemoteWins)[0].Action)
+	assert.Equal(t, SyncDeleteRemote, CreateSyncPlan(nil, localFiles, state, LocalWins)[0].Action)
+}
+
+func TestUpdateSyncState(t *testing.T) {
+	localFiles := map[string]SyncFile{"same.txt": {Sha256: "1"}, "uploaded.txt": {Sha256: "2"}, "failed.txt": {Sha256: "3"}, "deleted.txt": {Sha256: "4"}}
+	remoteFiles := map[string]SyncFile{"same.txt": {Sha256: "1"}}
+	state := map[string]string{"failed.txt": "3", "deleted.txt": "4", "gone.txt": "5"}
+	plan := []SyncPlanItem{
+		{Path: "deleted.txt", Action: SyncDeleteLocal},
+		{Path: "failed.txt", Action: SyncDeleteLocal},
+		{Path: "uploaded.txt", Action: SyncUpload},
+	}
+	updateSyncState(state, localFiles, remoteFiles, plan, map[string]bool{"deleted.txt": true, "uploaded.txt": true})
+	assert.Equal(t, map[string]string{"same.txt": "1", "uploaded.txt": "2", "failed.txt": "3"}, state)
+}
+
+func TestSyncStateSaveAndLoad(t *testing.T) {
+	tempDirPath, createTempDirCallback := coretests.CreateTempDirWithCallbackAndAssert(t)
+	defer createTempDirCallback()
+	statePath := filepath.Join(tempDirPath, syncStatesDirName, "state.json")
+	state, err := loadSyncState(statePath)
+	assert.NoError(t, err)
+	assert.Empty(t, state.Files)
+
+	state.Files["a/b.txt"] = "1"
+	assert.NoError(t, state.save())
+	loaded, err := loadSyncState(statePath)
+	assert.NoError(t, err)
+	assert.Equal(t, state, loaded)
+}
+
+func TestCollectLocalFiles(t *testing.T) {
+	tempDirPath, createTempDirCallback := coretests.CreateTempDirWithCallbackAndAssert(t)
+	defer createTempDirCallback()
+	assert.NoError(t, os.MkdirAll(filepath.Join(tempDirPath, "a"), 0777))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(tempDirPath, "a", "b.txt"), []byte("content"), 0644))
+
+	files, err := collectLocalFiles(tempDirPath)
+	assert.NoError(t, err)
+	if assert.Len(t, files, 1) {
+		assert.Equal(t, "ed7002b439e9ac845f22357d822bac1444730fbdb6016d3ec9432297b9ec9f73", files["a/b.txt"].Sha256)
+	}
+}
+
+func getPlanActions(plan []SyncPlanItem) map[string]SyncAction {
+	actions := make(map[string]SyncAction)
+	for _, item := range plan {
+		actions[item.Path] = item.Action
+	}
+	return actions
+}
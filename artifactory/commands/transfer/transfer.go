@@ -0,0 +1,300 @@
+package transfer
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	commandsutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	ioUtils "github.com/jfrog/jfrog-client-go/utils/io"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	// The number of files transferred between two consecutive journal saves.
+	journalBatchSize = 1000
+	// When events are reported, smaller batches are used, so that the success of each file is reported shortly after it's transferred.
+	eventsBatchSize = 100
+)
+
+// Transfers the files of a journal. Implemented by the upload and download commands.
+type transferHandler interface {
+	// Transfers a batch of journal entries and returns the results of the transfer.
+	runBatch(entries []*Entry, progress ioUtils.ProgressMgr) (*commandsutils.Result, error)
+	// Returns the journal key of a transferred file, as reported in the transfer results.
+	resultKey(transferDetails *clientutils.FileTransferDetails) string
+	// Returns the journal key of an entry.
+	entryKey(entry *Entry) string
+	// Returns the path of the entry in Artifactory.
+	remotePath(entry *Entry) string
+	// Converts a path reported to the progress manager to a path in Artifactory.
+	progressPath(path string) string
+}
+
+// The base of the upload and download commands of this package.
+// The files to transfer are collected first and recorded in a journal. The journal is then transferred in batches.
+// When resume is enabled, the journal is saved after each batch, so that an interrupted transfer can be continued.
+type transferCommand struct {
+	spec                   *spec.SpecFiles
+	specPath               string
+	serverDetails          *config.ServerDetails
+	retries                int
+	retryWaitTimeMilliSecs int
+	detailedSummary        bool
+	resume                 bool
+	events                 *EventsWriter
+	progress               ioUtils.ProgressMgr
+	result                 *commandsutils.Result
+}
+
+func (tc *transferCommand) SetSpec(spec *spec.SpecFiles) *transferCommand {
+	tc.spec = spec
+	return tc
+}
+
+// The path of the file spec the command was created from, if any. The journal is stored next to it.
+func (tc *transferCommand) SetSpecPath(specPath string) *transferCommand {
+	tc.specPath = specPath
+	return tc
+}
+
+func (tc *transferCommand) SetServerDetails(serverDetails *config.ServerDetails) *transferCommand {
+	tc.serverDetails = serverDetails
+	return tc
+}
+
+func (tc *transferCommand) SetRetries(retries int) *transferCommand {
+	tc.retries = retries
+	return tc
+}
+
+func (tc *transferCommand) SetRetryWaitMilliSecs(retryWaitMilliSecs int) *transferCommand {
+	tc.retryWaitTimeMilliSecs = retryWaitMilliSecs
+	return tc
+}
+
+func (tc *transferCommand) SetDetailedSummary(detailedSummary bool) *transferCommand {
+	tc.detailedSummary = detailedSummary
+	return tc
+}
+
+func (tc *transferCommand) SetResume(resume bool) *transferCommand {
+	tc.resume = resume
+	return tc
+}
+
+func (tc *transferCommand) SetEvents(events *EventsWriter) *transferCommand {
+	tc.events = events
+	return tc
+}
+
+func (tc *transferCommand) SetProgress(progress ioUtils.ProgressMgr) {
+	tc.progress = progress
+}
+
+func (tc *transferCommand) ServerDetails() (*config.ServerDetails, error) {
+	return tc.serverDetails, nil
+}
+
+func (tc *transferCommand) Result() *commandsutils.Result {
+	return tc.result
+}
+
+// Loads the journal of the command, or creates a new one using createEntries if there's no journal to resume.
+// When resume is disabled, the journal is kept in memory only.
+func (tc *transferCommand) loadOrCreateJournal(command string, createEntries func() ([]*Entry, error)) (journal *Journal, err error) {
+	if !tc.resume {
+		journal = NewJournal("", command, "")
+		journal.Entries, err = createEntries()
+		return
+	}
+	fingerprint, err := CreateFingerprint(command, tc.serverDetails.ArtifactoryUrl, tc.spec)
+	if err != nil {
+		return
+	}
+	journalPath, err := GetJournalPath(tc.specPath, fingerprint)
+	if err != nil {
+		return
+	}
+	journal, err = LoadJournal(journalPath)
+	if err != nil {
+		return
+	}
+	if journal != nil {
+		if journal.Fingerprint == fingerprint {
+			done, _, _ := journal.Counts()
+			log.Info(fmt.Sprintf("Resuming the transfer using the journal at %s. %d out of %d files were already transferred.", journalPath, done, len(journal.Entries)))
+			return
+		}
+		log.Warn("The journal at " + journalPath + " was created for a different command. Starting the transfer from scratch.")
+	}
+	journal = NewJournal(journalPath, command, fingerprint)
+	journal.Entries, err = createEntries()
+	if err != nil {
+		return
+	}
+	log.Info(fmt.Sprintf("Created a transfer journal for %d files at %s.", len(journal.Entries), journalPath))
+	return journal, journal.Save()
+}
+
+// Transfers the remaining entries of the journal in batches, saving the journal after each batch.
+// The journal is removed once all of its entries are done.
+func (tc *transferCommand) runJournal(journal *Journal, handler transferHandler) (err error) {
+	var summaryWriter *content.ContentWriter
+	if tc.detailedSummary {
+		summaryWriter, err = content.NewContentWriter(content.DefaultKey, true, false)
+		if err != nil {
+			return
+		}
+		defer func() {
+			e := summaryWriter.Close()
+			if err == nil {
+				err = e
+			}
+			if !summaryWriter.IsEmpty() {
+				tc.result.SetReader(content.NewContentReader(summaryWriter.GetFilePath(), content.DefaultKey))
+			}
+		}()
+	}
+	var progress ioUtils.ProgressMgr
+	if tc.progress != nil {
+		tc.progress.InitProgressReaders()
+		progress = &batchProgress{tc.progress}
+	}
+	var events *eventsProgress
+	if tc.events != nil {
+		events = newEventsProgress(progress, tc.events, journal.Entries, handler)
+		progress = events
+		for _, entry := range journal.Entries {
+			if entry.Status == Done {
+				tc.events.Skipped(entry.Source, entry.Target, "already transferred")
+			}
+		}
+	}
+
+	remaining := journal.Remaining()
+	batchSize := journalBatchSize
+	if tc.events != nil {
+		batchSize = eventsBatchSize
+	}
+	for start := 0; start < len(remaining); start += batchSize {
+		batch := remaining[start:minInt(start+batchSize, len(remaining))]
+		for _, entry := range batch {
+			entry.Status = Partial
+		}
+		if err = journal.Save(); err != nil {
+			return
+		}
+		batchResult, batchErr := handler.runBatch(batch, progress)
+		succeeded, e := tc.collectSucceeded(batchResult, handler, summaryWriter)
+		for _, entry := range batch {
+			if succeeded[filepath.Clean(handler.entryKey(entry))] {
+				entry.Status = Done
+			} else {
+				entry.Status = Pending
+			}
+		}
+		if events != nil {
+			events.reportBatch(batch, batchErr)
+		}
+		if batchResult != nil {
+			tc.result.SetSuccessCount(tc.result.SuccessCount() + batchResult.SuccessCount())
+			tc.result.SetFailCount(tc.result.FailCount() + batchResult.FailCount())
+		}
+		if err = journal.Save(); err != nil {
+			return
+		}
+		if batchErr != nil {
+			err = batchErr
+			break
+		}
+		if e != nil {
+			err = e
+			break
+		}
+	}
+
+	if _, partial, pending := journal.Counts(); partial+pending > 0 {
+		if tc.resume {
+			log.Info(fmt.Sprintf("%d files were not transferred. Run the command again with --resume to continue the transfer.", partial+pending))
+		}
+		return
+	}
+	if tc.resume {
+		log.Debug("All files were transferred. Removing the journal at " + journal.Path())
+	}
+	if e := journal.Remove(); err == nil {
+		err = e
+	}
+	return
+}
+
+// Returns the keys of the files which were transferred successfully in the batch, and appends them to the detailed summary.
+func (tc *transferCommand) collectSucceeded(batchResult *commandsutils.Result, handler transferHandler, summaryWriter *content.ContentWriter) (map[string]bool, error) {
+	succeeded := make(map[string]bool)
+	if batchResult == nil || batchResult.Reader() == nil {
+		return succeeded, nil
+	}
+	reader := batchResult.Reader()
+	defer reader.Close()
+	for transferDetails := new(clientutils.FileTransferDetails); reader.NextRecord(transferDetails) == nil; transferDetails = new(clientutils.FileTransferDetails) {
+		succeeded[filepath.Clean(handler.resultKey(transferDetails))] = true
+		if summaryWriter != nil {
+			summaryWriter.Write(*transferDetails)
+		}
+	}
+	return succeeded, reader.GetError()
+}
+
+// Returns a result which includes the files transferred outside of the transfer client, in addition to the files of the result.
+// The result may be nil if the transfer client wasn't used.
+func appendToResult(result *commandsutils.Result, transferred []clientutils.FileTransferDetails, failed int) (*commandsutils.Result, error) {
+	mergedResult := new(commandsutils.Result)
+	writer, err := content.NewContentWriter(content.DefaultKey, true, false)
+	if err != nil {
+		return result, err
+	}
+	for _, transferDetails := range transferred {
+		writer.Write(transferDetails)
+	}
+	mergedResult.SetSuccessCount(len(transferred))
+	mergedResult.SetFailCount(failed)
+	if result != nil {
+		mergedResult.SetSuccessCount(mergedResult.SuccessCount() + result.SuccessCount())
+		mergedResult.SetFailCount(mergedResult.FailCount() + result.FailCount())
+		if reader := result.Reader(); reader != nil {
+			for transferDetails := new(clientutils.FileTransferDetails); reader.NextRecord(transferDetails) == nil; transferDetails = new(clientutils.FileTransferDetails) {
+				writer.Write(*transferDetails)
+			}
+			err = reader.GetError()
+			reader.Close()
+		}
+	}
+	if e := writer.Close(); err == nil {
+		err = e
+	}
+	mergedResult.SetReader(content.NewContentReader(writer.GetFilePath(), content.DefaultKey))
+	return mergedResult, err
+}
+
+// The commands running the batches initialize the progress bar on every run.
+// Since the progress bar should be initialized only once, batchProgress ignores those calls.
+type batchProgress struct {
+	ioUtils.ProgressMgr
+}
+
+func (bp *batchProgress) InitProgressReaders() {}
+
+// The error reported for files which failed to transfer, when the batch itself didn't fail.
+var errTransferFailed = errors.New("the transfer failed, see the log for more details")
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,217 @@
+package transfer
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	commandsutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	coretests "github.com/jfrog/jfrog-cli-core/v2/utils/tests"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	ioUtils "github.com/jfrog/jfrog-client-go/utils/io"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/stretchr/testify/assert"
+)
+
+// A download-like handler, which transfers the batches using the provided function.
+type testHandler struct {
+	transfer func(entries []*Entry, progress ioUtils.ProgressMgr) (*commandsutils.Result, error)
+}
+
+func (th *testHandler) runBatch(entries []*Entry, progress ioUtils.ProgressMgr) (*commandsutils.Result, error) {
+	return th.transfer(entries, progress)
+}
+
+func (th *testHandler) resultKey(transferDetails *clientutils.FileTransferDetails) string {
+	return transferDetails.TargetPath
+}
+
+func (th *testHandler) entryKey(entry *Entry) string {
+	return entry.Target
+}
+
+func (th *testHandler) remotePath(entry *Entry) string {
+	return entry.Source
+}
+
+func (th *testHandler) progressPath(path string) string {
+	return path
+}
+
+func TestRunJournal(t *testing.T) {
+	tempDirPath, createTempDirCallback := coretests.CreateTempDirWithCallbackAndAssert(t)
+	defer createTempDirCallback()
+	journalPath := filepath.Join(tempDirPath, "download.json"+journalSuffix)
+	journal := NewJournal(journalPath, "rt_download", "fingerprint")
+	for i := 0; i < journalBatchSize+10; i++ {
+		journal.Entries = append(journal.Entries, &Entry{Source: "repo/" + strconv.Itoa(i), Target: filepath.Join("dir", strconv.Itoa(i)), Status: Pending})
+	}
+	journal.Entries[0].Status = Done
+	failedTarget := journal.Entries[5].Target
+
+	// The first run fails to transfer a single file.
+	tc := &transferCommand{resume: true, result: new(commandsutils.Result)}
+	var batches int
+	err := tc.runJournal(journal, &testHandler{func(entries []*Entry, progress ioUtils.ProgressMgr) (*commandsutils.Result, error) {
+		batches++
+		return createBatchResult(t, entries, failedTarget), nil
+	}})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, batches)
+	assert.Equal(t, journalBatchSize+8, tc.Result().SuccessCount())
+	assert.Equal(t, 1, tc.Result().FailCount())
+	loaded, err := LoadJournal(journalPath)
+	assert.NoError(t, err)
+	remaining := loaded.Remaining()
+	if assert.Len(t, remaining, 1) {
+		assert.Equal(t, failedTarget, remaining[0].Target)
+		assert.Equal(t, Pending, remaining[0].Status)
+	}
+
+	// The second run transfers only the remaining file and removes the journal.
+	tc = &transferCommand{resume: true, result: new(commandsutils.Result)}
+	err = tc.runJournal(loaded, &testHandler{func(entries []*Entry, progress ioUtils.ProgressMgr) (*commandsutils.Result, error) {
+		assert.Len(t, entries, 1)
+		return createBatchResult(t, entries, ""), nil
+	}})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, tc.Result().SuccessCount())
+	_, err = os.Stat(journalPath)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestRunJournalBatchError(t *testing.T) {
+	tempDirPath, createTempDirCallback := coretests.CreateTempDirWithCallbackAndAssert(t)
+	defer createTempDirCallback()
+	journalPath := filepath.Join(tempDirPath, "upload.json"+journalSuffix)
+	journal := NewJournal(journalPath, "rt_upload", "fingerprint")
+	journal.Entries = []*Entry{{Source: "a.bin", Target: "repo/a.bin", Status: Pending}}
+	tc := &transferCommand{resume: true, result: new(commandsutils.Result)}
+	err := tc.runJournal(journal, &testHandler{func(entries []*Entry, progress ioUtils.ProgressMgr) (*commandsutils.Result, error) {
+		return nil, errors.New("connection refused")
+	}})
+	assert.EqualError(t, err, "connection refused")
+	loaded, err := LoadJournal(journalPath)
+	assert.NoError(t, err)
+	assert.Equal(t, Pending, loaded.Entries[0].Status)
+}
+
+func TestRunJournalEvents(t *testing.T) {
+	journal := NewJournal("", "rt_download", "")
+	journal.Entries = []*Entry{
+		{Source: "repo/done", Target: "done", Status: Done},
+		{Source: "repo/retried", Target: "retried", Size: 3, Status: Pending},
+		{Source: "repo/failed", Target: "failed", Status: Pending},
+		{Source: "repo/checksum", Target: "checksum", Status: Pending},
+	}
+	var lines []string
+	events := NewEventsWriter("rt_download")
+	events.output = func(line string) { lines = append(lines, line) }
+	tc := &transferCommand{events: events, result: new(commandsutils.Result)}
+	err := tc.runJournal(journal, &testHandler{func(entries []*Entry, progress ioUtils.ProgressMgr) (*commandsutils.Result, error) {
+		// Two attempts to transfer the first file, and a single attempt for the second one.
+		// The last file isn't transferred through a progress reader, as if its checksum was deployed.
+		for _, path := range []string{"repo/retried", "repo/retried", "repo/failed"} {
+			progress.RemoveProgress(progress.NewProgressReader(3, "Downloading", path).GetId())
+		}
+		return createBatchResult(t, entries, "failed"), nil
+	}})
+	assert.NoError(t, err)
+
+	var actual []Event
+	for _, line := range lines {
+		event := Event{}
+		assert.NoError(t, json.Unmarshal([]byte(line), &event))
+		assert.Equal(t, "rt_download", event.Command)
+		assert.NotEmpty(t, event.Time)
+		event.Time, event.Command = "", ""
+		actual = append(actual, event)
+	}
+	expected := []Event{
+		{Type: EventSkipped, Source: "repo/done", Target: "done", Reason: "already transferred"},
+		{Type: EventStarted, Source: "repo/retried", Target: "retried", Size: 3, Attempt: 1},
+		{Type: EventRetried, Source: "repo/retried", Target: "retried", Attempt: 2},
+		{Type: EventStarted, Source: "repo/failed", Target: "failed", Attempt: 1},
+		{Type: EventSucceeded, Source: "repo/retried", Target: "retried", Size: 3},
+		{Type: EventFailed, Source: "repo/failed", Target: "failed", Error: errTransferFailed.Error()},
+		{Type: EventStarted, Source: "repo/checksum", Target: "checksum", Attempt: 1},
+		{Type: EventSucceeded, Source: "repo/checksum", Target: "checksum"},
+	}
+	assert.Equal(t, expected, actual)
+}
+
+func TestRunJournalEventsConfirmedByBatch(t *testing.T) {
+	journal := NewJournal("", "rt_download", "")
+	journal.Entries = []*Entry{
+		{Source: "repo/a", Target: "a", Size: 3, Status: Pending},
+		{Source: "repo/b", Target: "b", Size: 3, Status: Pending},
+		{Source: "repo/c", Target: "c", Size: 3, Status: Pending},
+	}
+	var types []string
+	events := NewEventsWriter("rt_download")
+	events.output = func(line string) {
+		event := Event{}
+		assert.NoError(t, json.Unmarshal([]byte(line), &event))
+		types = append(types, event.Source+" "+string(event.Type))
+	}
+	tc := &transferCommand{events: events, result: new(commandsutils.Result)}
+	err := tc.runJournal(journal, &testHandler{func(entries []*Entry, progress ioUtils.ProgressMgr) (*commandsutils.Result, error) {
+		// The second file is transferred twice, and the last one is transferred but rejected.
+		for _, path := range []string{"repo/a", "repo/b", "repo/b", "repo/c"} {
+			reader := progress.NewProgressReader(3, "Downloading", path)
+			_, err := ioutil.ReadAll(reader.ActionWithProgress(strings.NewReader("abc")))
+			assert.NoError(t, err)
+			progress.RemoveProgress(reader.GetId())
+		}
+		return createBatchResult(t, entries, "c"), nil
+	}})
+	assert.NoError(t, err)
+	// The files are reported as succeeded only once the batch result confirms their transfer.
+	expected := []string{
+		"repo/a started",
+		"repo/b started",
+		"repo/b retried",
+		"repo/c started",
+		"repo/a succeeded",
+		"repo/b succeeded",
+		"repo/c failed",
+	}
+	assert.Equal(t, expected, types)
+}
+
+func TestEventsSummary(t *testing.T) {
+	var lines []string
+	events := NewEventsWriter("rt_delete")
+	events.output = func(line string) { lines = append(lines, line) }
+	events.Summary(2, 1, false, errors.New("failed deleting 1 artifacts"))
+	if assert.Len(t, lines, 1) {
+		event := make(map[string]interface{})
+		assert.NoError(t, json.Unmarshal([]byte(lines[0]), &event))
+		assert.Equal(t, "summary", event["event"])
+		assert.Equal(t, "failed deleting 1 artifacts", event["error"])
+		assert.Equal(t, map[string]interface{}{"status": "failure", "totals": map[string]interface{}{"success": 2.0, "failure": 1.0}}, event["summary"])
+	}
+}
+
+// Returns a result which includes all the entries, except for the one with the failed target.
+func createBatchResult(t *testing.T, entries []*Entry, failedTarget string) *commandsutils.Result {
+	result := new(commandsutils.Result)
+	writer, err := content.NewContentWriter(content.DefaultKey, true, false)
+	assert.NoError(t, err)
+	for _, entry := range entries {
+		if entry.Target == failedTarget {
+			result.SetFailCount(result.FailCount() + 1)
+			continue
+		}
+		writer.Write(clientutils.FileTransferDetails{SourcePath: entry.Source, TargetPath: entry.Target})
+		result.SetSuccessCount(result.SuccessCount() + 1)
+	}
+	assert.NoError(t, writer.Close())
+	result.SetReader(content.NewContentReader(writer.GetFilePath(), content.DefaultKey))
+	return result
+}
@@ -0,0 +1,336 @@
+package transfer
+
+import (
+	"encoding/json"
+	"net/http"
+	"path"
+	"strings"
+
+	commandsutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	// The repository in which Artifactory keeps the deleted artifacts.
+	// A deleted artifact is kept under its original path, prefixed with the name of its repository.
+	TrashRepo = "auto-trashcan"
+
+	trashDeletedProp   = "trash.deleted"
+	trashDeletedByProp = "trash.deletedBy"
+)
+
+// An artifact in the trash can, as printed by the trash list command.
+type TrashItem struct {
+	Path      string `json:"path,omitempty"`
+	Size      int64  `json:"size"`
+	Deleted   string `json:"deleted,omitempty"`
+	DeletedBy string `json:"deletedBy,omitempty"`
+}
+
+// Returns a copy of the spec, in which the patterns and exclusions point at the original paths of the artifacts in the trash can.
+// Specs with AQL queries aren't supported, since the queries can't be converted.
+func toTrashSpec(original *spec.SpecFiles) (*spec.SpecFiles, error) {
+	trashSpec := new(spec.SpecFiles)
+	for i := range original.Files {
+		file := original.Files[i]
+		if file.Aql.ItemsFind != "" {
+			return nil, errorutils.CheckErrorf("the trash commands don't support file specs with AQL queries")
+		}
+		file.Pattern = toTrashPath(file.Pattern)
+		file.Exclusions = nil
+		for _, exclusion := range original.Files[i].Exclusions {
+			file.Exclusions = append(file.Exclusions, toTrashPath(exclusion))
+		}
+		if file.Pattern == "" {
+			// Artifacts of a build or a bundle are searched in all repositories, unless a pattern is set.
+			file.Pattern = TrashRepo + "/*"
+		}
+		trashSpec.Files = append(trashSpec.Files, file)
+	}
+	return trashSpec, nil
+}
+
+func toTrashPath(originalPath string) string {
+	if originalPath == "" {
+		return ""
+	}
+	return TrashRepo + "/" + strings.TrimPrefix(originalPath, "/")
+}
+
+// Returns the path from which an artifact in the trash can was deleted.
+func getOriginalPath(item *servicesutils.ResultItem) string {
+	return strings.TrimPrefix(item.GetItemRelativePath(), TrashRepo+"/")
+}
+
+// Searches the artifacts in the trash can which were deleted from paths matching the spec, and calls handleItem with each of them.
+func searchTrash(serverDetails *config.ServerDetails, originalSpec *spec.SpecFiles, retries, retryWaitTimeMilliSecs int, handleItem func(item *servicesutils.ResultItem) error) error {
+	trashSpec, err := toTrashSpec(originalSpec)
+	if err != nil {
+		return err
+	}
+	servicesManager, err := utils.CreateServiceManager(serverDetails, retries, retryWaitTimeMilliSecs, false)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < len(trashSpec.Files); i++ {
+		searchParams, err := utils.GetSearchParams(trashSpec.Get(i))
+		if err != nil {
+			return err
+		}
+		reader, err := servicesManager.SearchFiles(searchParams)
+		if err != nil {
+			return err
+		}
+		for item := new(servicesutils.ResultItem); reader.NextRecord(item) == nil; item = new(servicesutils.ResultItem) {
+			if err = handleItem(item); err != nil {
+				break
+			}
+		}
+		if err == nil {
+			err = reader.GetError()
+		}
+		reader.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Lists the artifacts in the trash can, which were deleted from paths matching a file spec.
+type TrashListCommand struct {
+	spec                   *spec.SpecFiles
+	serverDetails          *config.ServerDetails
+	retries                int
+	retryWaitTimeMilliSecs int
+	count                  int
+}
+
+func NewTrashListCommand() *TrashListCommand {
+	return &TrashListCommand{}
+}
+
+// The spec of the command matches the original paths of the artifacts, before they were deleted.
+func (tlc *TrashListCommand) SetSpec(spec *spec.SpecFiles) *TrashListCommand {
+	tlc.spec = spec
+	return tlc
+}
+
+func (tlc *TrashListCommand) SetServerDetails(serverDetails *config.ServerDetails) *TrashListCommand {
+	tlc.serverDetails = serverDetails
+	return tlc
+}
+
+func (tlc *TrashListCommand) SetRetries(retries int) *TrashListCommand {
+	tlc.retries = retries
+	return tlc
+}
+
+func (tlc *TrashListCommand) SetRetryWaitMilliSecs(retryWaitMilliSecs int) *TrashListCommand {
+	tlc.retryWaitTimeMilliSecs = retryWaitMilliSecs
+	return tlc
+}
+
+func (tlc *TrashListCommand) ServerDetails() (*config.ServerDetails, error) {
+	return tlc.serverDetails, nil
+}
+
+func (tlc *TrashListCommand) CommandName() string {
+	return "rt_trash_list"
+}
+
+// Returns the number of listed artifacts.
+func (tlc *TrashListCommand) Count() int {
+	return tlc.count
+}
+
+// Prints the artifacts as a JSON array. Each artifact is printed as soon as it's found.
+func (tlc *TrashListCommand) Run() error {
+	var pending string
+	err := searchTrash(tlc.serverDetails, tlc.spec, tlc.retries, tlc.retryWaitTimeMilliSecs, func(item *servicesutils.ResultItem) error {
+		data, err := json.MarshalIndent(toTrashItem(item), "  ", "  ")
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		if tlc.count == 0 {
+			log.Output("[")
+		} else {
+			log.Output(pending + ",")
+		}
+		tlc.count++
+		pending = "  " + string(data)
+		return nil
+	})
+	if tlc.count == 0 {
+		log.Output("[]")
+	} else {
+		log.Output(pending)
+		log.Output("]")
+	}
+	return err
+}
+
+func toTrashItem(item *servicesutils.ResultItem) TrashItem {
+	trashItem := TrashItem{Path: getOriginalPath(item), Size: item.Size}
+	for _, property := range item.Properties {
+		switch property.Key {
+		case trashDeletedProp:
+			trashItem.Deleted = property.Value
+		case trashDeletedByProp:
+			trashItem.DeletedBy = property.Value
+		}
+	}
+	return trashItem
+}
+
+// Restores the artifacts in the trash can to the paths they were deleted from, one artifact at a time.
+type TrashRestoreCommand struct {
+	remoteCommand
+}
+
+func NewTrashRestoreCommand() *TrashRestoreCommand {
+	return &TrashRestoreCommand{remoteCommand: remoteCommand{progressLabel: "Restoring", result: new(commandsutils.Result)}}
+}
+
+func (trc *TrashRestoreCommand) CommandName() string {
+	return "rt_trash_restore"
+}
+
+func (trc *TrashRestoreCommand) Run() error {
+	servicesManager, err := trc.createOperationsServiceManager()
+	if err != nil {
+		return err
+	}
+	return trc.runOperations(func(addOperation addOperationFunc) error {
+		return searchTrash(trc.serverDetails, trc.spec, trc.retries, trc.retryWaitTimeMilliSecs, func(item *servicesutils.ResultItem) error {
+			trashPath := item.GetItemRelativePath()
+			originalPath := getOriginalPath(item)
+			addOperation(trashPath, originalPath, func(logMsgPrefix string) (bool, error) {
+				if trc.dryRun {
+					log.Info(logMsgPrefix+"[Dry run] Restoring", originalPath)
+					return false, nil
+				}
+				log.Info(logMsgPrefix+"Restoring", originalPath)
+				return restoreFromTrash(servicesManager, originalPath)
+			})
+			return nil
+		})
+	})
+}
+
+// Restores a single artifact from the trash can to its original path.
+func restoreFromTrash(servicesManager artifactory.ArtifactoryServicesManager, originalPath string) (bool, error) {
+	serviceDetails := servicesManager.GetConfig().GetServiceDetails()
+	requestFullUrl, err := servicesutils.BuildArtifactoryUrl(serviceDetails.GetUrl(), path.Join("api", "trash", "restore", originalPath), map[string]string{"to": originalPath})
+	if err != nil {
+		return false, err
+	}
+	httpClientsDetails := serviceDetails.CreateHttpClientDetails()
+	resp, body, err := servicesManager.Client().SendPost(requestFullUrl, nil, &httpClientsDetails)
+	return checkOperationResponse(resp, body, err, http.StatusOK, http.StatusAccepted)
+}
+
+// Permanently deletes artifacts from the trash can.
+// If a spec is set, only the artifacts deleted from paths matching the spec are deleted, one artifact at a time.
+// Otherwise, the entire trash can is emptied with a single request.
+type TrashEmptyCommand struct {
+	remoteCommand
+}
+
+func NewTrashEmptyCommand() *TrashEmptyCommand {
+	return &TrashEmptyCommand{remoteCommand: remoteCommand{progressLabel: "Deleting", result: new(commandsutils.Result)}}
+}
+
+func (tec *TrashEmptyCommand) CommandName() string {
+	return "rt_trash_empty"
+}
+
+func (tec *TrashEmptyCommand) Run() error {
+	servicesManager, err := tec.createOperationsServiceManager()
+	if err != nil {
+		return err
+	}
+	if tec.spec == nil {
+		if tec.dryRun {
+			log.Info("[Dry run] Emptying the trash can")
+			return nil
+		}
+		log.Info("Emptying the trash can...")
+		return emptyTrash(servicesManager)
+	}
+	reader, err := tec.collectItems()
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	if !tec.quiet {
+		allowDelete, err := utils.ConfirmDelete(reader)
+		if err != nil || !allowDelete {
+			return err
+		}
+	}
+	return tec.runOperations(func(addOperation addOperationFunc) error {
+		for item := new(servicesutils.ResultItem); reader.NextRecord(item) == nil; item = new(servicesutils.ResultItem) {
+			trashPath := item.GetItemRelativePath()
+			originalPath := getOriginalPath(item)
+			addOperation(trashPath, "", func(logMsgPrefix string) (bool, error) {
+				log.Info(logMsgPrefix+"Deleting", trashPath)
+				if tec.dryRun {
+					return false, nil
+				}
+				return deleteFromTrash(servicesManager, originalPath)
+			})
+		}
+		return reader.GetError()
+	})
+}
+
+// Collects the artifacts to delete, so that they can be confirmed before they are deleted.
+func (tec *TrashEmptyCommand) collectItems() (*content.ContentReader, error) {
+	writer, err := content.NewContentWriter(content.DefaultKey, true, false)
+	if err != nil {
+		return nil, err
+	}
+	err = searchTrash(tec.serverDetails, tec.spec, tec.retries, tec.retryWaitTimeMilliSecs, func(item *servicesutils.ResultItem) error {
+		writer.Write(*item)
+		return nil
+	})
+	if e := writer.Close(); err == nil {
+		err = e
+	}
+	if err != nil {
+		return nil, err
+	}
+	return content.NewContentReader(writer.GetFilePath(), content.DefaultKey), nil
+}
+
+func emptyTrash(servicesManager artifactory.ArtifactoryServicesManager) error {
+	serviceDetails := servicesManager.GetConfig().GetServiceDetails()
+	requestFullUrl, err := servicesutils.BuildArtifactoryUrl(serviceDetails.GetUrl(), "api/trash/empty", map[string]string{})
+	if err != nil {
+		return err
+	}
+	httpClientsDetails := serviceDetails.CreateHttpClientDetails()
+	resp, body, err := servicesManager.Client().SendPost(requestFullUrl, nil, &httpClientsDetails)
+	_, err = checkOperationResponse(resp, body, err, http.StatusOK, http.StatusNoContent)
+	return err
+}
+
+// Permanently deletes a single artifact from the trash can.
+func deleteFromTrash(servicesManager artifactory.ArtifactoryServicesManager, originalPath string) (bool, error) {
+	serviceDetails := servicesManager.GetConfig().GetServiceDetails()
+	requestFullUrl, err := servicesutils.BuildArtifactoryUrl(serviceDetails.GetUrl(), path.Join("api", "trash", "clean", originalPath), map[string]string{})
+	if err != nil {
+		return false, err
+	}
+	httpClientsDetails := serviceDetails.CreateHttpClientDetails()
+	resp, body, err := servicesManager.Client().SendDelete(requestFullUrl, nil, &httpClientsDetails)
+	return checkOperationResponse(resp, body, err, http.StatusOK, http.StatusNoContent)
+}
@@ -0,0 +1,45 @@
+package transfer
+
+import (
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToTrashSpec(t *testing.T) {
+	original := spec.NewBuilder().Pattern("repo/a/*.zip").Exclusions([]string{"repo/a/b/*", "/repo/c/*"}).Props("x=1").BuildSpec()
+	original.Files = append(original.Files, spec.File{Build: "build/1"})
+	trashSpec, err := toTrashSpec(original)
+	assert.NoError(t, err)
+	assert.Equal(t, "auto-trashcan/repo/a/*.zip", trashSpec.Get(0).Pattern)
+	assert.Equal(t, []string{"auto-trashcan/repo/a/b/*", "auto-trashcan/repo/c/*"}, trashSpec.Get(0).Exclusions)
+	assert.Equal(t, "x=1", trashSpec.Get(0).Props)
+	// Artifacts of a build are searched in the trash can only.
+	assert.Equal(t, "auto-trashcan/*", trashSpec.Get(1).Pattern)
+	assert.Equal(t, "build/1", trashSpec.Get(1).Build)
+	// The original spec isn't modified.
+	assert.Equal(t, "repo/a/*.zip", original.Get(0).Pattern)
+	assert.Equal(t, []string{"repo/a/b/*", "/repo/c/*"}, original.Get(0).Exclusions)
+
+	aqlSpec := &spec.SpecFiles{Files: []spec.File{{Aql: servicesutils.Aql{ItemsFind: `{"repo":"repo"}`}}}}
+	_, err = toTrashSpec(aqlSpec)
+	assert.Error(t, err)
+}
+
+func TestToTrashItem(t *testing.T) {
+	item := &servicesutils.ResultItem{
+		Repo: TrashRepo,
+		Path: "repo/a/b",
+		Name: "file.zip",
+		Size: 100,
+		Properties: []servicesutils.Property{
+			{Key: "trash.deleted", Value: "2021-06-01T10:00:00.000Z"},
+			{Key: "trash.deletedBy", Value: "admin"},
+			{Key: "build.name", Value: "build"},
+		},
+	}
+	expected := TrashItem{Path: "repo/a/b/file.zip", Size: 100, Deleted: "2021-06-01T10:00:00.000Z", DeletedBy: "admin"}
+	assert.Equal(t, expected, toTrashItem(item))
+}
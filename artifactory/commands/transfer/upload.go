@@ -0,0 +1,286 @@
+package transfer
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/generic"
+	commandsutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/ioutils"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	ioUtils "github.com/jfrog/jfrog-client-go/utils/io"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Uploads the files of a file spec in batches, while keeping track of each file in a journal.
+// This allows resuming an interrupted upload, and reporting the events of each file.
+type UploadCommand struct {
+	transferCommand
+	uploadConfiguration    *utils.UploadConfiguration
+	buildConfiguration     *utils.BuildConfiguration
+	multipartConfiguration *MultipartConfiguration
+	multipartUploader      *multipartUploader
+	multipartChecked       bool
+	journal                *Journal
+	// Protects the journal while the parts of large files are uploaded.
+	journalMutex sync.Mutex
+}
+
+func NewUploadCommand() *UploadCommand {
+	return &UploadCommand{transferCommand: transferCommand{result: new(commandsutils.Result)}}
+}
+
+func (ruc *UploadCommand) SetUploadConfiguration(uploadConfiguration *utils.UploadConfiguration) *UploadCommand {
+	ruc.uploadConfiguration = uploadConfiguration
+	return ruc
+}
+
+func (ruc *UploadCommand) SetBuildConfiguration(buildConfiguration *utils.BuildConfiguration) *UploadCommand {
+	ruc.buildConfiguration = buildConfiguration
+	return ruc
+}
+
+// Files which are large enough are uploaded in parts, which are uploaded concurrently and retried separately.
+// When resume is enabled, the uploaded parts are recorded in the journal, so that only the missing parts are uploaded when the upload is resumed.
+func (ruc *UploadCommand) SetMultipartConfiguration(multipartConfiguration *MultipartConfiguration) *UploadCommand {
+	ruc.multipartConfiguration = multipartConfiguration
+	return ruc
+}
+
+func (ruc *UploadCommand) CommandName() string {
+	return "rt_upload"
+}
+
+func (ruc *UploadCommand) Run() error {
+	journal, err := ruc.loadOrCreateJournal(ruc.CommandName(), ruc.createEntries)
+	if err != nil {
+		return err
+	}
+	ruc.journal = journal
+	return ruc.runJournal(journal, ruc)
+}
+
+// Collects the files to upload by running the upload in dry-run mode, once for each file spec group.
+func (ruc *UploadCommand) createEntries() ([]*Entry, error) {
+	log.Info("Collecting the files to upload...")
+	var entries []*Entry
+	for i, file := range ruc.spec.Files {
+		if file.Archive != "" {
+			return nil, errorutils.CheckErrorf("the --resume, --events and multipart upload options are not supported when uploading to an archive")
+		}
+		groupEntries, err := ruc.createGroupEntries(i, nil)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, groupEntries...)
+	}
+	return entries, nil
+}
+
+// Creates the entries of the files of a single file spec group. If sources isn't nil, only the entries of the files it includes are created.
+func (ruc *UploadCommand) createGroupEntries(specIndex int, sources map[string]bool) ([]*Entry, error) {
+	file := ruc.spec.Files[specIndex]
+	reader, err := runUploadDryRun(file, ruc.uploadConfiguration, ruc.serverDetails, ruc.retries, ruc.retryWaitTimeMilliSecs)
+	if err != nil || reader == nil {
+		return nil, err
+	}
+	defer reader.Close()
+	var entries []*Entry
+	props := clientutils.AddProps(file.TargetProps, file.Props)
+	for transferDetails := new(clientutils.FileTransferDetails); reader.NextRecord(transferDetails) == nil; transferDetails = new(clientutils.FileTransferDetails) {
+		if sources != nil && !sources[transferDetails.SourcePath] {
+			continue
+		}
+		target, err := ruc.getTargetPath(transferDetails.TargetPath)
+		if err != nil {
+			return nil, err
+		}
+		entry := &Entry{Source: transferDetails.SourcePath, Target: target, Sha256: transferDetails.Sha256, Props: props, SpecIndex: specIndex, Status: Pending}
+		if fileInfo, err := os.Stat(entry.Source); err == nil {
+			entry.Size = fileInfo.Size()
+		}
+		entries = append(entries, entry)
+	}
+	return entries, reader.GetError()
+}
+
+// Runs the upload of a file spec group in dry-run mode, and returns the reader of the files which would be uploaded.
+func runUploadDryRun(file spec.File, configuration *utils.UploadConfiguration, serverDetails *config.ServerDetails, retries, retryWaitMilliSecs int) (*content.ContentReader, error) {
+	dryRunCmd := generic.NewUploadCommand()
+	dryRunCmd.SetUploadConfiguration(configuration).SetSpec(&spec.SpecFiles{Files: []spec.File{file}}).
+		SetServerDetails(serverDetails).SetDryRun(true).SetDetailedSummary(true).SetRetries(retries).SetRetryWaitMilliSecs(retryWaitMilliSecs)
+	if err := dryRunCmd.Run(); err != nil {
+		return nil, err
+	}
+	return dryRunCmd.Result().Reader(), nil
+}
+
+func (ruc *UploadCommand) resultKey(transferDetails *clientutils.FileTransferDetails) string {
+	return transferDetails.SourcePath
+}
+
+func (ruc *UploadCommand) entryKey(entry *Entry) string {
+	return entry.Source
+}
+
+func (ruc *UploadCommand) remotePath(entry *Entry) string {
+	return entry.Target
+}
+
+// The upload progress is reported with the URL of the uploaded file, which may include its properties.
+func (ruc *UploadCommand) progressPath(path string) string {
+	if propsIndex := strings.Index(path[strings.LastIndex(path, "/")+1:], ";"); propsIndex >= 0 {
+		path = path[:strings.LastIndex(path, "/")+1+propsIndex]
+	}
+	targetPath, err := ruc.getTargetPath(path)
+	if err != nil {
+		return path
+	}
+	return targetPath
+}
+
+// The upload results include the full URL of the uploaded file. Returns its path in Artifactory.
+func (ruc *UploadCommand) getTargetPath(targetUrl string) (string, error) {
+	targetPath := strings.TrimPrefix(targetUrl, clientutils.AddTrailingSlashIfNeeded(ruc.serverDetails.ArtifactoryUrl))
+	targetPath, err := url.PathUnescape(targetPath)
+	return targetPath, errorutils.CheckError(err)
+}
+
+func (ruc *UploadCommand) runBatch(entries []*Entry, progress ioUtils.ProgressMgr) (*commandsutils.Result, error) {
+	var regular, multipart []*Entry
+	for _, entry := range entries {
+		if ruc.isMultipart(entry) {
+			multipart = append(multipart, entry)
+		} else {
+			regular = append(regular, entry)
+		}
+	}
+	if len(multipart) > 0 {
+		uploader, err := ruc.getMultipartUploader()
+		if err != nil {
+			return nil, err
+		}
+		if uploader == nil {
+			regular, multipart = entries, nil
+		}
+	}
+	var result *commandsutils.Result
+	var err error
+	if len(regular) > 0 {
+		result, err = ruc.upload(regular, progress)
+	}
+	if len(multipart) == 0 {
+		return result, err
+	}
+	uploaded, failed := ruc.uploadMultipart(multipart, progress)
+	mergedResult, e := appendToResult(result, uploaded, failed)
+	if err == nil {
+		err = e
+	}
+	return mergedResult, err
+}
+
+// Returns true if the file of the entry should be uploaded in parts.
+// Files which are extracted or attached to a build or a Debian package are always uploaded by the client, which handles those.
+func (ruc *UploadCommand) isMultipart(entry *Entry) bool {
+	if ruc.multipartConfiguration == nil || ruc.multipartConfiguration.MinSplitSize < 0 || entry.Size < ruc.multipartConfiguration.MinSplitSize {
+		return false
+	}
+	file := ruc.spec.Files[entry.SpecIndex]
+	if file.Explode == "true" || file.Symlinks == "true" || (ruc.uploadConfiguration != nil && ruc.uploadConfiguration.Deb != "") {
+		return false
+	}
+	if ruc.buildConfiguration != nil {
+		if buildName, err := ruc.buildConfiguration.GetBuildName(); err != nil || buildName != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns the uploader of the files which are uploaded in parts, or nil if Artifactory doesn't support the multipart upload.
+func (ruc *UploadCommand) getMultipartUploader() (*multipartUploader, error) {
+	if ruc.multipartChecked {
+		return ruc.multipartUploader, nil
+	}
+	servicesManager, err := utils.CreateServiceManager(ruc.serverDetails, ruc.retries, ruc.retryWaitTimeMilliSecs, false)
+	if err != nil {
+		return nil, err
+	}
+	ruc.multipartChecked = true
+	uploader := &multipartUploader{client: servicesManager.Client(), serviceDetails: servicesManager.GetConfig().GetServiceDetails(), configuration: ruc.multipartConfiguration,
+		retries: ruc.retries, retryWaitMilliSecs: ruc.retryWaitTimeMilliSecs, saveState: ruc.saveJournal, mutex: &ruc.journalMutex}
+	if !uploader.isSupported() {
+		log.Info("The multipart upload isn't supported by Artifactory. Large files are uploaded in a single request.")
+		return nil, nil
+	}
+	ruc.multipartUploader = uploader
+	return uploader, nil
+}
+
+func (ruc *UploadCommand) saveJournal() error {
+	if ruc.journal == nil {
+		return nil
+	}
+	return ruc.journal.Save()
+}
+
+// Uploads the files of the entries in parts, one file at a time. Returns the details of the uploaded files, and the number of files which failed.
+// Unless the upload can be resumed, the parts of a failed upload are removed from Artifactory.
+func (ruc *UploadCommand) uploadMultipart(entries []*Entry, progress ioUtils.ProgressMgr) (uploaded []clientutils.FileTransferDetails, failed int) {
+	ruc.multipartUploader.progress = progress
+	for _, entry := range entries {
+		log.Info(fmt.Sprintf("Uploading %s to %s in parts of %d MB.", entry.Source, entry.Target, ruc.multipartConfiguration.ChunkSize/SizeMiB))
+		err := ruc.multipartUploader.upload(entry)
+		if err == nil && entry.Props != "" {
+			err = ruc.multipartUploader.setProps(entry.Target, entry.Props)
+		}
+		if err != nil {
+			log.Error("Failed uploading " + entry.Source + ": " + err.Error())
+			if !ruc.resume {
+				ruc.multipartUploader.abort(entry)
+			}
+			failed++
+			continue
+		}
+		ruc.multipartUploader.setState(entry, nil)
+		uploaded = append(uploaded, clientutils.FileTransferDetails{SourcePath: entry.Source, TargetPath: clientutils.AddTrailingSlashIfNeeded(ruc.serverDetails.ArtifactoryUrl) + entry.Target, Sha256: entry.Sha256})
+	}
+	return
+}
+
+// Uploads the files of the entries using the upload command of the client.
+func (ruc *UploadCommand) upload(entries []*Entry, progress ioUtils.ProgressMgr) (*commandsutils.Result, error) {
+	batchSpec := &spec.SpecFiles{}
+	for _, entry := range entries {
+		file := ruc.spec.Files[entry.SpecIndex]
+		file.Pattern = entry.Source
+		if coreutils.IsWindows() {
+			file.Pattern = ioutils.DoubleWinPathSeparator(file.Pattern)
+		}
+		file.Target = entry.Target
+		file.Exclusions = nil
+		file.Recursive = "false"
+		file.Flat = "true"
+		file.Regexp = "false"
+		file.Ant = "false"
+		batchSpec.Files = append(batchSpec.Files, file)
+	}
+	uploadCmd := generic.NewUploadCommand()
+	uploadCmd.SetUploadConfiguration(ruc.uploadConfiguration).SetBuildConfiguration(ruc.buildConfiguration).SetSpec(batchSpec).
+		SetServerDetails(ruc.serverDetails).SetDetailedSummary(true).SetRetries(ruc.retries).SetRetryWaitMilliSecs(ruc.retryWaitTimeMilliSecs)
+	if progress != nil {
+		uploadCmd.SetProgress(progress)
+	}
+	err := uploadCmd.Run()
+	return uploadCmd.Result(), err
+}
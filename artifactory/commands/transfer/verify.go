@@ -0,0 +1,242 @@
+package transfer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli/utils/output"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Compares local files with the files in Artifactory, which are matched by a file spec like the files of the download command.
+// Reports the files which are missing locally, the local files which aren't in Artifactory, and the files whose checksums are different.
+type VerifyCommand struct {
+	serverDetails *config.ServerDetails
+	spec          *spec.SpecFiles
+	threads       int
+	report        *VerifyReport
+}
+
+type VerifyReport struct {
+	Matched    int             `json:"matched"`
+	Mismatched []*VerifiedFile `json:"mismatched"`
+	Missing    []*VerifiedFile `json:"missing"`
+	Extra      []*VerifiedFile `json:"extra"`
+}
+
+// Returns true if the local files are identical to the files in Artifactory.
+func (vr *VerifyReport) IsIdentical() bool {
+	return len(vr.Mismatched) == 0 && len(vr.Missing) == 0 && len(vr.Extra) == 0
+}
+
+// The sha256 checksum is compared when Artifactory has it. Otherwise, the sha1 checksum is compared.
+type VerifiedFile struct {
+	Path        string `json:"path,omitempty"`
+	LocalPath   string `json:"localPath"`
+	Sha256      string `json:"sha256,omitempty"`
+	LocalSha256 string `json:"localSha256,omitempty"`
+	Sha1        string `json:"sha1,omitempty"`
+	LocalSha1   string `json:"localSha1,omitempty"`
+}
+
+func NewVerifyCommand() *VerifyCommand {
+	return &VerifyCommand{threads: 3}
+}
+
+func (vc *VerifyCommand) SetServerDetails(serverDetails *config.ServerDetails) *VerifyCommand {
+	vc.serverDetails = serverDetails
+	return vc
+}
+
+func (vc *VerifyCommand) SetSpec(verifySpec *spec.SpecFiles) *VerifyCommand {
+	vc.spec = verifySpec
+	return vc
+}
+
+// The number of local files whose checksums are calculated concurrently.
+func (vc *VerifyCommand) SetThreads(threads int) *VerifyCommand {
+	vc.threads = threads
+	return vc
+}
+
+func (vc *VerifyCommand) ServerDetails() (*config.ServerDetails, error) {
+	return vc.serverDetails, nil
+}
+
+func (vc *VerifyCommand) CommandName() string {
+	return "rt_verify"
+}
+
+// Returns the report of the last run of the command.
+func (vc *VerifyCommand) Report() *VerifyReport {
+	return vc.report
+}
+
+func (vc *VerifyCommand) Run() error {
+	servicesManager, err := utils.CreateServiceManager(vc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	log.Info("Collecting the files to verify...")
+	expected := make(map[string]*VerifiedFile)
+	err = forEachDownloadTarget(servicesManager, vc.spec, func(item *servicesutils.ResultItem, localPath string, _ int) error {
+		expected[filepath.Clean(localPath)] = &VerifiedFile{Path: item.GetItemRelativePath(), LocalPath: localPath, Sha256: item.Sha256, Sha1: item.Actual_Sha1}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	log.Info("Comparing", len(expected), "files with the local files...")
+	report, err := vc.compare(expected)
+	if err != nil {
+		return err
+	}
+	if report.Extra, err = findExtraFiles(getVerifyRoots(vc.spec), expected); err != nil {
+		return err
+	}
+	vc.report = report
+	if err = output.PrintJson(report); err != nil {
+		return err
+	}
+	if !report.IsIdentical() {
+		return errorutils.CheckErrorf("the local files are different from the files in Artifactory: %d mismatched, %d missing and %d extra files", len(report.Mismatched), len(report.Missing), len(report.Extra))
+	}
+	log.Info(fmt.Sprintf("All %d files are identical to the files in Artifactory.", report.Matched))
+	return nil
+}
+
+// Calculates the checksums of the expected local files concurrently, and compares them with the checksums in Artifactory.
+func (vc *VerifyCommand) compare(expected map[string]*VerifiedFile) (*VerifyReport, error) {
+	report := &VerifyReport{Mismatched: []*VerifiedFile{}, Missing: []*VerifiedFile{}}
+	files := make(chan *VerifiedFile)
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+	var firstErr error
+	threads := vc.threads
+	if threads < 1 {
+		threads = 1
+	}
+	for i := 0; i < threads; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for file := range files {
+				exists, err := fileutils.IsFileExists(file.LocalPath, false)
+				var identical bool
+				if err == nil && exists {
+					identical, err = compareLocalFile(file)
+				}
+				mutex.Lock()
+				switch {
+				case err != nil:
+					if firstErr == nil {
+						firstErr = err
+					}
+				case !exists:
+					report.Missing = append(report.Missing, file)
+				case identical:
+					report.Matched++
+				default:
+					report.Mismatched = append(report.Mismatched, file)
+				}
+				mutex.Unlock()
+			}
+		}()
+	}
+	for _, file := range expected {
+		files <- file
+	}
+	close(files)
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	sortVerifiedFiles(report.Mismatched)
+	sortVerifiedFiles(report.Missing)
+	return report, nil
+}
+
+// Sets the checksum of the local file, and returns true if it's identical to the checksum in Artifactory.
+func compareLocalFile(file *VerifiedFile) (bool, error) {
+	checksums, err := calcFileChecksums(file.LocalPath)
+	if err != nil {
+		return false, err
+	}
+	if file.Sha256 != "" {
+		file.LocalSha256 = checksums.sha256()
+		file.Sha1 = ""
+		return file.LocalSha256 == file.Sha256, nil
+	}
+	file.LocalSha1 = checksums.sha1()
+	return file.LocalSha1 == file.Sha1, nil
+}
+
+// Returns the local directories in which the files of the spec are downloaded. Files under these directories which aren't in Artifactory are reported as extra files.
+// The target of a file spec group which isn't a directory is of a single renamed file, so no directory is returned for it.
+func getVerifyRoots(verifySpec *spec.SpecFiles) []string {
+	var roots []string
+	for _, file := range verifySpec.Files {
+		target := file.Target
+		if placeholderIndex := strings.Index(target, "{"); placeholderIndex >= 0 {
+			target = target[:placeholderIndex]
+			if !isDirTarget(target) {
+				target = filepath.Dir(target)
+			}
+		} else if !isDirTarget(target) {
+			continue
+		}
+		// Files downloaded without a target are placed under the current directory, which may include any other files.
+		if target == "" || target == "." {
+			continue
+		}
+		roots = append(roots, filepath.Clean(target))
+	}
+	return roots
+}
+
+func isDirTarget(target string) bool {
+	return strings.HasSuffix(target, "/") || strings.HasSuffix(target, "\\")
+}
+
+func findExtraFiles(roots []string, expected map[string]*VerifiedFile) ([]*VerifiedFile, error) {
+	extra := []*VerifiedFile{}
+	found := make(map[string]bool)
+	for _, root := range roots {
+		if exists, err := fileutils.IsDirExists(root, false); err != nil || !exists {
+			continue
+		}
+		err := filepath.Walk(root, func(localPath string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			localPath = filepath.Clean(localPath)
+			if info.IsDir() || expected[localPath] != nil || found[localPath] {
+				return nil
+			}
+			found[localPath] = true
+			extra = append(extra, &VerifiedFile{LocalPath: localPath})
+			return nil
+		})
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+	}
+	sortVerifiedFiles(extra)
+	return extra, nil
+}
+
+func sortVerifiedFiles(files []*VerifiedFile) {
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].LocalPath < files[j].LocalPath
+	})
+}
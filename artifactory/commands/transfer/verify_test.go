@@ -0,0 +1,71 @@
+package transfer
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	coretests "github.com/jfrog/jfrog-cli-core/v2/utils/tests"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetVerifyRoots(t *testing.T) {
+	verifySpec := &spec.SpecFiles{Files: []spec.File{
+		{Target: "out/"},
+		{Target: "out/renamed.txt"},
+		{Target: "out/{1}/"},
+		{Target: "out/a-{1}/"},
+		{Target: ""},
+		{Target: "{1}/"},
+	}}
+	assert.Equal(t, []string{"out", "out", "out"}, getVerifyRoots(verifySpec))
+}
+
+func TestVerifyLocalFiles(t *testing.T) {
+	tempDirPath, createTempDirCallback := coretests.CreateTempDirWithCallbackAndAssert(t)
+	defer createTempDirCallback()
+	matchedPath := filepath.Join(tempDirPath, "matched.txt")
+	assert.NoError(t, ioutil.WriteFile(matchedPath, []byte("content"), 0644))
+	mismatchedPath := filepath.Join(tempDirPath, "mismatched.txt")
+	assert.NoError(t, ioutil.WriteFile(mismatchedPath, []byte("other content"), 0644))
+	assert.NoError(t, os.Mkdir(filepath.Join(tempDirPath, "sub"), 0755))
+	extraPath := filepath.Join(tempDirPath, "sub", "extra.txt")
+	assert.NoError(t, ioutil.WriteFile(extraPath, []byte("content"), 0644))
+	missingPath := filepath.Join(tempDirPath, "missing.txt")
+
+	contentSha256 := "ed7002b439e9ac845f22357d822bac1444730fbdb6016d3ec9432297b9ec9f73"
+	expected := map[string]*VerifiedFile{
+		matchedPath:    {Path: "repo/matched.txt", LocalPath: matchedPath, Sha256: contentSha256},
+		mismatchedPath: {Path: "repo/mismatched.txt", LocalPath: mismatchedPath, Sha256: contentSha256},
+		missingPath:    {Path: "repo/missing.txt", LocalPath: missingPath, Sha256: contentSha256},
+	}
+	report, err := NewVerifyCommand().compare(expected)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, report.Matched)
+	if assert.Len(t, report.Mismatched, 1) {
+		assert.Equal(t, mismatchedPath, report.Mismatched[0].LocalPath)
+		assert.NotEqual(t, contentSha256, report.Mismatched[0].LocalSha256)
+	}
+	if assert.Len(t, report.Missing, 1) {
+		assert.Equal(t, missingPath, report.Missing[0].LocalPath)
+	}
+
+	extra, err := findExtraFiles([]string{tempDirPath}, expected)
+	assert.NoError(t, err)
+	if assert.Len(t, extra, 1) {
+		assert.Equal(t, extraPath, extra[0].LocalPath)
+	}
+}
+
+func TestCompareLocalFileWithSha1(t *testing.T) {
+	tempDirPath, createTempDirCallback := coretests.CreateTempDirWithCallbackAndAssert(t)
+	defer createTempDirCallback()
+	localPath := filepath.Join(tempDirPath, "a.txt")
+	assert.NoError(t, ioutil.WriteFile(localPath, []byte("content"), 0644))
+	// Artifactory may not have the sha256 checksum of files deployed by older versions.
+	identical, err := compareLocalFile(&VerifiedFile{LocalPath: localPath, Sha1: "040f06fd774092478d450774f5ba30c5da78acc8"})
+	assert.NoError(t, err)
+	assert.True(t, identical)
+}
@@ -0,0 +1,211 @@
+package transfer
+
+import (
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jfrog/jfrog-client-go/artifactory/services/fspatterns"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Uploads the files of an upload spec, and then keeps watching the local file system until interrupted.
+// New or changed files are uploaded once they stay unchanged for a full interval, so that files which are still being written aren't uploaded.
+type WatchUploadCommand struct {
+	*UploadCommand
+	interval time.Duration
+}
+
+func NewWatchUploadCommand(uploadCommand *UploadCommand) *WatchUploadCommand {
+	return &WatchUploadCommand{UploadCommand: uploadCommand, interval: 5 * time.Second}
+}
+
+func (wuc *WatchUploadCommand) SetInterval(interval time.Duration) *WatchUploadCommand {
+	wuc.interval = interval
+	return wuc
+}
+
+// The size and modification time of a watched file. A file is considered changed when either of them changes.
+type watchedFile struct {
+	size    int64
+	modTime time.Time
+}
+
+// The local root path of a single file spec group, which is scanned for changes.
+// The files which match the patterns of the group, and their targets, are resolved by the upload service.
+type watchedGroup struct {
+	rootPath     string
+	isSingleFile bool
+	recursive    bool
+	symlinks     bool
+}
+
+func (wuc *WatchUploadCommand) Run() error {
+	groups, err := wuc.createWatchedGroups()
+	if err != nil {
+		return err
+	}
+	// The files are scanned before the first upload, so that files which change during the upload are uploaded again.
+	snapshot, err := scanWatchedGroups(groups)
+	if err != nil {
+		return err
+	}
+	if err = wuc.UploadCommand.Run(); err != nil {
+		return err
+	}
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	defer signal.Stop(interrupt)
+	ticker := time.NewTicker(wuc.interval)
+	defer ticker.Stop()
+	log.Info("Watching for new or changed files. Press Ctrl+C to stop.")
+	pending := make(map[string]bool)
+	for {
+		select {
+		case <-interrupt:
+			log.Info("Stopped watching.")
+			return nil
+		case <-ticker.C:
+			current, err := scanWatchedGroups(groups)
+			if err != nil {
+				// Files may be removed while they are scanned. Such errors shouldn't stop the watch.
+				log.Warn("Failed scanning the local files:", err.Error())
+				continue
+			}
+			ready := collectReadyFiles(snapshot, current, pending)
+			snapshot = current
+			if len(ready) == 0 {
+				continue
+			}
+			if err = wuc.uploadChanges(ready); err != nil {
+				log.Error(err)
+			}
+		}
+	}
+}
+
+// Uploads the changed files. Files which failed to upload are uploaded again on the next change.
+func (wuc *WatchUploadCommand) uploadChanges(paths []string) error {
+	entries, err := wuc.createChangedEntries(paths)
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		return nil
+	}
+	log.Info("Uploading", len(entries), "new or changed files...")
+	journal := NewJournal("", wuc.CommandName(), "")
+	journal.Entries = entries
+	return wuc.runJournal(journal, wuc.UploadCommand)
+}
+
+// Creates the entries of the changed files, with the targets they are uploaded to.
+// The targets are resolved by running the upload of each file spec group in dry-run mode, and keeping the changed files which match the group.
+func (wuc *WatchUploadCommand) createChangedEntries(paths []string) ([]*Entry, error) {
+	sources := make(map[string]bool)
+	for _, path := range paths {
+		sources[path] = true
+	}
+	var entries []*Entry
+	for i := range wuc.spec.Files {
+		groupEntries, err := wuc.createGroupEntries(i, sources)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, groupEntries...)
+	}
+	return entries, nil
+}
+
+func (wuc *WatchUploadCommand) createWatchedGroups() ([]*watchedGroup, error) {
+	var groups []*watchedGroup
+	for i := range wuc.spec.Files {
+		file := wuc.spec.Get(i)
+		if file.Archive != "" {
+			return nil, errorutils.CheckErrorf("the --watch option is not supported when uploading to an archive")
+		}
+		params, err := file.ToCommonParams()
+		if err != nil {
+			return nil, err
+		}
+		group := new(watchedGroup)
+		if group.recursive, err = file.IsRecursive(true); err != nil {
+			return nil, err
+		}
+		if group.symlinks, err = file.IsSymlinks(false); err != nil {
+			return nil, err
+		}
+		target := params.Target
+		if !strings.Contains(target, "/") {
+			target += "/"
+		}
+		params.Regexp = file.Regexp == "true"
+		params.Ant = file.Ant == "true"
+		pattern := clientutils.ReplaceTildeWithUserHome(params.Pattern)
+		if group.rootPath, err = fspatterns.GetRootPath(pattern, target, params.GetPatternType(), group.symlinks); err != nil {
+			return nil, err
+		}
+		isDir, err := fileutils.IsDirExists(group.rootPath, group.symlinks)
+		if err != nil {
+			return nil, err
+		}
+		group.isSingleFile = !isDir || (fileutils.IsPathSymlink(group.rootPath) && group.symlinks)
+		groups = append(groups, group)
+	}
+	return groups, nil
+}
+
+// Returns the size and modification time of the files of all groups, mapped by their paths.
+func scanWatchedGroups(groups []*watchedGroup) (map[string]watchedFile, error) {
+	files := make(map[string]watchedFile)
+	for _, group := range groups {
+		paths := []string{group.rootPath}
+		if !group.isSingleFile {
+			var err error
+			if paths, err = fspatterns.GetPaths(group.rootPath, group.recursive, false, group.symlinks); err != nil {
+				return nil, err
+			}
+		}
+		for _, path := range paths {
+			fileInfo, err := os.Stat(path)
+			if err != nil {
+				return nil, errorutils.CheckError(err)
+			}
+			if !fileInfo.IsDir() {
+				files[path] = watchedFile{size: fileInfo.Size(), modTime: fileInfo.ModTime()}
+			}
+		}
+	}
+	return files, nil
+}
+
+// Compares the current scan with the previous one, and returns the files which are ready to be uploaded.
+// A new or changed file becomes pending, and is ready once it stays unchanged until the next scan.
+func collectReadyFiles(previous, current map[string]watchedFile, pending map[string]bool) []string {
+	var ready []string
+	for path := range pending {
+		if file, ok := current[path]; !ok || previous[path] != file {
+			continue
+		}
+		ready = append(ready, path)
+		delete(pending, path)
+	}
+	for path, file := range current {
+		if previousFile, ok := previous[path]; !ok || previousFile != file {
+			pending[path] = true
+		}
+	}
+	for path := range pending {
+		if _, ok := current[path]; !ok {
+			delete(pending, path)
+		}
+	}
+	sort.Strings(ready)
+	return ready
+}
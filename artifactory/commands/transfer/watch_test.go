@@ -0,0 +1,69 @@
+package transfer
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	coretests "github.com/jfrog/jfrog-cli-core/v2/utils/tests"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollectReadyFiles(t *testing.T) {
+	modTime := time.Now()
+	pending := make(map[string]bool)
+	previous := map[string]watchedFile{"a.txt": {size: 1, modTime: modTime}}
+	current := map[string]watchedFile{"a.txt": {size: 1, modTime: modTime}, "b.txt": {size: 1, modTime: modTime}}
+	assert.Empty(t, collectReadyFiles(previous, current, pending))
+	assert.Equal(t, map[string]bool{"b.txt": true}, pending)
+
+	// b.txt is still being written, so it stays pending.
+	previous, current = current, map[string]watchedFile{"a.txt": {size: 1, modTime: modTime}, "b.txt": {size: 2, modTime: modTime}}
+	assert.Empty(t, collectReadyFiles(previous, current, pending))
+
+	previous, current = current, map[string]watchedFile{"a.txt": {size: 3, modTime: modTime}, "b.txt": {size: 2, modTime: modTime}}
+	assert.Equal(t, []string{"b.txt"}, collectReadyFiles(previous, current, pending))
+	assert.Equal(t, map[string]bool{"a.txt": true}, pending)
+
+	// A removed file is no longer pending.
+	previous, current = current, map[string]watchedFile{"b.txt": {size: 2, modTime: modTime}}
+	assert.Empty(t, collectReadyFiles(previous, current, pending))
+	assert.Empty(t, pending)
+}
+
+func TestWatchCreateChangedEntries(t *testing.T) {
+	tempDirPath, createTempDirCallback := coretests.CreateTempDirWithCallbackAndAssert(t)
+	defer createTempDirCallback()
+	assert.NoError(t, os.MkdirAll(filepath.Join(tempDirPath, "a"), 0777))
+	for _, fileName := range []string{filepath.Join("a", "b.txt"), filepath.Join("a", "c.log"), filepath.Join("a", "d.log")} {
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(tempDirPath, fileName), []byte("content"), 0644))
+	}
+	specFiles := &spec.SpecFiles{Files: []spec.File{
+		{Pattern: filepath.Join(tempDirPath, "(*).txt"), Target: "repo/{1}/", TargetProps: "a=b"},
+		{Pattern: filepath.Join(tempDirPath, "*"), Target: "repo2/", Exclusions: []string{"*.txt"}, Flat: "true"},
+	}}
+	uploadCommand := NewUploadCommand().SetUploadConfiguration(&utils.UploadConfiguration{Threads: 1})
+	uploadCommand.SetSpec(specFiles).SetServerDetails(&config.ServerDetails{ArtifactoryUrl: "http://localhost:8081/artifactory/"})
+	watchUploadCommand := NewWatchUploadCommand(uploadCommand)
+	groups, err := watchUploadCommand.createWatchedGroups()
+	assert.NoError(t, err)
+	files, err := scanWatchedGroups(groups)
+	assert.NoError(t, err)
+	assert.Len(t, files, 3)
+
+	// Only the changed files are uploaded, to the targets resolved by the upload service.
+	entries, err := watchUploadCommand.createChangedEntries([]string{filepath.Join(tempDirPath, "a", "b.txt"), filepath.Join(tempDirPath, "a", "c.log")})
+	assert.NoError(t, err)
+	if assert.Len(t, entries, 2) {
+		assert.Equal(t, "repo/a/b/b.txt", entries[0].Target)
+		assert.Equal(t, "a=b", entries[0].Props)
+		assert.Equal(t, int64(7), entries[0].Size)
+		assert.Equal(t, "repo2/c.log", entries[1].Target)
+		assert.Equal(t, 1, entries[1].SpecIndex)
+	}
+}
@@ -0,0 +1,12 @@
+package utils
+
+import "strings"
+
+// Splits a path in Artifactory into the repository and the path inside it.
+func SplitRepoPath(path string) (repoKey, repoPath string) {
+	path = strings.Trim(path, "/")
+	if slashIndex := strings.Index(path, "/"); slashIndex >= 0 {
+		return path[:slashIndex], path[slashIndex+1:]
+	}
+	return path, ""
+}
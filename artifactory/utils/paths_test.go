@@ -0,0 +1,16 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitRepoPath(t *testing.T) {
+	repoKey, repoPath := SplitRepoPath("/repo/a/b/")
+	assert.Equal(t, "repo", repoKey)
+	assert.Equal(t, "a/b", repoPath)
+	repoKey, repoPath = SplitRepoPath("repo")
+	assert.Equal(t, "repo", repoKey)
+	assert.Empty(t, repoPath)
+}
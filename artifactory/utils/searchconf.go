@@ -0,0 +1,24 @@
+package utils
+
+import (
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/auth"
+	"github.com/jfrog/jfrog-client-go/http/jfroghttpclient"
+)
+
+// Allows using the search and AQL utilities of the client, such as ExecAqlSaveToFile, with a services manager.
+type SearchConf struct {
+	servicesManager artifactory.ArtifactoryServicesManager
+}
+
+func NewSearchConf(servicesManager artifactory.ArtifactoryServicesManager) *SearchConf {
+	return &SearchConf{servicesManager: servicesManager}
+}
+
+func (sc *SearchConf) GetArtifactoryDetails() auth.ServiceDetails {
+	return sc.servicesManager.GetConfig().GetServiceDetails()
+}
+
+func (sc *SearchConf) GetJfrogHttpClient() *jfroghttpclient.JfrogHttpClient {
+	return sc.servicesManager.Client()
+}
@@ -0,0 +1,7 @@
+package accessdiff
+
+var Usage = []string{"rt access-diff [command options]"}
+
+func GetDescription() string {
+	return "Compare permission targets, users and group memberships exported by the pte, ue and ge commands with Artifactory, and report the differences."
+}
@@ -0,0 +1,7 @@
+package accesstokenlist
+
+var Usage = []string{"rt atl [command options]"}
+
+func GetDescription() string {
+	return "List the access tokens in Artifactory. Use the --subject option to list only the tokens of a specific user."
+}
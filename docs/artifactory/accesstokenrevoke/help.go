@@ -0,0 +1,12 @@
+package accesstokenrevoke
+
+var Usage = []string{"rt access-token-revoke <token id>", "rt access-token-revoke --subject=<subject>"}
+
+func GetDescription() string {
+	return "Revoke an access token by its ID, or all the access tokens of a subject."
+}
+
+func GetArguments() string {
+	return `	token id
+		The ID of the token to revoke, as listed by the access-token-list command.`
+}
@@ -0,0 +1,11 @@
+package accesstokenrotate
+
+import "github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+
+var Usage = []string{"rt access-token-rotate [command options]"}
+
+func GetDescription() string {
+	return "Replace the access token of a server in the " + coreutils.GetCliExecutableName() + " configuration. " +
+		"A new token is created with the same user, scope and audience, the server configuration is updated to use it, and then the old token is revoked. " +
+		"The server is chosen by the --server-id option, or the default server if not specified."
+}
@@ -0,0 +1,13 @@
+package applyplan
+
+var Usage = []string{"rt apply-plan [command options] <plan path>"}
+
+func GetDescription() string {
+	return "Apply a plan created by the upload, download, copy, move or delete commands, when used with the --dry-run and --plan-file options."
+}
+
+func GetArguments() string {
+	return `	plan path
+		The path of the plan file. Plans of upload and download commands should be applied from the directory in which they were created,
+		since they may include relative local paths.`
+}
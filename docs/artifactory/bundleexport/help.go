@@ -0,0 +1,18 @@
+package bundleexport
+
+var Usage = []string{"rt bundle-export [command options] <pattern> <archive path>",
+	"rt bundle-export --spec=<File Spec path> [command options] <archive path>"}
+
+func GetDescription() string {
+	return "Export artifacts, with their properties, checksums and the build-info of their builds, into a single archive which can be imported into another Artifactory server."
+}
+
+func GetArguments() string {
+	return `	pattern
+		Specifies the source path in Artifactory of the artifacts to export,
+		in the following format: <repository name>/<repository path>. You can use wildcards to specify multiple artifacts.
+
+	archive path
+		Specifies the local path of the archive to create. Its format is determined by its extension, which should be one of .tar, .tar.gz, .tgz or .tar.zst.
+		The archive includes an index.json file, which lists the exported artifacts with their paths, properties and checksums, and the exported builds.`
+}
@@ -0,0 +1,13 @@
+package bundleimport
+
+var Usage = []string{"rt bundle-import [command options] <archive path>"}
+
+func GetDescription() string {
+	return "Import an archive created by the bundle-export command. The artifacts are deployed to the same paths and with the same properties, and the build-info of their builds is published."
+}
+
+func GetArguments() string {
+	return `	archive path
+		Specifies the local path of the archive to import.
+		The checksums of the artifacts are verified against the index of the archive before they are deployed. If any of them doesn't match, nothing is deployed.`
+}
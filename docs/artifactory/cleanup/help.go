@@ -0,0 +1,7 @@
+package cleanup
+
+var Usage = []string{"rt cleanup --policy=<policy path> [command options]"}
+
+func GetDescription() string {
+	return "Delete the artifacts matching the retention rules of a cleanup policy. A report of the artifacts is printed before they are deleted."
+}
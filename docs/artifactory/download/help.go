@@ -23,5 +23,7 @@ func GetArguments() string {
 		If there is no terminal slash, the target path is assumed to be a file to which the downloaded file should be renamed.
 		For example, if you specify the target as "a/b", the downloaded file is renamed to "b".
 		For flexibility in specifying the target path, you can include placeholders in the form of {1}, {2} which are replaced by corresponding
-		tokens in the source path that are enclosed in parenthesis.`
+		tokens in the source path that are enclosed in parenthesis.
+		Specify "-" to write the content of a single file to the standard output. In this case, the source should be the path of the file,
+		and the progress and logs are written to the standard error. For example: jf rt dl repo-name/dir.tar - | tar x`
 }
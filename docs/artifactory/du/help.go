@@ -0,0 +1,15 @@
+package du
+
+var Usage = []string{"rt du [command options] <pattern>",
+	"rt du --spec=<File Spec path> [command options]"}
+
+func GetDescription() string {
+	return "Report the aggregated size, number of files and last modified and downloaded dates of folders in Artifactory."
+}
+
+func GetArguments() string {
+	return `	pattern
+		Specifies the path in Artifactory of the files to aggregate,
+		in the following format: <repository name>/<repository path>. You can use wildcards to specify multiple files.
+		The folders are reported from the part of the pattern before its first wildcard, down to the depth set by the --depth option.`
+}
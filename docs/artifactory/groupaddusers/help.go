@@ -1,6 +1,6 @@
 package groupaddusers
 
-var Usage = []string{"rt gau <group name> <users list>"}
+var Usage = []string{"rt gau <group name> <users list>", "rt gau --csv <group memberships file path>"}
 
 func GetDescription() string {
 	return "Add a list of users to a group."
@@ -13,5 +13,9 @@ func GetArguments() string {
 	users list
 		Specifies the usernames to add to the specified group.
 		The list should be comma-separated. 
+
+	group memberships file path
+		A CSV file, as created by the groups-export command, with a "groupname" and a "username" column.
+		The users listed in the file are added to their groups.
 	`
 }
@@ -0,0 +1,15 @@
+package groupsexport
+
+import "github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+
+var Usage = []string{"rt ge <group memberships file path>"}
+
+func GetDescription() string {
+	return "Export the groups in Artifactory and their users to a CSV file."
+}
+
+func GetArguments() string {
+	return `	group memberships file path
+		Specifies the local file system path of the CSV file to which the groups are written, with a line for each user of each group.
+		The file can be used by the "` + coreutils.GetCliExecutableName() + ` rt gau --csv" command.`
+}
@@ -0,0 +1,13 @@
+package ls
+
+var Usage = []string{"rt ls [command options] <path>"}
+
+func GetDescription() string {
+	return "List the files and folders under a path in Artifactory, with their sizes and modification times."
+}
+
+func GetArguments() string {
+	return `	path
+		Specifies the path in Artifactory to list, in the following format: <repository name>/<repository path>.
+		If the path is of a file, the file itself is listed.`
+}
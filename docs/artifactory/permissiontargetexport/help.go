@@ -0,0 +1,15 @@
+package permissiontargetexport
+
+import "github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+
+var Usage = []string{"rt pte <target directory>"}
+
+func GetDescription() string {
+	return "Export the permission targets in Artifactory to a directory of permission target templates."
+}
+
+func GetArguments() string {
+	return `	target directory
+		Specifies the local file system path of the directory to which a template file is written for each permission target.
+		The templates can be used by the "` + coreutils.GetCliExecutableName() + ` rt ptc" and "` + coreutils.GetCliExecutableName() + ` rt ptu" commands.`
+}
@@ -0,0 +1,7 @@
+package replicationlist
+
+var Usage = []string{"rt rpll [command options]"}
+
+func GetDescription() string {
+	return "List the replications configured in Artifactory."
+}
@@ -0,0 +1,12 @@
+package replicationstatus
+
+var Usage = []string{"rt rpls [command options] <repository key>"}
+
+func GetDescription() string {
+	return "Print the status of the last replication of a repository. Use the --run option to trigger a replication and wait for it to finish first."
+}
+
+func GetArguments() string {
+	return `	repository key
+		The key of the local or remote repository whose replication status should be printed.`
+}
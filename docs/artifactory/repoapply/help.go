@@ -0,0 +1,15 @@
+package repoapply
+
+import "github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+
+var Usage = []string{"rt repo-apply [command options] <templates directory>"}
+
+func GetDescription() string {
+	return "Create and update repositories, so that they match a directory of repository templates. The changes are printed as a plan before they are applied."
+}
+
+func GetArguments() string {
+	return `	templates directory
+		Specifies the local file system path of a directory with a template file for each repository. The templates can be created using the "` + coreutils.GetCliExecutableName() + ` rt rpt" command.
+		Only the files with a .json extension are used.`
+}
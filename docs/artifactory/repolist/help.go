@@ -0,0 +1,7 @@
+package repolist
+
+var Usage = []string{"rt repo-list [command options]"}
+
+func GetDescription() string {
+	return "List the repositories in Artifactory."
+}
@@ -0,0 +1,12 @@
+package reposhow
+
+var Usage = []string{"rt repo-show <repository key>"}
+
+func GetDescription() string {
+	return "Print the configuration of a repository."
+}
+
+func GetArguments() string {
+	return `	repository key
+		The key of the repository.`
+}
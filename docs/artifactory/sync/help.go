@@ -0,0 +1,15 @@
+package sync
+
+var Usage = []string{"rt sync [command options] <local directory> <repository path>"}
+
+func GetDescription() string {
+	return "Sync a local directory with a path in Artifactory in both directions."
+}
+
+func GetArguments() string {
+	return `	local directory
+		The path of the local directory to sync.
+
+	repository path
+		The path in Artifactory to sync, in the following format: <repository name>/<repository path>.`
+}
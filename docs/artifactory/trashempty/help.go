@@ -0,0 +1,15 @@
+package trashempty
+
+var Usage = []string{"rt trash empty [command options] [pattern]",
+	"rt trash empty --spec=<File Spec path> [command options]"}
+
+func GetDescription() string {
+	return "Permanently delete artifacts from the trash can."
+}
+
+func GetArguments() string {
+	return `	pattern
+		Specifies the path in Artifactory from which the artifacts were deleted,
+		in the following format: <repository name>/<repository path>. You can use wildcards to specify multiple artifacts.
+		If no pattern, spec, build or bundle is sent, the entire trash can is emptied.`
+}
@@ -0,0 +1,15 @@
+package trashlist
+
+var Usage = []string{"rt trash list [command options] [pattern]",
+	"rt trash list --spec=<File Spec path> [command options]"}
+
+func GetDescription() string {
+	return "List the artifacts in the trash can."
+}
+
+func GetArguments() string {
+	return `	pattern
+		Specifies the path in Artifactory from which the artifacts were deleted,
+		in the following format: <repository name>/<repository path>. You can use wildcards to specify multiple artifacts.
+		If no pattern is sent, all the artifacts in the trash can are listed.`
+}
@@ -0,0 +1,14 @@
+package trashrestore
+
+var Usage = []string{"rt trash restore [command options] <pattern>",
+	"rt trash restore --spec=<File Spec path> [command options]"}
+
+func GetDescription() string {
+	return "Restore artifacts from the trash can to the paths they were deleted from."
+}
+
+func GetArguments() string {
+	return `	pattern
+		Specifies the path in Artifactory from which the artifacts were deleted,
+		in the following format: <repository name>/<repository path>. You can use wildcards to specify multiple artifacts.`
+}
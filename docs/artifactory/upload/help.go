@@ -16,6 +16,8 @@ func GetArguments() string {
 		Specifies the local file system path to artifacts which should be uploaded to Artifactory.
 		You can specify multiple artifacts by using wildcards or a regular expression as designated by the --regexp command option.
 		If you have specified that you are using regular expressions, then the first one used in the argument must be enclosed in parenthesis.
+		Specify "-" to upload the standard input. In this case, the target should be the path of a single file, and the size of the
+		uploaded content can be specified using the --size option, to display the progress of the upload.
 
 	target pattern
 		Specifies the target path in Artifactory in the following format: <repository name>/<repository path>.
@@ -24,5 +26,6 @@ func GetArguments() string {
 		is assumed to be a file to which the uploaded file should be renamed. For example, if you specify the target as "repo-name/a/b",
 		the uploaded file is renamed to "b" in Artifactory.
 		For flexibility in specifying the upload path, you can include placeholders in the form of {1}, {2} which are replaced by corresponding
-		tokens in the source path that are enclosed in parenthesis.`
+		tokens in the source path that are enclosed in parenthesis.
+		For example, to upload a tar archive created on the fly: tar c dir | jf rt u - repo-name/dir.tar`
 }
@@ -0,0 +1,15 @@
+package usersexport
+
+import "github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+
+var Usage = []string{"rt ue <users details file path>"}
+
+func GetDescription() string {
+	return "Export the users in Artifactory to a CSV file."
+}
+
+func GetArguments() string {
+	return `	users details file path
+		Specifies the local file system path of the CSV file to which the users are written. The file can be used by the "` + coreutils.GetCliExecutableName() + ` rt uc" command.
+		The passwords of the users are not exported, and their group memberships are exported by the "` + coreutils.GetCliExecutableName() + ` rt ge" command.`
+}
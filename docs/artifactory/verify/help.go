@@ -0,0 +1,19 @@
+package verify
+
+var Usage = []string{"rt verify [command options] <local directory> <pattern>",
+	"rt verify --spec=<File Spec path> [command options]"}
+
+func GetDescription() string {
+	return "Compare local files with the files in Artifactory, and report the missing, extra and mismatched files."
+}
+
+func GetArguments() string {
+	return `	local directory
+		Specifies the local directory to which the artifacts were downloaded.
+		The artifacts are expected in the same local paths to which the download command places them, so unless the --flat option is set,
+		the Artifactory repository path structure is expected under this directory.
+
+	pattern
+		Specifies the source path in Artifactory of the artifacts to compare with the local files,
+		in the following format: <repository name>/<repository path>. You can use wildcards to specify multiple artifacts.`
+}
@@ -69,5 +69,14 @@ func GetGlobalEnvVars() string {
 	JFROG_CLI_MIN_CHECKSUM_DEPLOY_SIZE_KB
 		[Default: 10]
 		Minimum file size in KB for which JFrog CLI performs checksum deploy optimization.
+
+	JFROG_CLI_DOWNLOAD_CACHE_DIR
+		[Default: $JFROG_CLI_HOME_DIR/download-cache]
+		Defines the directory of the local download cache. If set, the "` + coreutils.GetCliExecutableName() + ` rt download" command uses the cache, as if the --cache option was sent.
+		Other commands, such as the Maven commands and plugin installs, don't use the cache.
+
+	JFROG_CLI_DOWNLOAD_CACHE_MAX_SIZE
+		[Default: 10GB]
+		The maximum size of the local download cache. The least recently used files are removed from the cache once it exceeds this size.
 		`
 }
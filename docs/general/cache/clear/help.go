@@ -0,0 +1,7 @@
+package clear
+
+var Usage = []string{"cache clear"}
+
+func GetDescription() string {
+	return "Remove all files from the local download cache."
+}
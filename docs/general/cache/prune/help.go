@@ -0,0 +1,7 @@
+package prune
+
+var Usage = []string{"cache prune [command options]"}
+
+func GetDescription() string {
+	return "Remove the least recently used files from the local download cache, until its size doesn't exceed the maximum size."
+}
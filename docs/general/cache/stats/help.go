@@ -0,0 +1,7 @@
+package stats
+
+var Usage = []string{"cache stats"}
+
+func GetDescription() string {
+	return "Print the location, number of files and size of the local download cache, which is used by the 'rt download' command."
+}
@@ -0,0 +1,12 @@
+package explain
+
+var Usage = []string{"spec explain --command=<command> [command options] <File Spec path>"}
+
+func GetDescription() string {
+	return "Print the AQL query or the local file-system walk which each group of a File Spec produces, after its variables are replaced."
+}
+
+func GetArguments() string {
+	return `	File Spec path
+		The path to the File Spec to explain.`
+}
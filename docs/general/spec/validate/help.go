@@ -0,0 +1,12 @@
+package validate
+
+var Usage = []string{"spec validate [command options] <File Spec path>"}
+
+func GetDescription() string {
+	return "Validate a File Spec against the File Spec schema, and optionally against the rules of the command which uses it."
+}
+
+func GetArguments() string {
+	return `	File Spec path
+		The path to the File Spec to validate.`
+}
@@ -0,0 +1,105 @@
+package cache
+
+import (
+	"fmt"
+
+	corecommon "github.com/jfrog/jfrog-cli-core/v2/docs/common"
+	"github.com/jfrog/jfrog-cli/docs/general/cache/clear"
+	"github.com/jfrog/jfrog-cli/docs/general/cache/prune"
+	"github.com/jfrog/jfrog-cli/docs/general/cache/stats"
+	cacheutils "github.com/jfrog/jfrog-cli/utils/cache"
+	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/urfave/cli"
+)
+
+func GetCommands() []cli.Command {
+	return cliutils.GetSortedCommands(cli.CommandsByName{
+		{
+			Name:         "stats",
+			Description:  stats.GetDescription(),
+			Flags:        cliutils.GetCommandFlags(cliutils.CacheStats),
+			HelpName:     corecommon.CreateUsage("cache stats", stats.GetDescription(), stats.Usage),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return statsCmd(c)
+			},
+		},
+		{
+			Name:         "prune",
+			Description:  prune.GetDescription(),
+			Flags:        cliutils.GetCommandFlags(cliutils.CachePrune),
+			HelpName:     corecommon.CreateUsage("cache prune", prune.GetDescription(), prune.Usage),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return pruneCmd(c)
+			},
+		},
+		{
+			Name:         "clear",
+			Description:  clear.GetDescription(),
+			Flags:        cliutils.GetCommandFlags(cliutils.CacheClear),
+			HelpName:     corecommon.CreateUsage("cache clear", clear.GetDescription(), clear.Usage),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return clearCmd(c)
+			},
+		},
+	})
+}
+
+func statsCmd(c *cli.Context) error {
+	if c.NArg() != 0 {
+		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
+	}
+	downloadCache, err := cacheutils.GetDownloadCache()
+	if err != nil {
+		return err
+	}
+	cacheStats, err := downloadCache.Stats()
+	if err != nil {
+		return err
+	}
+	log.Output("Directory: " + cacheStats.Dir)
+	log.Output(fmt.Sprintf("Files:     %d", cacheStats.Files))
+	log.Output(fmt.Sprintf("Size:      %s (maximum %s)", cliutils.FormatSize(cacheStats.Size), cliutils.FormatSize(cacheStats.MaxSize)))
+	return nil
+}
+
+func pruneCmd(c *cli.Context) error {
+	if c.NArg() != 0 {
+		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
+	}
+	downloadCache, err := cacheutils.GetDownloadCache()
+	if err != nil {
+		return err
+	}
+	maxSize := downloadCache.MaxSize()
+	if c.IsSet("max-size") {
+		if maxSize, err = cliutils.ParseSize(c.String("max-size")); err != nil {
+			return err
+		}
+	}
+	files, size, err := downloadCache.Prune(maxSize)
+	if err != nil {
+		return err
+	}
+	log.Info(fmt.Sprintf("Removed %d files (%s) from the download cache.", files, cliutils.FormatSize(size)))
+	return nil
+}
+
+func clearCmd(c *cli.Context) error {
+	if c.NArg() != 0 {
+		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
+	}
+	downloadCache, err := cacheutils.GetDownloadCache()
+	if err != nil {
+		return err
+	}
+	files, size, err := downloadCache.Clear()
+	if err != nil {
+		return err
+	}
+	log.Info(fmt.Sprintf("Removed %d files (%s) from the download cache.", files, cliutils.FormatSize(size)))
+	return nil
+}
@@ -0,0 +1,86 @@
+package spec
+
+import (
+	speccore "github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	corecommon "github.com/jfrog/jfrog-cli-core/v2/docs/common"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli/docs/general/spec/explain"
+	"github.com/jfrog/jfrog-cli/docs/general/spec/validate"
+	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/urfave/cli"
+)
+
+func GetCommands() []cli.Command {
+	return cliutils.GetSortedCommands(cli.CommandsByName{
+		{
+			Name:         "validate",
+			Description:  validate.GetDescription(),
+			Flags:        cliutils.GetCommandFlags(cliutils.SpecValidate),
+			HelpName:     corecommon.CreateUsage("spec validate", validate.GetDescription(), validate.Usage),
+			UsageText:    validate.GetArguments(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return validateCmd(c)
+			},
+		},
+		{
+			Name:         "explain",
+			Description:  explain.GetDescription(),
+			Flags:        cliutils.GetCommandFlags(cliutils.SpecExplain),
+			HelpName:     corecommon.CreateUsage("spec explain", explain.GetDescription(), explain.Usage),
+			UsageText:    explain.GetArguments(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return explainCmd(c)
+			},
+		},
+	})
+}
+
+func validateCmd(c *cli.Context) error {
+	if c.NArg() != 1 {
+		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
+	}
+	specPath := c.Args().Get(0)
+	content, err := fileutils.ReadFile(specPath)
+	if errorutils.CheckError(err) != nil {
+		return err
+	}
+	if specVars := coreutils.SpecVarsStringToMap(c.String("spec-vars")); len(specVars) > 0 {
+		content = coreutils.ReplaceVars(content, specVars)
+	}
+	issues, err := ValidateSpec(content, c.String("command"))
+	if err != nil {
+		return err
+	}
+	for _, issue := range issues {
+		log.Output(specPath + ":" + issue.String())
+	}
+	if len(issues) > 0 {
+		return errorutils.CheckErrorf("%d issues were found in the File Spec", len(issues))
+	}
+	log.Info("The File Spec is valid.")
+	return nil
+}
+
+func explainCmd(c *cli.Context) error {
+	if c.NArg() != 1 {
+		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
+	}
+	if !c.IsSet("command") {
+		return cliutils.PrintHelpAndReturnError("The --command option is mandatory.", c)
+	}
+	specFiles, err := speccore.CreateSpecFromFile(c.Args().Get(0), coreutils.SpecVarsStringToMap(c.String("spec-vars")))
+	if err != nil {
+		return err
+	}
+	explanation, err := ExplainSpec(specFiles, c.String("command"))
+	if err != nil {
+		return err
+	}
+	log.Output(explanation)
+	return nil
+}
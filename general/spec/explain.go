@@ -0,0 +1,150 @@
+package spec
+
+import (
+	"fmt"
+	"strings"
+
+	speccore "github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+)
+
+// The artifact properties each search based command requests, which affect the fields its AQL query includes.
+var commandsRequiredProps = map[string]servicesutils.RequiredArtifactProps{
+	"download": servicesutils.SYMLINK,
+	"search":   servicesutils.ALL,
+}
+
+// Returns a description of what each File Spec group does when used by a command.
+// For upload, this is the walk on the local file system. For the other commands, this is the AQL query sent to Artifactory.
+func ExplainSpec(specFiles *speccore.SpecFiles, command string) (string, error) {
+	rules, err := getCommandRules(command)
+	if err != nil {
+		return "", err
+	}
+	if rules == nil {
+		return "", errorutils.CheckErrorf("a command must be provided in order to explain a spec")
+	}
+	var explanation strings.Builder
+	for i := 0; i < len(specFiles.Files); i++ {
+		var groupExplanation string
+		if rules.isUpload {
+			groupExplanation, err = explainFileSystemWalk(specFiles.Get(i))
+		} else {
+			groupExplanation, err = explainSearch(specFiles.Get(i), command)
+		}
+		if err != nil {
+			return "", err
+		}
+		explanation.WriteString(fmt.Sprintf("File group %d:\n%s", i+1, groupExplanation))
+	}
+	return strings.TrimSuffix(explanation.String(), "\n"), nil
+}
+
+// Explains which local files are collected by an upload, the same way the upload service of the client collects them.
+func explainFileSystemWalk(file *speccore.File) (string, error) {
+	params, err := file.ToCommonParams()
+	if err != nil {
+		return "", err
+	}
+	if params.Recursive, err = file.IsRecursive(true); err != nil {
+		return "", err
+	}
+	if params.IncludeDirs, err = file.IsIncludeDirs(false); err != nil {
+		return "", err
+	}
+	flat, err := file.IsFlat(true)
+	if err != nil {
+		return "", err
+	}
+	params.Regexp = file.Regexp == "true"
+	params.Ant = file.Ant == "true"
+	patternType := params.GetPatternType()
+	pattern := clientutils.ReplaceTildeWithUserHome(params.Pattern)
+	rootPath := clientutils.GetRootPath(pattern, patternType, clientutils.NewParenthesesSlice(pattern, params.Target))
+
+	var explanation strings.Builder
+	writeField(&explanation, "Pattern", params.Pattern)
+	writeField(&explanation, "Pattern type", string(patternType))
+	writeField(&explanation, "Walk root", rootPath)
+	writeField(&explanation, "Recursive", fmt.Sprint(params.Recursive))
+	writeField(&explanation, "Include directories", fmt.Sprint(params.IncludeDirs))
+	writeField(&explanation, "Path regexp", clientutils.ConvertLocalPatternToRegexp(pattern, patternType))
+	if excludePattern := prepareExcludePathPattern(params); excludePattern != "" {
+		writeField(&explanation, "Exclusions regexp", excludePattern)
+	}
+	writeField(&explanation, "Target", params.Target)
+	writeField(&explanation, "Flat", fmt.Sprint(flat))
+	return explanation.String(), nil
+}
+
+// Returns the regexp of the excluded local paths, the same way fspatterns.PrepareExcludePathPattern does.
+// It isn't used directly since it requires upload params.
+func prepareExcludePathPattern(params *servicesutils.CommonParams) string {
+	var exclusions []string
+	for _, exclusion := range params.Exclusions {
+		if exclusion == "" {
+			continue
+		}
+		exclusion = clientutils.ConvertLocalPatternToRegexp(clientutils.ReplaceTildeWithUserHome(exclusion), params.GetPatternType())
+		if params.Recursive && strings.HasSuffix(exclusion, fileutils.GetFileSeparator()) {
+			exclusion += "*"
+		}
+		exclusions = append(exclusions, "("+exclusion+")")
+	}
+	return strings.Join(exclusions, "|")
+}
+
+// Explains the AQL query sent to Artifactory by a search based command, the same way the search utilities of the client create it.
+func explainSearch(file *speccore.File, command string) (string, error) {
+	params, err := file.ToCommonParams()
+	if err != nil {
+		return "", err
+	}
+	params.Pattern = strings.TrimPrefix(params.Pattern, "/")
+	if params.Recursive, err = file.IsRecursive(true); err != nil {
+		return "", err
+	}
+	if params.IncludeDirs, err = file.IsIncludeDirs(false); err != nil {
+		return "", err
+	}
+	if params.ExcludeArtifacts, err = file.IsExcludeArtifacts(false); err != nil {
+		return "", err
+	}
+	if params.IncludeDeps, err = file.IsIncludeDeps(false); err != nil {
+		return "", err
+	}
+
+	var explanation strings.Builder
+	switch params.GetSpecType() {
+	case servicesutils.BUILD:
+		writeField(&explanation, "Build", params.Build)
+		writeField(&explanation, "Query", "the artifacts and dependencies of the build are found by their checksums, after the build number is resolved by Artifactory")
+		return explanation.String(), nil
+	case servicesutils.AQL:
+		writeField(&explanation, "AQL", params.Aql.ItemsFind)
+	default:
+		writeField(&explanation, "Pattern", params.Pattern)
+		if params.Aql.ItemsFind, err = servicesutils.CreateAqlBodyForSpecWithPattern(params); err != nil {
+			return "", err
+		}
+	}
+	writeField(&explanation, "Query", servicesutils.BuildQueryFromSpecFile(params, getRequiredProps(command)))
+	if params.Build != "" {
+		writeField(&explanation, "Build", params.Build+" (the results are filtered by the artifacts of the build)")
+	}
+	return explanation.String(), nil
+}
+
+func getRequiredProps(command string) servicesutils.RequiredArtifactProps {
+	if requiredProps, ok := commandsRequiredProps[command]; ok {
+		return requiredProps
+	}
+	return servicesutils.NONE
+}
+
+func writeField(explanation *strings.Builder, name, value string) {
+	explanation.WriteString(fmt.Sprintf("  %s: %s\n", name, value))
+}
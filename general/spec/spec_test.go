@@ -0,0 +1,91 @@
+package spec
+
+import (
+	"testing"
+
+	speccore "github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateSpec(t *testing.T) {
+	content := `{
+  "files": [
+    {
+      "pattern": "repo/a/*.zip",
+      "flat": "yes"
+    },
+    {
+      "pattern": "repo/b/*.zip",
+      "target": "repo2/",
+      "aql": {"items.find": {"repo": "repo"}}
+    }
+  ]
+}`
+	issues, err := ValidateSpec([]byte(content), "copy")
+	assert.NoError(t, err)
+	expected := []Issue{
+		{Line: 5, Column: 15, Message: `files.0.flat must be one of the following: "true", "false"`},
+		{Line: 7, Column: 5, Message: "files.1: Must not validate the schema (not)"},
+		{Line: 3, Column: 5, Message: "Spec must include target."},
+		{Line: 7, Column: 5, Message: "Spec cannot include both 'aql' and 'pattern.'"},
+	}
+	assert.Equal(t, expected, issues)
+
+	// Without a command, only the schema is checked.
+	issues, err = ValidateSpec([]byte(content), "")
+	assert.NoError(t, err)
+	assert.Len(t, issues, 2)
+
+	_, err = ValidateSpec([]byte(content), "build-publish")
+	assert.Error(t, err)
+}
+
+func TestValidateSpecSyntaxError(t *testing.T) {
+	issues, err := ValidateSpec([]byte("{\n  \"files\": [\n    {\"pattern\": \"a\",}\n  ]\n}"), "download")
+	assert.NoError(t, err)
+	if assert.Len(t, issues, 1) {
+		assert.Equal(t, 3, issues[0].Line)
+		assert.Equal(t, 21, issues[0].Column)
+	}
+}
+
+func TestValidateValidSpec(t *testing.T) {
+	issues, err := ValidateSpec([]byte(`{"files": [{"pattern": "a/*.zip", "target": "repo/"}]}`), "upload")
+	assert.NoError(t, err)
+	assert.Empty(t, issues)
+}
+
+func TestValidateArchiveSpec(t *testing.T) {
+	for _, archive := range []string{"zip", "tar", "tar.gz", "tar.zst"} {
+		issues, err := ValidateSpec([]byte(`{"files": [{"pattern": "a/*", "target": "repo/a.archive", "archive": "`+archive+`"}]}`), "upload")
+		assert.NoError(t, err)
+		assert.Empty(t, issues, archive)
+	}
+	issues, err := ValidateSpec([]byte(`{"files": [{"pattern": "a/*", "target": "repo/a.7z", "archive": "7z"}]}`), "upload")
+	assert.NoError(t, err)
+	assert.NotEmpty(t, issues)
+}
+
+func TestExplainSpec(t *testing.T) {
+	specFiles := &speccore.SpecFiles{Files: []speccore.File{
+		{Pattern: "dir/(*).zip", Target: "repo/{1}/", Recursive: "false"},
+	}}
+	explanation, err := ExplainSpec(specFiles, "upload")
+	assert.NoError(t, err)
+	assert.Contains(t, explanation, "  Walk root: dir\n")
+	assert.Contains(t, explanation, "  Recursive: false\n")
+	assert.Contains(t, explanation, `  Path regexp: ^dir/(.*)\.zip$`)
+
+	specFiles = &speccore.SpecFiles{Files: []speccore.File{
+		{Pattern: "/repo/a/*.zip"},
+		{Build: "build/1"},
+	}}
+	explanation, err = ExplainSpec(specFiles, "delete")
+	assert.NoError(t, err)
+	assert.Contains(t, explanation, "File group 1:\n  Pattern: repo/a/*.zip\n  Query: items.find(")
+	assert.Contains(t, explanation, `{"repo":"repo","path":"a","name":{"$match":"*.zip"}}`)
+	assert.Contains(t, explanation, "File group 2:\n  Build: build/1\n")
+
+	_, err = ExplainSpec(specFiles, "")
+	assert.Error(t, err)
+}
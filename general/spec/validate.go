@@ -0,0 +1,190 @@
+package spec
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	speccore "github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/transfer"
+	"github.com/jfrog/jfrog-cli/schema"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// The path of the root of a json document, as reported by the schema validation.
+const rootPath = "(root)"
+
+// The rules of spec.ValidateSpec which apply to the File Specs of a command.
+type specRules struct {
+	isTargetMandatory bool
+	isSearchBasedSpec bool
+	isUpload          bool
+}
+
+var commandsRules = map[string]specRules{
+	"upload":       {isTargetMandatory: true, isUpload: true},
+	"download":     {isSearchBasedSpec: true},
+	"copy":         {isTargetMandatory: true, isSearchBasedSpec: true},
+	"move":         {isTargetMandatory: true, isSearchBasedSpec: true},
+	"delete":       {isSearchBasedSpec: true},
+	"search":       {isSearchBasedSpec: true},
+	"set-props":    {isSearchBasedSpec: true},
+	"delete-props": {isSearchBasedSpec: true},
+}
+
+// An issue found in a File Spec.
+type Issue struct {
+	Line    int
+	Column  int
+	Message string
+}
+
+func (issue Issue) String() string {
+	return fmt.Sprintf("%d:%d: %s", issue.Line, issue.Column, issue.Message)
+}
+
+// Validates the content of a File Spec against the File Spec schema.
+// If a command is provided, the File Spec is also validated against the rules of the command.
+func ValidateSpec(content []byte, command string) ([]Issue, error) {
+	rules, err := getCommandRules(command)
+	if err != nil {
+		return nil, err
+	}
+	var document interface{}
+	if err = json.Unmarshal(content, &document); err != nil {
+		if syntaxErr, ok := err.(*json.SyntaxError); ok {
+			return []Issue{createIssue(content, int(syntaxErr.Offset)-1, syntaxErr.Error())}, nil
+		}
+		return nil, errorutils.CheckError(err)
+	}
+	offsets, err := getValuesOffsets(content)
+	if err != nil {
+		return nil, err
+	}
+
+	var issues []Issue
+	result, err := gojsonschema.Validate(gojsonschema.NewStringLoader(schema.FileSpecSchema), gojsonschema.NewBytesLoader(content))
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	for _, resultError := range result.Errors() {
+		message := resultError.Description()
+		if !strings.HasPrefix(message, resultError.Field()) {
+			message = resultError.Field() + ": " + message
+		}
+		issues = append(issues, createIssue(content, offsets[resultError.Field()], message))
+	}
+	if rules == nil {
+		return issues, nil
+	}
+
+	specFiles := new(speccore.SpecFiles)
+	if err = json.Unmarshal(content, specFiles); err != nil {
+		if typeErr, ok := err.(*json.UnmarshalTypeError); ok {
+			return append(issues, createIssue(content, int(typeErr.Offset)-1, typeErr.Error())), nil
+		}
+		return nil, errorutils.CheckError(err)
+	}
+	// The File Spec groups are validated one by one, so that each issue can be located.
+	for i, file := range specFiles.Files {
+		if rules.isUpload {
+			// The upload spec is validated by the rules of the upload command, which supports the tar archives as well.
+			err = transfer.ValidateUploadSpec(&speccore.SpecFiles{Files: []speccore.File{file}})
+		} else {
+			err = speccore.ValidateSpec([]speccore.File{file}, rules.isTargetMandatory, rules.isSearchBasedSpec, rules.isUpload)
+		}
+		if err != nil {
+			path := joinPath(joinPath(rootPath, "files"), strconv.Itoa(i))
+			issues = append(issues, createIssue(content, offsets[path], err.Error()))
+		}
+	}
+	return issues, nil
+}
+
+// Returns the rules of a command, or nil if no command is provided.
+func getCommandRules(command string) (*specRules, error) {
+	if command == "" {
+		return nil, nil
+	}
+	rules, ok := commandsRules[command]
+	if !ok {
+		return nil, errorutils.CheckErrorf("the '%s' command doesn't use File Specs", command)
+	}
+	return &rules, nil
+}
+
+func createIssue(content []byte, offset int, message string) Issue {
+	line, column := getPosition(content, offset)
+	return Issue{Line: line, Column: column, Message: message}
+}
+
+// Returns the line and the column of an offset in the content. Both start from 1.
+func getPosition(content []byte, offset int) (line, column int) {
+	if offset < 0 {
+		offset = 0
+	}
+	if offset > len(content) {
+		offset = len(content)
+	}
+	line = bytes.Count(content[:offset], []byte("\n")) + 1
+	column = offset - bytes.LastIndexByte(content[:offset], '\n')
+	return
+}
+
+// Maps the path of each value in a json document, such as (root).files.0.pattern, to the offset in which the value starts.
+func getValuesOffsets(content []byte) (map[string]int, error) {
+	offsets := make(map[string]int)
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	if err := readValuesOffsets(decoder, content, rootPath, offsets); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return offsets, nil
+}
+
+func readValuesOffsets(decoder *json.Decoder, content []byte, path string, offsets map[string]int) error {
+	offsets[path] = skipSeparators(content, int(decoder.InputOffset()))
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	switch token {
+	case json.Delim('{'):
+		for decoder.More() {
+			key, err := decoder.Token()
+			if err != nil {
+				return err
+			}
+			if err = readValuesOffsets(decoder, content, joinPath(path, key.(string)), offsets); err != nil {
+				return err
+			}
+		}
+		_, err = decoder.Token()
+	case json.Delim('['):
+		for i := 0; decoder.More(); i++ {
+			if err = readValuesOffsets(decoder, content, joinPath(path, strconv.Itoa(i)), offsets); err != nil {
+				return err
+			}
+		}
+		_, err = decoder.Token()
+	}
+	return err
+}
+
+// Returns the offset of the first character from the provided offset, which isn't a whitespace or a separator between json values.
+func skipSeparators(content []byte, offset int) int {
+	for offset < len(content) && bytes.IndexByte([]byte(" \t\r\n,:"), content[offset]) >= 0 {
+		offset++
+	}
+	return offset
+}
+
+// Joins paths the same way the schema validation does.
+func joinPath(parent, child string) string {
+	if parent == rootPath {
+		return child
+	}
+	return parent + "." + child
+}
@@ -3,6 +3,7 @@ module github.com/jfrog/jfrog-cli
 go 1.14
 
 require (
+	github.com/ProtonMail/go-crypto v0.0.0-20210428141323-04723f9f07d7
 	github.com/buger/jsonparser v1.1.1
 	github.com/frankban/quicktest v1.13.1 // indirect
 	github.com/go-git/go-git/v5 v5.4.2
@@ -12,7 +13,9 @@ require (
 	github.com/jfrog/jfrog-cli-core/v2 v2.8.0
 	github.com/jfrog/jfrog-client-go v1.7.0
 	github.com/jszwec/csvutil v1.4.0
+	github.com/klauspost/compress v1.11.4
 	github.com/mholt/archiver v2.1.0+incompatible
+	github.com/mholt/archiver/v3 v3.5.1-0.20210618180617-81fac4ba96e4
 	github.com/pierrec/lz4 v2.6.1+incompatible // indirect
 	github.com/pkg/errors v0.9.1
 	github.com/stretchr/testify v1.7.0
@@ -13,9 +13,11 @@ import (
 	"github.com/jfrog/jfrog-cli/config"
 	"github.com/jfrog/jfrog-cli/docs/common"
 	"github.com/jfrog/jfrog-cli/docs/general/cisetup"
+	"github.com/jfrog/jfrog-cli/general/cache"
 	cisetupcommand "github.com/jfrog/jfrog-cli/general/cisetup"
 	"github.com/jfrog/jfrog-cli/general/envsetup"
 	"github.com/jfrog/jfrog-cli/general/project"
+	"github.com/jfrog/jfrog-cli/general/spec"
 	"github.com/jfrog/jfrog-cli/plugins"
 	"github.com/jfrog/jfrog-cli/plugins/utils"
 
@@ -141,6 +143,18 @@ func getCommands() []cli.Command {
 			Subcommands: project.GetCommands(),
 			Category:    otherCategory,
 		},
+		{
+			Name:        cliutils.CmdSpec,
+			Description: "File Spec commands",
+			Subcommands: spec.GetCommands(),
+			Category:    otherCategory,
+		},
+		{
+			Name:        cliutils.CmdCache,
+			Description: "Download cache commands",
+			Subcommands: cache.GetCommands(),
+			Category:    otherCategory,
+		},
 		{
 			Name:         "ci-setup",
 			Usage:        cisetup.GetDescription(),
@@ -17,6 +17,7 @@ import (
 	"github.com/jfrog/jfrog-cli-core/v2/xray/commands/audit/npm"
 	"github.com/jfrog/jfrog-cli-core/v2/xray/commands/audit/python"
 	"github.com/jfrog/jfrog-cli-core/v2/xray/commands/scan"
+	xrutils "github.com/jfrog/jfrog-cli-core/v2/xray/utils"
 	"github.com/jfrog/jfrog-cli/docs/common"
 	auditdocs "github.com/jfrog/jfrog-cli/docs/scan/audit"
 	auditgodocs "github.com/jfrog/jfrog-cli/docs/scan/auditgo"
@@ -28,6 +29,7 @@ import (
 	buildscandocs "github.com/jfrog/jfrog-cli/docs/scan/buildscan"
 	scandocs "github.com/jfrog/jfrog-cli/docs/scan/scan"
 	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-cli/utils/summary"
 	"github.com/urfave/cli"
 
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
@@ -192,7 +194,7 @@ func AuditMvnCmd(c *cli.Context) error {
 		return err
 	}
 	xrAuditMvnCmd := java.NewAuditMavenCommand(*genericAuditCmd).SetInsecureTls(c.Bool(cliutils.InsecureTls))
-	return commands.Exec(xrAuditMvnCmd)
+	return execAuditCmd(c, xrAuditMvnCmd)
 }
 
 func AuditGradleCmd(c *cli.Context) error {
@@ -201,7 +203,7 @@ func AuditGradleCmd(c *cli.Context) error {
 		return err
 	}
 	xrAuditGradleCmd := java.NewAuditGradleCommand(*genericAuditCmd).SetExcludeTestDeps(c.Bool(cliutils.ExcludeTestDeps)).SetUseWrapper(c.Bool(cliutils.UseWrapper))
-	return commands.Exec(xrAuditGradleCmd)
+	return execAuditCmd(c, xrAuditGradleCmd)
 }
 
 func AuditNpmCmd(c *cli.Context) error {
@@ -217,7 +219,7 @@ func AuditNpmCmd(c *cli.Context) error {
 		typeRestriction = npmutils.ProdOnly
 	}
 	auditNpmCmd := npm.NewAuditNpmCommand(*genericAuditCmd).SetNpmTypeRestriction(typeRestriction)
-	return commands.Exec(auditNpmCmd)
+	return execAuditCmd(c, auditNpmCmd)
 }
 
 func AuditGoCmd(c *cli.Context) error {
@@ -226,7 +228,7 @@ func AuditGoCmd(c *cli.Context) error {
 		return err
 	}
 	auditGoCmd := _go.NewAuditGoCommand(*genericAuditCmd)
-	return commands.Exec(auditGoCmd)
+	return execAuditCmd(c, auditGoCmd)
 }
 
 func AuditPipCmd(c *cli.Context) error {
@@ -235,7 +237,7 @@ func AuditPipCmd(c *cli.Context) error {
 		return err
 	}
 	auditPipCmd := python.NewAuditPipCommand(*genericAuditCmd)
-	return commands.Exec(auditPipCmd)
+	return execAuditCmd(c, auditPipCmd)
 }
 
 func AuditPipenvCmd(c *cli.Context) error {
@@ -244,7 +246,7 @@ func AuditPipenvCmd(c *cli.Context) error {
 		return err
 	}
 	auditPipenvCmd := python.NewAuditPipenvCommand(*genericAuditCmd)
-	return commands.Exec(auditPipenvCmd)
+	return execAuditCmd(c, auditPipenvCmd)
 }
 
 func createGenericAuditCmd(c *cli.Context) (*audit.AuditCommand, error) {
@@ -261,6 +263,17 @@ func createGenericAuditCmd(c *cli.Context) (*audit.AuditCommand, error) {
 	if err != nil {
 		return nil, err
 	}
+	summaryFormat, err := cliutils.GetSummaryFormat(c)
+	if err != nil {
+		return nil, err
+	}
+	// The results are printed as json, so that they can be parsed and printed in the summary format.
+	if summaryFormat != summary.Json {
+		if c.IsSet("format") && format != xrutils.Json {
+			return nil, cliutils.PrintHelpAndReturnError("The --format option cannot be set to '"+c.String("format")+"' together with the --summary-format option, which prints the results in its own format.", c)
+		}
+		format = xrutils.Json
+	}
 
 	auditCmd.SetServerDetails(serverDetails).
 		SetOutputFormat(format).
@@ -0,0 +1,120 @@
+package scan
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"sort"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/commands"
+	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-cli/utils/summary"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/xray/services"
+	"github.com/urfave/cli"
+)
+
+// Runs an audit command, and prints its results in the format set by the --summary-format option.
+func execAuditCmd(c *cli.Context, auditCmd commands.Command) error {
+	summaryFormat, err := cliutils.GetSummaryFormat(c)
+	if err != nil {
+		return err
+	}
+	if summaryFormat == summary.Json {
+		return commands.Exec(auditCmd)
+	}
+	// The audit commands print their results to the standard output rather than returning them.
+	// When a summary format is set, the results are printed as json (see createGenericAuditCmd), so they are captured and parsed here.
+	output, err := execAndCaptureStdout(func() error {
+		return commands.Exec(auditCmd)
+	})
+	results, precedingOutput, parseErr := parseScanResults(output)
+	// Anything else printed by the command, such as the output of the package manager, shouldn't be mixed with the summary.
+	os.Stderr.Write(precedingOutput)
+	if parseErr != nil {
+		if err != nil {
+			return err
+		}
+		return parseErr
+	}
+	report := summary.NewScanReport(auditCmd.CommandName(), len(results), getScanFindings(results), err)
+	return cliutils.PrintReport(summaryFormat, report, err)
+}
+
+// Runs exec while the standard output is redirected to a temporary file, and returns the content of the file.
+func execAndCaptureStdout(exec func() error) (output []byte, err error) {
+	outputFile, err := fileutils.CreateTempFile()
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if e := os.Remove(outputFile.Name()); err == nil {
+			err = errorutils.CheckError(e)
+		}
+	}()
+	stdout := os.Stdout
+	os.Stdout = outputFile
+	err = exec()
+	os.Stdout = stdout
+	if e := outputFile.Close(); err == nil {
+		err = errorutils.CheckError(e)
+	}
+	output, e := ioutil.ReadFile(outputFile.Name())
+	if err == nil {
+		err = errorutils.CheckError(e)
+	}
+	return
+}
+
+// Splits the output of an audit command into the scan results, which are printed as an indented json array at its end,
+// and the output which precedes them.
+func parseScanResults(output []byte) (results []services.ScanResponse, precedingOutput []byte, err error) {
+	start := 0
+	if !bytes.HasPrefix(output, []byte("[\n")) {
+		start = bytes.LastIndex(output, []byte("\n[\n")) + 1
+		if start == 0 {
+			return nil, output, errorutils.CheckErrorf("no scan results were found in the output of the command")
+		}
+	}
+	if err = json.Unmarshal(output[start:], &results); err != nil {
+		return nil, output, errorutils.CheckError(err)
+	}
+	return results, output[:start], nil
+}
+
+// Returns a finding for every component affected by a violation or a vulnerability.
+func getScanFindings(results []services.ScanResponse) []summary.Finding {
+	var findings []summary.Finding
+	for _, result := range results {
+		for _, violation := range result.Violations {
+			id := violation.IssueId
+			if violation.LicenseKey != "" {
+				id = violation.LicenseKey
+			}
+			findings = appendScanFindings(findings, id, violation.Severity, violation.Summary, violation.Components)
+		}
+		for _, vulnerability := range result.Vulnerabilities {
+			id := vulnerability.IssueId
+			if len(vulnerability.Cves) > 0 && vulnerability.Cves[0].Id != "" {
+				id = vulnerability.Cves[0].Id
+			}
+			findings = appendScanFindings(findings, id, vulnerability.Severity, vulnerability.Summary, vulnerability.Components)
+		}
+	}
+	return findings
+}
+
+func appendScanFindings(findings []summary.Finding, id, severity, issueSummary string, components map[string]services.Component) []summary.Finding {
+	// The components are sorted, so that the order of the findings is stable.
+	var componentIds []string
+	for componentId := range components {
+		componentIds = append(componentIds, componentId)
+	}
+	sort.Strings(componentIds)
+	for _, componentId := range componentIds {
+		findings = append(findings, summary.Finding{Id: id, Severity: severity, Summary: issueSummary, Component: componentId})
+	}
+	return findings
+}
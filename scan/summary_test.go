@@ -0,0 +1,43 @@
+package scan
+
+import (
+	"testing"
+
+	"github.com/jfrog/jfrog-client-go/xray/services"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseScanResults(t *testing.T) {
+	output := "[INFO] BUILD SUCCESS\n[\n  {\n    \"scan_id\": \"1\"\n  }\n]\n"
+	results, precedingOutput, err := parseScanResults([]byte(output))
+	assert.NoError(t, err)
+	assert.Equal(t, []services.ScanResponse{{ScanId: "1"}}, results)
+	assert.Equal(t, "[INFO] BUILD SUCCESS\n", string(precedingOutput))
+
+	results, precedingOutput, err = parseScanResults([]byte("[\n]\n"))
+	assert.NoError(t, err)
+	assert.Empty(t, results)
+	assert.Empty(t, precedingOutput)
+
+	_, _, err = parseScanResults([]byte("[INFO] BUILD FAILURE\n"))
+	assert.Error(t, err)
+}
+
+func TestGetScanFindings(t *testing.T) {
+	results := []services.ScanResponse{{
+		Violations: []services.Violation{
+			{IssueId: "XRAY-1", Severity: "High", Summary: "license", LicenseKey: "GPL-3.0", Components: map[string]services.Component{"npm://b:1": {}}},
+		},
+		Vulnerabilities: []services.Vulnerability{
+			{IssueId: "XRAY-2", Severity: "Low", Summary: "vulnerability", Cves: []services.Cve{{Id: "CVE-2021-2"}},
+				Components: map[string]services.Component{"npm://d:1": {}, "npm://c:1": {}}},
+		},
+	}}
+	findings := getScanFindings(results)
+	if assert.Len(t, findings, 3) {
+		assert.Equal(t, "GPL-3.0", findings[0].Id)
+		assert.Equal(t, "CVE-2021-2", findings[1].Id)
+		assert.Equal(t, "npm://c:1", findings[1].Component)
+		assert.Equal(t, "npm://d:1", findings[2].Component)
+	}
+}
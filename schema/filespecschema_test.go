@@ -33,3 +33,9 @@ func TestFileSpecSchema(t *testing.T) {
 			return nil
 		}))
 }
+
+func TestFileSpecSchemaContent(t *testing.T) {
+	schema, err := ioutil.ReadFile("filespec-schema.json")
+	assert.NoError(t, err)
+	assert.Equal(t, string(schema), FileSpecSchema, "FileSpecSchema doesn't match filespec-schema.json")
+}
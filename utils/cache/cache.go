@@ -0,0 +1,291 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	// Enables the download cache, and sets its directory.
+	DownloadCacheDirEnv = "JFROG_CLI_DOWNLOAD_CACHE_DIR"
+	// The maximum size of the download cache, such as 500MB or 10GB.
+	DownloadCacheMaxSizeEnv = "JFROG_CLI_DOWNLOAD_CACHE_MAX_SIZE"
+	DefaultMaxSize          = int64(10 << 30)
+
+	downloadCacheDirName = "download-cache"
+	tempFilePattern      = "tmp-*"
+)
+
+var (
+	sha256Regexp = regexp.MustCompile(`^[0-9a-f]{64}$`)
+	shardRegexp  = regexp.MustCompile(`^[0-9a-f]{2}$`)
+)
+
+// A local cache of downloaded files, keyed by their sha256 checksum.
+// Each file is stored under <cache dir>/<first two characters of the checksum>/<checksum>.
+// Files are copied into and out of the cache, so that removing an entry frees its space, and the size of the cache is its actual disk usage.
+// The modification time of an entry is updated whenever it's used, so that the least recently used entries are evicted first.
+type Cache struct {
+	dir     string
+	maxSize int64
+}
+
+func NewCache(dir string, maxSize int64) *Cache {
+	return &Cache{dir: dir, maxSize: maxSize}
+}
+
+// Returns the download cache, configured by the JFROG_CLI_DOWNLOAD_CACHE_DIR and JFROG_CLI_DOWNLOAD_CACHE_MAX_SIZE environment variables.
+// The cache is stored under the JFrog home directory by default.
+func GetDownloadCache() (*Cache, error) {
+	dir := os.Getenv(DownloadCacheDirEnv)
+	if dir == "" {
+		homeDir, err := coreutils.GetJfrogHomeDir()
+		if err != nil {
+			return nil, err
+		}
+		dir = filepath.Join(homeDir, downloadCacheDirName)
+	}
+	maxSize := DefaultMaxSize
+	if value := os.Getenv(DownloadCacheMaxSizeEnv); value != "" {
+		var err error
+		if maxSize, err = cliutils.ParseSize(value); err != nil {
+			return nil, err
+		}
+	}
+	return NewCache(dir, maxSize), nil
+}
+
+// Returns true if the download cache is enabled by the JFROG_CLI_DOWNLOAD_CACHE_DIR environment variable.
+func IsDownloadCacheEnabledByEnv() bool {
+	return os.Getenv(DownloadCacheDirEnv) != ""
+}
+
+func (c *Cache) Dir() string {
+	return c.dir
+}
+
+func (c *Cache) MaxSize() int64 {
+	return c.maxSize
+}
+
+func (c *Cache) entryPath(checksum string) string {
+	return filepath.Join(c.dir, checksum[:2], checksum)
+}
+
+// Places the cached file with the provided checksum at the target path.
+// Returns false if the cache doesn't include the file.
+func (c *Cache) Get(checksum, targetPath string) (bool, error) {
+	if !sha256Regexp.MatchString(checksum) {
+		return false, nil
+	}
+	entryPath := c.entryPath(checksum)
+	if _, err := os.Stat(entryPath); err != nil {
+		return false, nil
+	}
+	// The cache directory may be shared with other processes and tools, so entries are verified before they are used.
+	actual, err := CalcSha256(entryPath)
+	if err != nil {
+		return false, err
+	}
+	if actual != checksum {
+		log.Debug("Removing the modified download cache entry " + entryPath)
+		return false, errorutils.CheckError(os.Remove(entryPath))
+	}
+	if err = os.MkdirAll(filepath.Dir(targetPath), 0777); errorutils.CheckError(err) != nil {
+		return false, err
+	}
+	if err = copyFile(entryPath, targetPath); err != nil {
+		return false, err
+	}
+	now := time.Now()
+	return true, errorutils.CheckError(os.Chtimes(entryPath, now, now))
+}
+
+// Adds the file at the provided path to the cache, if its content matches the provided checksum.
+func (c *Cache) Put(checksum, path string) error {
+	if !sha256Regexp.MatchString(checksum) {
+		return nil
+	}
+	entryPath := c.entryPath(checksum)
+	if _, err := os.Stat(entryPath); err == nil {
+		return nil
+	}
+	actual, err := CalcSha256(path)
+	if err != nil {
+		return err
+	}
+	if actual != checksum {
+		log.Debug("Not adding " + path + " to the download cache, since its checksum doesn't match.")
+		return nil
+	}
+	entryDir := filepath.Dir(entryPath)
+	if err = os.MkdirAll(entryDir, 0777); errorutils.CheckError(err) != nil {
+		return err
+	}
+	// The entry is created under a temporary name and then renamed, so that other processes never use a partially written entry.
+	tempFile, err := ioutil.TempFile(entryDir, tempFilePattern)
+	if errorutils.CheckError(err) != nil {
+		return err
+	}
+	tempPath := tempFile.Name()
+	if err = tempFile.Close(); errorutils.CheckError(err) != nil {
+		return err
+	}
+	defer os.Remove(tempPath)
+	if err = copyFile(path, tempPath); err != nil {
+		return err
+	}
+	return errorutils.CheckError(os.Rename(tempPath, entryPath))
+}
+
+type Stats struct {
+	Dir     string `json:"dir"`
+	Files   int    `json:"files"`
+	Size    int64  `json:"size"`
+	MaxSize int64  `json:"maxSize"`
+}
+
+func (c *Cache) Stats() (*Stats, error) {
+	entries, err := c.listEntries()
+	if err != nil {
+		return nil, err
+	}
+	stats := &Stats{Dir: c.dir, Files: len(entries), MaxSize: c.maxSize}
+	for _, entry := range entries {
+		stats.Size += entry.size
+	}
+	return stats, nil
+}
+
+// Removes the least recently used entries, until the size of the cache doesn't exceed the provided size.
+// Returns the number of removed files and their total size.
+func (c *Cache) Prune(maxSize int64) (files int, size int64, err error) {
+	entries, err := c.listEntries()
+	if err != nil {
+		return
+	}
+	var totalSize int64
+	for _, entry := range entries {
+		totalSize += entry.size
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].modTime.Before(entries[j].modTime)
+	})
+	for _, entry := range entries {
+		if totalSize <= maxSize {
+			break
+		}
+		if err = os.Remove(entry.path); err != nil && !os.IsNotExist(err) {
+			err = errorutils.CheckError(err)
+			return
+		}
+		err = nil
+		totalSize -= entry.size
+		files++
+		size += entry.size
+	}
+	return
+}
+
+// Removes all entries of the cache, and the shard directories which are left empty.
+// Other files in the cache directory are kept, since the directory may be shared with other tools.
+// Returns the number of removed files and their total size.
+func (c *Cache) Clear() (files int, size int64, err error) {
+	entries, err := c.listEntries()
+	if err != nil {
+		return
+	}
+	shardDirs := make(map[string]bool)
+	for _, entry := range entries {
+		if err = os.Remove(entry.path); err != nil && !os.IsNotExist(err) {
+			err = errorutils.CheckError(err)
+			return
+		}
+		err = nil
+		shardDirs[filepath.Dir(entry.path)] = true
+		files++
+		size += entry.size
+	}
+	for shardDir := range shardDirs {
+		// A shard directory which includes other files isn't empty, so it isn't removed.
+		if e := os.Remove(shardDir); e != nil && !os.IsNotExist(e) {
+			log.Debug("Keeping the download cache directory " + shardDir + ": " + e.Error())
+		}
+	}
+	return
+}
+
+type cacheEntry struct {
+	path    string
+	size    int64
+	modTime time.Time
+}
+
+// Returns the entries of the cache. Only files which are stored according to the layout of the cache are considered entries.
+func (c *Cache) listEntries() ([]*cacheEntry, error) {
+	var entries []*cacheEntry
+	root := filepath.Clean(c.dir)
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		parent := filepath.Dir(path)
+		if info.IsDir() {
+			if path != root && (parent != root || !shardRegexp.MatchString(info.Name())) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if info.Mode().IsRegular() && sha256Regexp.MatchString(info.Name()) && filepath.Base(parent) == info.Name()[:2] {
+			entries = append(entries, &cacheEntry{path: path, size: info.Size(), modTime: info.ModTime()})
+		}
+		return nil
+	})
+	return entries, errorutils.CheckError(err)
+}
+
+// The checksums calculated by the client don't include sha256, which is the checksum Artifactory reports for each file.
+func CalcSha256(path string) (string, error) {
+	file, err := os.Open(path)
+	if errorutils.CheckError(err) != nil {
+		return "", err
+	}
+	defer file.Close()
+	hash := sha256.New()
+	if _, err = io.Copy(hash, file); errorutils.CheckError(err) != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func copyFile(src, dst string) error {
+	source, err := os.Open(src)
+	if errorutils.CheckError(err) != nil {
+		return err
+	}
+	defer source.Close()
+	target, err := os.Create(dst)
+	if errorutils.CheckError(err) != nil {
+		return err
+	}
+	_, err = io.Copy(target, source)
+	if e := target.Close(); err == nil {
+		err = e
+	}
+	return errorutils.CheckError(err)
+}
@@ -0,0 +1,116 @@
+package cache
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	coretests "github.com/jfrog/jfrog-cli-core/v2/utils/tests"
+	"github.com/stretchr/testify/assert"
+)
+
+func createFile(t *testing.T, path, content string) string {
+	assert.NoError(t, ioutil.WriteFile(path, []byte(content), 0644))
+	checksum, err := CalcSha256(path)
+	assert.NoError(t, err)
+	return checksum
+}
+
+func TestPutAndGet(t *testing.T) {
+	tempDirPath, createTempDirCallback := coretests.CreateTempDirWithCallbackAndAssert(t)
+	defer createTempDirCallback()
+	cache := NewCache(filepath.Join(tempDirPath, "cache"), DefaultMaxSize)
+	downloadedPath := filepath.Join(tempDirPath, "a.txt")
+	checksum := createFile(t, downloadedPath, "content")
+
+	// Files whose checksum doesn't match aren't cached.
+	assert.NoError(t, cache.Put(checksum[1:]+"0", downloadedPath))
+	assert.NoError(t, cache.Put(checksum, downloadedPath))
+	stats, err := cache.Stats()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, stats.Files)
+	assert.Equal(t, int64(7), stats.Size)
+
+	targetPath := filepath.Join(tempDirPath, "target", "b.txt")
+	hit, err := cache.Get(checksum, targetPath)
+	assert.NoError(t, err)
+	assert.True(t, hit)
+	content, err := ioutil.ReadFile(targetPath)
+	assert.NoError(t, err)
+	assert.Equal(t, "content", string(content))
+
+	// The placed files are copies, so modifying them doesn't modify the entries.
+	assert.NoError(t, ioutil.WriteFile(downloadedPath, []byte("modified"), 0644))
+	assert.NoError(t, ioutil.WriteFile(targetPath, []byte("modified"), 0644))
+	content, err = ioutil.ReadFile(cache.entryPath(checksum))
+	assert.NoError(t, err)
+	assert.Equal(t, "content", string(content))
+
+	// An entry which was modified is removed.
+	assert.NoError(t, os.Remove(targetPath))
+	assert.NoError(t, ioutil.WriteFile(cache.entryPath(checksum), []byte("modified"), 0644))
+	hit, err = cache.Get(checksum, targetPath)
+	assert.NoError(t, err)
+	assert.False(t, hit)
+	assert.NoFileExists(t, targetPath)
+	stats, err = cache.Stats()
+	assert.NoError(t, err)
+	assert.Zero(t, stats.Files)
+}
+
+func TestPruneAndClear(t *testing.T) {
+	tempDirPath, createTempDirCallback := coretests.CreateTempDirWithCallbackAndAssert(t)
+	defer createTempDirCallback()
+	cache := NewCache(filepath.Join(tempDirPath, "cache"), DefaultMaxSize)
+	var checksums []string
+	for i, content := range []string{"aaaa", "bbbb", "cccc"} {
+		path := filepath.Join(tempDirPath, content)
+		checksum := createFile(t, path, content)
+		assert.NoError(t, cache.Put(checksum, path))
+		// The first file is the least recently used.
+		modTime := time.Now().Add(time.Duration(i-3) * time.Hour)
+		assert.NoError(t, os.Chtimes(cache.entryPath(checksum), modTime, modTime))
+		checksums = append(checksums, checksum)
+	}
+
+	files, size, err := cache.Prune(8)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, files)
+	assert.Equal(t, int64(4), size)
+	assert.NoFileExists(t, cache.entryPath(checksums[0]))
+	assert.FileExists(t, cache.entryPath(checksums[1]))
+
+	files, size, err = cache.Clear()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, files)
+	assert.Equal(t, int64(8), size)
+	for _, checksum := range checksums[1:] {
+		assert.NoDirExists(t, filepath.Dir(cache.entryPath(checksum)))
+	}
+}
+
+func TestClearKeepsForeignFiles(t *testing.T) {
+	tempDirPath, createTempDirCallback := coretests.CreateTempDirWithCallbackAndAssert(t)
+	defer createTempDirCallback()
+	cache := NewCache(tempDirPath, DefaultMaxSize)
+	path := filepath.Join(tempDirPath, "a.txt")
+	checksum := createFile(t, path, "content")
+	assert.NoError(t, cache.Put(checksum, path))
+	// Files which are named like entries, but aren't stored according to the layout of the cache, aren't entries.
+	foreignDir := filepath.Join(tempDirPath, "data")
+	assert.NoError(t, os.MkdirAll(foreignDir, 0755))
+	foreignEntryPath := filepath.Join(foreignDir, checksum)
+	createFile(t, foreignEntryPath, "content")
+	shardFilePath := filepath.Join(filepath.Dir(cache.entryPath(checksum)), "notes.txt")
+	createFile(t, shardFilePath, "notes")
+
+	files, _, err := cache.Clear()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, files)
+	assert.NoFileExists(t, cache.entryPath(checksum))
+	assert.FileExists(t, path)
+	assert.FileExists(t, foreignEntryPath)
+	assert.FileExists(t, shardFilePath)
+}
@@ -15,12 +15,20 @@ const (
 	CmdConfig         = "config"
 	CmdOptions        = "options"
 	CmdProject        = "project"
+	CmdSpec           = "spec"
+	CmdCache          = "cache"
 
 	// Download
 	DownloadMinSplitKb    = 5120
 	DownloadSplitCount    = 3
 	DownloadMaxSplitCount = 15
 
+	// Upload
+	UploadMinSplitMb    = 200
+	UploadSplitCount    = 5
+	UploadMaxSplitCount = 100
+	UploadChunkSizeMb   = 20
+
 	// Common
 	Retries             = 3
 	RetryWaitMilliSecs  = 0
@@ -1,15 +1,54 @@
 package cliutils
 
 import (
+	"regexp"
 	"sort"
 	"strconv"
 	"strings"
 
 	"github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
 	"github.com/pkg/errors"
 	"github.com/urfave/cli"
 )
 
+var sizeRegexp = regexp.MustCompile(`^(\d+(?:\.\d+)?)\s*([kmg]?)b?$`)
+
+// Parses a size in bytes, such as 500KB, 10MB or 1GB. A number without a unit is a number of bytes.
+func ParseSize(value string) (int64, error) {
+	matches := sizeRegexp.FindStringSubmatch(strings.ToLower(strings.TrimSpace(value)))
+	if matches == nil {
+		return 0, errorutils.CheckErrorf("'%s' is not a valid size. Examples of valid sizes: 500KB, 10MB or 1GB", value)
+	}
+	size, err := strconv.ParseFloat(matches[1], 64)
+	if err != nil {
+		return 0, errorutils.CheckError(err)
+	}
+	switch matches[2] {
+	case "k":
+		size *= 1 << 10
+	case "m":
+		size *= 1 << 20
+	case "g":
+		size *= 1 << 30
+	}
+	return int64(size), nil
+}
+
+// Formats a size in bytes using the largest unit in which it's at least 1, such as 512B, 1.5MB or 10.0GB.
+func FormatSize(size int64) string {
+	units := []string{"KB", "MB", "GB", "TB"}
+	if size < 1<<10 {
+		return strconv.FormatInt(size, 10) + "B"
+	}
+	value := float64(size) / (1 << 10)
+	unit := 0
+	for ; value >= 1<<10 && unit < len(units)-1; unit++ {
+		value /= 1 << 10
+	}
+	return strconv.FormatFloat(value, 'f', 1, 64) + units[unit]
+}
+
 func GetIntFlagValue(c *cli.Context, flagName string, defValue int) (int, error) {
 	if c.IsSet(flagName) {
 		flagIntVal, err := strconv.Atoi(c.String(flagName))
@@ -18,6 +18,17 @@ const (
 	Delete                 = "delete"
 	Properties             = "properties"
 	Search                 = "search"
+	Sync                   = "sync"
+	ApplyPlan              = "apply-plan"
+	TrashList              = "trash-list"
+	TrashRestore           = "trash-restore"
+	TrashEmpty             = "trash-empty"
+	Du                     = "du"
+	Ls                     = "ls"
+	Verify                 = "verify"
+	BundleExport           = "bundle-export"
+	BundleImport           = "bundle-import"
+	Cleanup                = "cleanup"
 	BuildPublish           = "build-publish"
 	BuildAppend            = "build-append"
 	BuildScanLegacy        = "build-scan-legacy"
@@ -56,15 +67,27 @@ const (
 	RtCurl                 = "rt-curl"
 	TemplateConsumer       = "template-consumer"
 	RepoDelete             = "repo-delete"
+	RepoList               = "repo-list"
+	RepoShow               = "repo-show"
+	RepoApply              = "repo-apply"
 	ReplicationDelete      = "replication-delete"
+	ReplicationList        = "replication-list"
+	ReplicationStatus      = "replication-status"
 	PermissionTargetDelete = "permission-target-delete"
+	PermissionTargetExport = "permission-target-export"
 	AccessTokenCreate      = "access-token-create"
+	AccessTokenList        = "access-token-list"
+	AccessTokenRevoke      = "access-token-revoke"
+	AccessTokenRotate      = "access-token-rotate"
 	UserCreate             = "user-create"
 	UsersCreate            = "users-create"
 	UsersDelete            = "users-delete"
 	GroupCreate            = "group-create"
 	GroupAddUsers          = "group-add-users"
 	GroupDelete            = "group-delete"
+	UsersExport            = "users-export"
+	GroupsExport           = "groups-export"
+	AccessDiff             = "access-diff"
 	passphrase             = "passphrase"
 
 	// Distribution's Command Keys
@@ -103,6 +126,15 @@ const (
 	// Project commands keys
 	InitProject = "project-init"
 
+	// Spec commands keys
+	SpecValidate = "spec-validate"
+	SpecExplain  = "spec-explain"
+
+	// Cache commands keys
+	CacheStats = "cache-stats"
+	CachePrune = "cache-prune"
+	CacheClear = "cache-clear"
+
 	// *** Artifactory Commands' flags ***
 	// Base flags
 	url         = "url"
@@ -164,6 +196,12 @@ const (
 	antFlag          = "ant"
 	fromRt           = "from-rt"
 	transitive       = "transitive"
+	resume           = "resume"
+	events           = "events"
+	summaryFormat    = "summary-format"
+	planFile         = "plan-file"
+	limitRate        = "limit-rate"
+	limitSchedule    = "limit-schedule"
 
 	// Config flags
 	interactive   = "interactive"
@@ -184,6 +222,16 @@ const (
 	deb               = "deb"
 	symlinks          = "symlinks"
 	uploadAnt         = uploadPrefix + antFlag
+	watch             = "watch"
+	watchInterval     = "watch-interval"
+	uploadMinSplit    = uploadPrefix + minSplit
+	uploadSplitCount  = uploadPrefix + splitCount
+	chunkSize         = "chunk-size"
+	uploadSize        = uploadPrefix + "size"
+	checksumFiles     = "checksum-files"
+	signKey           = "sign-key"
+	signPassphrase    = "sign-passphrase"
+	signatureFormat   = "signature-format"
 
 	// Unique download flags
 	downloadPrefix       = "download-"
@@ -193,6 +241,8 @@ const (
 	downloadProps        = downloadPrefix + props
 	downloadExcludeProps = downloadPrefix + excludeProps
 	downloadSyncDeletes  = downloadPrefix + syncDeletes
+	downloadCache        = "cache"
+	verifySignature      = "verify-signature"
 	minSplit             = "min-split"
 	splitCount           = "split-count"
 	validateSymlinks     = "validate-symlinks"
@@ -218,6 +268,83 @@ const (
 	deleteExcludeProps = deletePrefix + excludeProps
 	deleteQuiet        = deletePrefix + quiet
 
+	// Unique sync flags
+	syncPrefix = "sync-"
+	syncDryRun = syncPrefix + dryRun
+	syncQuiet  = syncPrefix + quiet
+	conflict   = "conflict"
+
+	// Unique repo-list flags
+	repoType        = "type"
+	repoPackageType = "package-type"
+
+	// Unique repo-apply flags
+	repoApplyPrefix        = "repo-apply-"
+	repoApplyDryRun        = repoApplyPrefix + dryRun
+	repoApplyQuiet         = repoApplyPrefix + quiet
+	repoApplyDeleteMissing = "delete-missing"
+
+	// Unique replication-list and replication-status flags
+	replicationFormat         = "replication-format"
+	replicationRun            = "run"
+	replicationMaxWaitMinutes = "replication-" + maxWaitMinutes
+
+	// Unique access-diff flags
+	accessDiffPermissionTargetsDir = "permission-targets-dir"
+	accessDiffUsersCsv             = "users-csv"
+	accessDiffGroupsCsv            = "groups-csv"
+	accessDiffFailOnDrift          = "fail-on-drift"
+
+	// Unique trash flags
+	trashPrefix       = "trash-"
+	trashRecursive    = trashPrefix + recursive
+	trashProps        = trashPrefix + props
+	trashExcludeProps = trashPrefix + excludeProps
+	trashQuiet        = trashPrefix + quiet
+
+	// Unique du flags
+	duPrefix       = "du-"
+	duRecursive    = duPrefix + recursive
+	duProps        = duPrefix + props
+	duExcludeProps = duPrefix + excludeProps
+	duDepth        = duPrefix + "depth"
+	duSortBy       = duPrefix + sortBy
+	duSortOrder    = duPrefix + sortOrder
+	duFormat       = duPrefix + "format"
+
+	// Unique ls flags
+	lsPrefix      = "ls-"
+	lsRecursive   = lsPrefix + recursive
+	lsDepth       = lsPrefix + "depth"
+	humanReadable = "human-readable"
+	showProps     = "show-props"
+
+	// Unique verify flags
+	verifyPrefix       = "verify-"
+	verifyRecursive    = verifyPrefix + recursive
+	verifyFlat         = verifyPrefix + flat
+	verifyProps        = verifyPrefix + props
+	verifyExcludeProps = verifyPrefix + excludeProps
+
+	// Unique bundle-export flags
+	bundleExportPrefix       = "bundle-export-"
+	bundleExportRecursive    = bundleExportPrefix + recursive
+	bundleExportProps        = bundleExportPrefix + props
+	bundleExportExcludeProps = bundleExportPrefix + excludeProps
+	includeBuilds            = "include-builds"
+
+	// Unique bundle-import flags
+	bundleImportDryRun = "bundle-import-" + dryRun
+
+	// Unique cleanup flags
+	cleanupPrefix = "cleanup-"
+	cleanupPolicy = "policy"
+	cleanupDryRun = cleanupPrefix + dryRun
+	cleanupQuiet  = cleanupPrefix + quiet
+
+	// Unique apply-plan flags
+	applyPlanQuiet = "apply-plan-" + quiet
+
 	// Unique search flags
 	searchPrefix       = "search-"
 	searchRecursive    = searchPrefix + recursive
@@ -225,6 +352,9 @@ const (
 	searchExcludeProps = searchPrefix + excludeProps
 	count              = "count"
 	searchTransitive   = searchPrefix + transitive
+	searchFormat       = searchPrefix + "format"
+	searchFields       = "fields"
+	searchTemplate     = "template"
 
 	// Unique properties flags
 	propertiesPrefix  = "props-"
@@ -340,12 +470,13 @@ const (
 	vars = "vars"
 
 	// User Management flags
-	csv            = "csv"
-	usersCreateCsv = "users-create-csv"
-	usersDeleteCsv = "users-delete-csv"
-	UsersGroups    = "users-groups"
-	Replace        = "replace"
-	Admin          = "admin"
+	csv              = "csv"
+	usersCreateCsv   = "users-create-csv"
+	usersDeleteCsv   = "users-delete-csv"
+	groupAddUsersCsv = "group-add-users-csv"
+	UsersGroups      = "users-groups"
+	Replace          = "replace"
+	Admin            = "admin"
 
 	// Unique access-token-create flags
 	groups      = "groups"
@@ -354,6 +485,11 @@ const (
 	refreshable = "refreshable"
 	audience    = "audience"
 
+	// Unique access-token-list, access-token-revoke and access-token-rotate flags
+	tokenSubject            = "subject"
+	accessTokenRevokeQuiet  = "access-token-revoke-" + quiet
+	accessTokenRotateExpiry = "access-token-rotate-" + expiry
+
 	// Unique Xray Flags for upload/publish commands
 	xrayScan = "scan"
 
@@ -434,6 +570,12 @@ const (
 
 	// *** Project Commands' flags ***
 	projectPath = "path"
+
+	// *** Spec Commands' flags ***
+	specCommand = "command"
+
+	// *** Cache Commands' flags ***
+	cacheMaxSize = "max-size"
 )
 
 var flagsMap = map[string]cli.Flag{
@@ -573,6 +715,30 @@ var flagsMap = map[string]cli.Flag{
 		Name:  detailedSummary,
 		Usage: "[Default: false] Set to true to include a list of the affected files in the command summary.` `",
 	},
+	resume: cli.BoolFlag{
+		Name:  resume,
+		Usage: "[Default: false] Set to true to keep a journal of the transferred files, so that an interrupted transfer can be continued by running the same command again. The journal is saved next to the spec file, or under the JFrog home directory if no spec is used.` `",
+	},
+	events: cli.StringFlag{
+		Name:  events,
+		Usage: "[Optional] Set to \"ndjson\" to print a JSON line to the standard output for each file as it is handled (started, succeeded, failed, skipped or retried). The command summary is printed as the last line.` `",
+	},
+	summaryFormat: cli.StringFlag{
+		Name:  summaryFormat,
+		Usage: "[Default: json] Defines the format of the command summary. Acceptable values are: json, junit, markdown and sarif.` `",
+	},
+	planFile: cli.StringFlag{
+		Name:  planFile,
+		Usage: "[Optional] Path to a file, to which the plan of the dry run is written. The plan lists the source, target, size and checksum of each file, and can be applied later by the apply-plan command. Can only be used together with --dry-run.` `",
+	},
+	limitRate: cli.StringFlag{
+		Name:  limitRate,
+		Usage: "[Default: unlimited] Maximum transfer rate per second, shared by all threads. For example: 500KB, 10MB or 1GB. A number without a unit is a number of bytes.` `",
+	},
+	limitSchedule: cli.StringFlag{
+		Name:  limitSchedule,
+		Usage: "[Optional] Daily time windows with their own maximum transfer rate, in the form of \"HH:MM-HH:MM=rate;HH:MM-HH:MM=rate\" in local time. For example: \"09:00-18:00=10MB;18:00-09:00=unlimited\". Outside of these windows, the --limit-rate value is used.` `",
+	},
 	interactive: cli.BoolTFlag{
 		Name:  interactive,
 		Usage: "[Default: true, unless $CI is true] Set to false if you do not want the config command to be interactive. If true, the --url option becomes optional.` `",
@@ -623,6 +789,14 @@ var flagsMap = map[string]cli.Flag{
 		Name:  symlinks,
 		Usage: "[Default: false] Set to true to preserve symbolic links structure in Artifactory.` `",
 	},
+	watch: cli.BoolFlag{
+		Name:  watch,
+		Usage: "[Default: false] Set to true to keep running after the files are uploaded, and upload new or changed files as they appear. Press Ctrl+C to stop.` `",
+	},
+	watchInterval: cli.StringFlag{
+		Name:  watchInterval,
+		Usage: "[Default: 5] Number of seconds between two scans of the local files, when --watch is used. A new or changed file is uploaded once it stays unchanged for a full interval.` `",
+	},
 	uploadTargetProps: cli.StringFlag{
 		Name:  targetProps,
 		Usage: "[Optional] List of properties in the form of \"key1=value1;key2=value2,...\". Those properties will be attached to the uploaded artifacts.` `",
@@ -633,7 +807,39 @@ var flagsMap = map[string]cli.Flag{
 	},
 	uploadArchive: cli.StringFlag{
 		Name:  archive,
-		Usage: "[Optional] Set to \"zip\" to deploy the files to Artifactory in a ZIP archive.` `",
+		Usage: "[Optional] Set to \"zip\", \"tar\", \"tar.gz\" or \"tar.zst\" to deploy the files to Artifactory in an archive of this format. The tar archives keep the permissions of the files.` `",
+	},
+	uploadMinSplit: cli.StringFlag{
+		Name:  minSplit,
+		Usage: "[Default: " + strconv.Itoa(UploadMinSplitMb) + "] Minimum file size in MB to upload in parts, using the multipart upload of Artifactory. Set to -1 for no splits. Setting this option, --split-count or --chunk-size uploads the files in batches, like --resume does.` `",
+	},
+	uploadSplitCount: cli.StringFlag{
+		Name:  splitCount,
+		Usage: "[Default: " + strconv.Itoa(UploadSplitCount) + "] Number of parts of a file to upload concurrently, when the file is uploaded in parts.` `",
+	},
+	chunkSize: cli.StringFlag{
+		Name:  chunkSize,
+		Usage: "[Default: " + strconv.Itoa(UploadChunkSizeMb) + "] Size in MB of each part, when a file is uploaded in parts.` `",
+	},
+	uploadSize: cli.StringFlag{
+		Name:  "size",
+		Usage: "[Optional] The size in bytes of the content uploaded from the standard input, when the source is \"-\". Used to display the progress of the upload.` `",
+	},
+	checksumFiles: cli.StringFlag{
+		Name:  checksumFiles,
+		Usage: "[Optional] Set to sidecar to also deploy a .sha256 and a .md5 file next to each uploaded file, or to manifest to deploy a SHA256SUMS file listing the checksums of the uploaded files to each folder. Existing manifests are updated with the uploaded files.` `",
+	},
+	signKey: cli.StringFlag{
+		Name:  signKey,
+		Usage: "[Optional] Path to a local armored or binary OpenPGP private key. If set, a detached signature of each uploaded file is created with the key, and deployed next to the file.` `",
+	},
+	signPassphrase: cli.StringFlag{
+		Name:  signPassphrase,
+		Usage: "[Optional] The passphrase of the private key set by the --sign-key option, if the key is encrypted.` `",
+	},
+	signatureFormat: cli.StringFlag{
+		Name:  signatureFormat,
+		Usage: "[Default: asc] The format of the signatures created by the --sign-key option. Set to asc for ASCII armored signatures, or to sig for binary signatures. The format is also the extension of the deployed signatures.` `",
 	},
 	syncDeletesQuiet: cli.BoolFlag{
 		Name:  quiet,
@@ -659,7 +865,15 @@ var flagsMap = map[string]cli.Flag{
 	},
 	downloadExplode: cli.BoolFlag{
 		Name:  explode,
-		Usage: "[Default: false] Set to true to extract an archive after it is downloaded from Artifactory.` `",
+		Usage: "[Default: false] Set to true to extract an archive after it is downloaded from Artifactory. Archives with entries which would be extracted outside of the target directory are rejected.` `",
+	},
+	downloadCache: cli.BoolFlag{
+		Name:  downloadCache,
+		Usage: "[Default: false, unless $JFROG_CLI_DOWNLOAD_CACHE_DIR is set] Set to true to use the local download cache. Files whose sha256 checksum is found in the cache are placed from it instead of being downloaded, and downloaded files are added to it.` `",
+	},
+	verifySignature: cli.StringFlag{
+		Name:  verifySignature,
+		Usage: "[Optional] Path to a local armored or binary file of OpenPGP public keys. If set, the detached signature of each downloaded file is downloaded from next to it in Artifactory, in the asc or sig format, and verified with the keys. A downloaded file whose signature is missing or invalid is removed, and the command fails.` `",
 	},
 	validateSymlinks: cli.BoolFlag{
 		Name:  validateSymlinks,
@@ -725,6 +939,130 @@ var flagsMap = map[string]cli.Flag{
 		Name:  quiet,
 		Usage: "[Default: $CI] Set to true to skip the delete confirmation message.` `",
 	},
+	trashRecursive: cli.BoolTFlag{
+		Name:  recursive,
+		Usage: "[Default: true] Set to false if you do not wish to include artifacts which were deleted from sub-folders.` `",
+	},
+	trashProps: cli.StringFlag{
+		Name:  props,
+		Usage: "[Optional] List of properties in the form of \"key1=value1;key2=value2,...\". Only artifacts with these properties are included.` `",
+	},
+	trashExcludeProps: cli.StringFlag{
+		Name:  excludeProps,
+		Usage: "[Optional] List of properties in the form of \"key1=value1;key2=value2,...\". Only artifacts without the specified properties are included.` `",
+	},
+	trashQuiet: cli.BoolFlag{
+		Name:  quiet,
+		Usage: "[Default: $CI] Set to true to skip the delete confirmation message.` `",
+	},
+	duRecursive: cli.BoolTFlag{
+		Name:  recursive,
+		Usage: "[Default: true] Set to false if you do not wish to include artifacts inside sub-folders in Artifactory.` `",
+	},
+	duProps: cli.StringFlag{
+		Name:  props,
+		Usage: "[Optional] List of properties in the form of \"key1=value1;key2=value2,...\". Only artifacts with these properties are included.` `",
+	},
+	duExcludeProps: cli.StringFlag{
+		Name:  excludeProps,
+		Usage: "[Optional] List of properties in the form of \"key1=value1;key2=value2,...\". Only artifacts without the specified properties are included.` `",
+	},
+	duDepth: cli.StringFlag{
+		Name:  "depth",
+		Usage: "[Default: 1] Number of folder levels to report below the folder of the pattern. Set to zero to report only the folder of the pattern.` `",
+	},
+	duSortBy: cli.StringFlag{
+		Name:  sortBy,
+		Usage: "[Default: size] The field to sort the folders by. Acceptable values are: size, files, path, modified and downloaded.` `",
+	},
+	duSortOrder: cli.StringFlag{
+		Name:  sortOrder,
+		Usage: "[Default: desc, or asc when sorting by path] The order by which the folders should be sorted. Accepts 'asc' or 'desc'.` `",
+	},
+	duFormat: cli.StringFlag{
+		Name:  "format",
+		Usage: "[Default: json] Defines the output format. Acceptable values are: json, csv and table.` `",
+	},
+	lsRecursive: cli.BoolFlag{
+		Name:  recursive,
+		Usage: "[Default: false] Set to true to list the content of the sub-folders as a tree.` `",
+	},
+	lsDepth: cli.StringFlag{
+		Name:  "depth",
+		Usage: "[Optional] Number of folder levels to list. Implies --recursive.` `",
+	},
+	humanReadable: cli.BoolFlag{
+		Name:  humanReadable,
+		Usage: "[Default: false] Set to true to print sizes in a human-readable format, such as 1.5MB.` `",
+	},
+	showProps: cli.BoolFlag{
+		Name:  showProps,
+		Usage: "[Default: false] Set to true to print the properties of the files and folders.` `",
+	},
+	verifyRecursive: cli.BoolTFlag{
+		Name:  recursive,
+		Usage: "[Default: true] Set to false if you do not wish to verify the artifacts inside sub-folders in Artifactory.` `",
+	},
+	verifyFlat: cli.BoolFlag{
+		Name:  flat,
+		Usage: "[Default: false] Set to true if the local files were downloaded without the Artifactory repository path structure.` `",
+	},
+	verifyProps: cli.StringFlag{
+		Name:  props,
+		Usage: "[Optional] List of properties in the form of \"key1=value1;key2=value2,...\". Only artifacts with these properties will be verified.` `",
+	},
+	verifyExcludeProps: cli.StringFlag{
+		Name:  excludeProps,
+		Usage: "[Optional] List of properties in the form of \"key1=value1;key2=value2,...\". Only artifacts without the specified properties will be verified.` `",
+	},
+	bundleExportRecursive: cli.BoolTFlag{
+		Name:  recursive,
+		Usage: "[Default: true] Set to false if you do not wish to export the artifacts inside sub-folders in Artifactory.` `",
+	},
+	bundleExportProps: cli.StringFlag{
+		Name:  props,
+		Usage: "[Optional] List of properties in the form of \"key1=value1;key2=value2,...\". Only artifacts with these properties will be exported.` `",
+	},
+	bundleExportExcludeProps: cli.StringFlag{
+		Name:  excludeProps,
+		Usage: "[Optional] List of properties in the form of \"key1=value1;key2=value2,...\". Only artifacts without the specified properties will be exported.` `",
+	},
+	includeBuilds: cli.BoolTFlag{
+		Name:  includeBuilds,
+		Usage: "[Default: true] Set to false if you do not wish to include the build-info of the builds of the exported artifacts in the bundle.` `",
+	},
+	bundleImportDryRun: cli.BoolFlag{
+		Name:  dryRun,
+		Usage: "[Default: false] Set to true to extract the bundle and verify its checksums without deploying anything.` `",
+	},
+	cleanupPolicy: cli.StringFlag{
+		Name:  cleanupPolicy,
+		Usage: "[Mandatory] Path to a YAML cleanup policy with a list of rules. Each rule has a pattern, and deletes the artifacts matching it to which all of its conditions apply. The conditions are olderThanDays, notDownloadedForDays, keepLastVersions, exclusions, excludeProps and excludeReleasedBuilds.` `",
+	},
+	cleanupDryRun: cli.BoolFlag{
+		Name:  dryRun,
+		Usage: "[Default: false] Set to true to only print the report of the artifacts which match the policy, without deleting them.` `",
+	},
+	cleanupQuiet: cli.BoolFlag{
+		Name:  quiet,
+		Usage: "[Default: $CI] Set to true to skip the delete confirmation message, which is displayed after the report.` `",
+	},
+	syncDryRun: cli.BoolFlag{
+		Name:  dryRun,
+		Usage: "[Default: false] Set to true to only print the plan of the sync, without applying it.` `",
+	},
+	syncQuiet: cli.BoolFlag{
+		Name:  quiet,
+		Usage: "[Default: $CI] Set to true to skip the confirmation message, which is displayed when the sync deletes files.` `",
+	},
+	conflict: cli.StringFlag{
+		Name:  conflict,
+		Usage: "[Default: newer-wins] Defines which side wins when a file was modified on both sides since the last sync. Acceptable values are: newer-wins, local-wins and remote-wins.` `",
+	},
+	applyPlanQuiet: cli.BoolFlag{
+		Name:  quiet,
+		Usage: "[Default: $CI] Set to true to skip the confirmation message, which is displayed when the plan deletes artifacts.` `",
+	},
 	searchRecursive: cli.BoolTFlag{
 		Name:  recursive,
 		Usage: "[Default: true] Set to false if you do not wish to search artifacts inside sub-folders in Artifactory.` `",
@@ -733,6 +1071,18 @@ var flagsMap = map[string]cli.Flag{
 		Name:  count,
 		Usage: "[Optional] Set to true to display only the total of files or folders found.` `",
 	},
+	searchFormat: cli.StringFlag{
+		Name:  "format",
+		Usage: "[Default: json] Defines the output format of the search results. Acceptable values are: json, table, csv and paths.` `",
+	},
+	searchFields: cli.StringFlag{
+		Name:  searchFields,
+		Usage: "[Optional] Comma separated list of the fields to print, such as \"path,size,sha256,props.build.name\". Acceptable fields are: path, repo, name, type, size, created, modified, sha1, md5, sha256, props and props.<property key>. Used together with --format.` `",
+	},
+	searchTemplate: cli.StringFlag{
+		Name:  searchTemplate,
+		Usage: "[Optional] Go template to print for each search result, such as '{{.path}} {{.size}}'. The available fields are the ones accepted by --fields. Properties are available as {{index .props \"<property key>\"}}. Cannot be used together with --format or --fields.` `",
+	},
 	searchProps: cli.StringFlag{
 		Name:  props,
 		Usage: "[Optional] List of properties in the form of \"key1=value1;key2=value2,...\". Only artifacts with these properties will be returned.` `",
@@ -983,6 +1333,54 @@ var flagsMap = map[string]cli.Flag{
 		Name:  noFallback,
 		Usage: "[Default: false] Set to true to avoid downloading packages from the VCS, if they are missing in Artifactory.` `",
 	},
+	repoType: cli.StringFlag{
+		Name:  repoType,
+		Usage: "[Optional] Only repositories of this type are listed. Acceptable values are: local, remote, virtual and federated.` `",
+	},
+	repoPackageType: cli.StringFlag{
+		Name:  repoPackageType,
+		Usage: "[Optional] Only repositories of this package type, such as maven or npm, are listed.` `",
+	},
+	repoApplyDryRun: cli.BoolFlag{
+		Name:  dryRun,
+		Usage: "[Default: false] Set to true to only print the plan, without applying it.` `",
+	},
+	repoApplyQuiet: cli.BoolFlag{
+		Name:  quiet,
+		Usage: "[Default: $CI] Set to true to skip the confirmation message, which is displayed before the plan is applied.` `",
+	},
+	repoApplyDeleteMissing: cli.BoolFlag{
+		Name:  repoApplyDeleteMissing,
+		Usage: "[Default: false] Set to true to delete the repositories which don't have a template in the directory, with all of their content.` `",
+	},
+	replicationFormat: cli.StringFlag{
+		Name:  "format",
+		Usage: "[Default: json] Defines the output format. Acceptable values are: json and table.` `",
+	},
+	replicationRun: cli.BoolFlag{
+		Name:  replicationRun,
+		Usage: "[Default: false] Set to true to trigger a replication of the repository and wait for it to finish, before its status is printed.` `",
+	},
+	replicationMaxWaitMinutes: cli.StringFlag{
+		Name:  maxWaitMinutes,
+		Usage: "[Default: 60] Max minutes to wait for the triggered replication to finish. Set to zero to wait without a limit. Used together with --run.` `",
+	},
+	accessDiffPermissionTargetsDir: cli.StringFlag{
+		Name:  accessDiffPermissionTargetsDir,
+		Usage: "[Optional] Path to a directory of permission target templates, as created by the permission-target-export command, to compare with Artifactory.` `",
+	},
+	accessDiffUsersCsv: cli.StringFlag{
+		Name:  accessDiffUsersCsv,
+		Usage: "[Optional] Path to a users CSV file, as created by the users-export command, to compare with Artifactory.` `",
+	},
+	accessDiffGroupsCsv: cli.StringFlag{
+		Name:  accessDiffGroupsCsv,
+		Usage: "[Optional] Path to a group memberships CSV file, as created by the groups-export command, to compare with Artifactory.` `",
+	},
+	accessDiffFailOnDrift: cli.BoolFlag{
+		Name:  accessDiffFailOnDrift,
+		Usage: "[Default: false] Set to true if you'd like the command to return exit code 1 when any difference is found.` `",
+	},
 	vars: cli.StringFlag{
 		Name:  vars,
 		Usage: "[Optional] List of variables in the form of \"key1=value1;key2=value2;...\" to be replaced in the template. In the template, the variables should be used as follows: ${key1}.` `",
@@ -1009,6 +1407,18 @@ var flagsMap = map[string]cli.Flag{
 		Name:  audience,
 		Usage: "[Optional] A space-separate list of the other Artifactory instances or services that should accept this token identified by their Artifactory Service IDs, as obtained by the 'jfrog rt curl api/system/service_id' command.` `",
 	},
+	tokenSubject: cli.StringFlag{
+		Name:  tokenSubject,
+		Usage: "[Optional] The subject of the access tokens, or the name of the user for which the tokens were created.` `",
+	},
+	accessTokenRevokeQuiet: cli.BoolFlag{
+		Name:  quiet,
+		Usage: "[Default: $CI] Set to true to skip the confirmation message, which is displayed before the tokens of a subject are revoked.` `",
+	},
+	accessTokenRotateExpiry: cli.StringFlag{
+		Name:  expiry,
+		Usage: "[Optional] The time in seconds for which the new token will be valid. To specify a token that never expires, set to zero. By default, the new token is valid for the same time as the current token.` `",
+	},
 	usersCreateCsv: cli.StringFlag{
 		Name:  csv,
 		Usage: "[Mandatory] Path to a csv file with the users' details. The first row of the file is reserved for the cells' headers. It must include \"username\",\"password\",\"email\"` `",
@@ -1017,6 +1427,10 @@ var flagsMap = map[string]cli.Flag{
 		Name:  csv,
 		Usage: "[Optional] Path to a csv file with the users' details. The first row of the file is reserved for the cells' headers. It must include \"username\"` `",
 	},
+	groupAddUsersCsv: cli.StringFlag{
+		Name:  csv,
+		Usage: "[Optional] Path to a group memberships CSV file, as created by the groups-export command. The users listed in the file are added to their groups. When provided, the <group name> and <users list> arguments should not be sent.` `",
+	},
 	UsersGroups: cli.StringFlag{
 		Name:  UsersGroups,
 		Usage: "[Optional] A list of comma-separated groups for the new users to be associated with.` `",
@@ -1247,6 +1661,14 @@ var flagsMap = map[string]cli.Flag{
 		Name:  projectPath,
 		Usage: "[Optional] A full path for a user project. ` `",
 	},
+	specCommand: cli.StringFlag{
+		Name:  specCommand,
+		Usage: "[Optional] The command which uses the File Spec. Acceptable values are: upload, download, copy, move, delete, search, set-props and delete-props.` `",
+	},
+	cacheMaxSize: cli.StringFlag{
+		Name:  cacheMaxSize,
+		Usage: "[Default: $JFROG_CLI_DOWNLOAD_CACHE_MAX_SIZE or 10GB] The size the cache is pruned to, such as 500MB or 10GB.` `",
+	},
 }
 
 var commandFlags = map[string][]string{
@@ -1266,48 +1688,106 @@ var commandFlags = map[string][]string{
 		clientCertKeyPath, specFlag, specVars, buildName, buildNumber, module, uploadExclusions, deb,
 		uploadRecursive, uploadFlat, uploadRegexp, retries, retryWaitTime, dryRun, uploadExplode, symlinks, includeDirs,
 		failNoOp, threads, uploadSyncDeletes, syncDeletesQuiet, InsecureTls, detailedSummary, project,
-		uploadAnt, uploadArchive,
+		uploadAnt, uploadArchive, resume, events, summaryFormat, watch, watchInterval, planFile, limitRate, limitSchedule,
+		uploadMinSplit, uploadSplitCount, chunkSize, uploadSize, checksumFiles, signKey, signPassphrase, signatureFormat,
 	},
 	Download: {
 		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, clientCertPath,
 		clientCertKeyPath, specFlag, specVars, buildName, buildNumber, module, exclusions, sortBy,
 		sortOrder, limit, offset, downloadRecursive, downloadFlat, build, includeDeps, excludeArtifacts, minSplit, splitCount,
 		retries, retryWaitTime, dryRun, downloadExplode, validateSymlinks, bundle, publicGpgKey, includeDirs, downloadProps, downloadExcludeProps,
-		failNoOp, threads, archiveEntries, downloadSyncDeletes, syncDeletesQuiet, InsecureTls, detailedSummary, project,
+		failNoOp, threads, archiveEntries, downloadSyncDeletes, syncDeletesQuiet, InsecureTls, detailedSummary, project, resume, events, summaryFormat, planFile,
+		limitRate, limitSchedule, downloadCache, verifySignature,
 	},
 	Move: {
 		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, clientCertPath,
 		clientCertKeyPath, specFlag, specVars, exclusions, sortBy, sortOrder, limit, offset, moveRecursive,
 		moveFlat, dryRun, build, includeDeps, excludeArtifacts, moveProps, moveExcludeProps, failNoOp, threads, archiveEntries,
-		InsecureTls, retries, retryWaitTime, project,
+		InsecureTls, retries, retryWaitTime, project, events, summaryFormat, planFile, detailedSummary,
 	},
 	Copy: {
 		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, clientCertPath,
 		clientCertKeyPath, specFlag, specVars, exclusions, sortBy, sortOrder, limit, offset, copyRecursive,
 		copyFlat, dryRun, build, includeDeps, excludeArtifacts, bundle, copyProps, copyExcludeProps, failNoOp, threads,
-		archiveEntries, InsecureTls, retries, retryWaitTime, project,
+		archiveEntries, InsecureTls, retries, retryWaitTime, project, events, summaryFormat, planFile, detailedSummary,
 	},
 	Delete: {
 		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, clientCertPath,
 		clientCertKeyPath, specFlag, specVars, exclusions, sortBy, sortOrder, limit, offset,
 		deleteRecursive, dryRun, build, includeDeps, excludeArtifacts, deleteQuiet, deleteProps, deleteExcludeProps, failNoOp, threads, archiveEntries,
+		InsecureTls, retries, retryWaitTime, project, events, summaryFormat, planFile, detailedSummary,
+	},
+	TrashList: {
+		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, clientCertPath,
+		clientCertKeyPath, specFlag, specVars, exclusions, sortBy, sortOrder, limit, offset,
+		trashRecursive, build, includeDeps, excludeArtifacts, bundle, trashProps, trashExcludeProps, failNoOp, archiveEntries,
 		InsecureTls, retries, retryWaitTime, project,
 	},
+	TrashRestore: {
+		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, clientCertPath,
+		clientCertKeyPath, specFlag, specVars, exclusions, sortBy, sortOrder, limit, offset,
+		trashRecursive, dryRun, build, includeDeps, excludeArtifacts, bundle, trashProps, trashExcludeProps, failNoOp, threads, archiveEntries,
+		InsecureTls, retries, retryWaitTime, project, summaryFormat, detailedSummary,
+	},
+	TrashEmpty: {
+		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, clientCertPath,
+		clientCertKeyPath, specFlag, specVars, exclusions, sortBy, sortOrder, limit, offset,
+		trashRecursive, dryRun, build, includeDeps, excludeArtifacts, bundle, trashQuiet, trashProps, trashExcludeProps, failNoOp, threads, archiveEntries,
+		InsecureTls, retries, retryWaitTime, project, summaryFormat, detailedSummary,
+	},
+	Du: {
+		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, clientCertPath,
+		clientCertKeyPath, specFlag, specVars, exclusions, duRecursive, duProps, duExcludeProps,
+		duDepth, duSortBy, duSortOrder, duFormat, InsecureTls,
+	},
+	Ls: {
+		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, clientCertPath,
+		clientCertKeyPath, lsRecursive, lsDepth, humanReadable, showProps, failNoOp, InsecureTls,
+	},
+	Verify: {
+		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, clientCertPath,
+		clientCertKeyPath, specFlag, specVars, exclusions, verifyRecursive, verifyFlat, verifyProps, verifyExcludeProps,
+		build, bundle, threads, InsecureTls,
+	},
+	BundleExport: {
+		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, clientCertPath,
+		clientCertKeyPath, specFlag, specVars, exclusions, bundleExportRecursive, bundleExportProps, bundleExportExcludeProps,
+		build, bundle, includeBuilds, project, threads, splitCount, minSplit, retries, retryWaitTime, InsecureTls,
+	},
+	BundleImport: {
+		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, clientCertPath,
+		clientCertKeyPath, bundleImportDryRun, project, threads, retries, retryWaitTime, InsecureTls,
+	},
+	Cleanup: {
+		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, clientCertPath,
+		clientCertKeyPath, cleanupPolicy, cleanupDryRun, cleanupQuiet, threads, retries, retryWaitTime,
+		failNoOp, InsecureTls, summaryFormat, detailedSummary,
+	},
+	ApplyPlan: {
+		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, clientCertPath,
+		clientCertKeyPath, applyPlanQuiet, deb, minSplit, splitCount, threads, retries, retryWaitTime,
+		failNoOp, InsecureTls, project,
+	},
+	Sync: {
+		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, clientCertPath,
+		clientCertKeyPath, conflict, syncDryRun, syncQuiet, deb, minSplit, splitCount, threads, retries, retryWaitTime,
+		failNoOp, InsecureTls, project,
+	},
 	Search: {
 		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, clientCertPath,
 		clientCertKeyPath, specFlag, specVars, exclusions, sortBy, sortOrder, limit, offset,
 		searchRecursive, build, includeDeps, excludeArtifacts, count, bundle, includeDirs, searchProps, searchExcludeProps, failNoOp, archiveEntries,
-		InsecureTls, searchTransitive, retries, retryWaitTime, project,
+		InsecureTls, searchTransitive, retries, retryWaitTime, project, searchFormat, searchFields, searchTemplate,
 	},
 	Properties: {
 		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, clientCertPath,
 		clientCertKeyPath, specFlag, specVars, exclusions, sortBy, sortOrder, limit, offset,
 		propsRecursive, build, includeDeps, excludeArtifacts, bundle, includeDirs, failNoOp, threads, archiveEntries, propsProps, propsExcludeProps,
-		InsecureTls, retries, retryWaitTime, project,
+		InsecureTls, retries, retryWaitTime, project, summaryFormat, detailedSummary,
 	},
 	BuildPublish: {
 		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, buildUrl, bpDryRun,
-		envInclude, envExclude, InsecureTls, project, bpDetailedSummary,
+		envInclude, envExclude, InsecureTls, project, bpDetailedSummary, summaryFormat,
 	},
 	BuildAppend: {
 		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, buildUrl, bpDryRun,
@@ -1453,18 +1933,53 @@ var commandFlags = map[string][]string{
 		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, clientCertPath,
 		clientCertKeyPath, deleteQuiet,
 	},
+	RepoList: {
+		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, clientCertPath,
+		clientCertKeyPath, repoType, repoPackageType,
+	},
+	RepoShow: {
+		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, clientCertPath,
+		clientCertKeyPath,
+	},
+	RepoApply: {
+		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, clientCertPath,
+		clientCertKeyPath, vars, repoApplyDryRun, repoApplyQuiet, repoApplyDeleteMissing,
+	},
 	ReplicationDelete: {
 		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, clientCertPath,
 		clientCertKeyPath, deleteQuiet,
 	},
+	ReplicationList: {
+		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, clientCertPath,
+		clientCertKeyPath, replicationFormat,
+	},
+	ReplicationStatus: {
+		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, clientCertPath,
+		clientCertKeyPath, replicationFormat, replicationRun, replicationMaxWaitMinutes,
+	},
 	PermissionTargetDelete: {
 		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, clientCertPath,
 		clientCertKeyPath, deleteQuiet,
 	},
+	PermissionTargetExport: {
+		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, clientCertPath,
+		clientCertKeyPath,
+	},
 	AccessTokenCreate: {
 		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, clientCertPath,
 		clientCertKeyPath, groups, grantAdmin, expiry, refreshable, audience,
 	},
+	AccessTokenList: {
+		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, clientCertPath,
+		clientCertKeyPath, tokenSubject,
+	},
+	AccessTokenRevoke: {
+		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, clientCertPath,
+		clientCertKeyPath, tokenSubject, accessTokenRevokeQuiet,
+	},
+	AccessTokenRotate: {
+		serverId, accessTokenRotateExpiry, refreshable,
+	},
 	UserCreate: {
 		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId,
 		UsersGroups, Replace, Admin,
@@ -1483,10 +1998,21 @@ var commandFlags = map[string][]string{
 	},
 	GroupAddUsers: {
 		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId,
+		groupAddUsersCsv,
 	},
 	GroupDelete: {
 		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, deleteQuiet,
 	},
+	UsersExport: {
+		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId,
+	},
+	GroupsExport: {
+		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId,
+	},
+	AccessDiff: {
+		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, clientCertPath,
+		clientCertKeyPath, accessDiffPermissionTargetsDir, accessDiffUsersCsv, accessDiffGroupsCsv, vars, accessDiffFailOnDrift,
+	},
 	// Xray's commands
 	OfflineUpdate: {
 		licenseId, from, to, version, target,
@@ -1495,26 +2021,26 @@ var commandFlags = map[string][]string{
 		serverId,
 	},
 	Audit: {
-		xrUrl, user, password, accessToken, serverId, InsecureTls, project, watches, repoPath, licenses, xrOutput, ExcludeTestDeps,
+		xrUrl, user, password, accessToken, serverId, InsecureTls, project, watches, repoPath, licenses, xrOutput, summaryFormat, ExcludeTestDeps,
 		UseWrapper, depType, fail,
 	},
 	AuditMvn: {
-		xrUrl, user, password, accessToken, serverId, InsecureTls, project, watches, repoPath, licenses, xrOutput, fail,
+		xrUrl, user, password, accessToken, serverId, InsecureTls, project, watches, repoPath, licenses, xrOutput, summaryFormat, fail,
 	},
 	AuditGradle: {
-		xrUrl, user, password, accessToken, serverId, ExcludeTestDeps, UseWrapper, project, watches, repoPath, licenses, xrOutput, fail,
+		xrUrl, user, password, accessToken, serverId, ExcludeTestDeps, UseWrapper, project, watches, repoPath, licenses, xrOutput, summaryFormat, fail,
 	},
 	AuditNpm: {
-		xrUrl, user, password, accessToken, serverId, depType, project, watches, repoPath, licenses, xrOutput, fail,
+		xrUrl, user, password, accessToken, serverId, depType, project, watches, repoPath, licenses, xrOutput, summaryFormat, fail,
 	},
 	AuditGo: {
-		xrUrl, user, password, accessToken, serverId, project, watches, repoPath, licenses, xrOutput, fail,
+		xrUrl, user, password, accessToken, serverId, project, watches, repoPath, licenses, xrOutput, summaryFormat, fail,
 	},
 	AuditPip: {
-		xrUrl, user, password, accessToken, serverId, project, watches, repoPath, licenses, xrOutput, fail,
+		xrUrl, user, password, accessToken, serverId, project, watches, repoPath, licenses, xrOutput, summaryFormat, fail,
 	},
 	AuditPipenv: {
-		xrUrl, user, password, accessToken, serverId, project, watches, repoPath, licenses, xrOutput,
+		xrUrl, user, password, accessToken, serverId, project, watches, repoPath, licenses, xrOutput, summaryFormat,
 	},
 	XrScan: {
 		xrUrl, user, password, accessToken, serverId, specFlag, threads, scanRecursive, scanRegexp, scanAnt,
@@ -1549,6 +2075,19 @@ var commandFlags = map[string][]string{
 	InitProject: {
 		projectPath, serverId,
 	},
+	// Spec commands
+	SpecValidate: {
+		specCommand, specVars,
+	},
+	SpecExplain: {
+		specCommand, specVars,
+	},
+	// Cache commands
+	CacheStats: {},
+	CachePrune: {
+		cacheMaxSize,
+	},
+	CacheClear: {},
 }
 
 func GetCommandFlags(cmd string) []cli.Flag {
@@ -200,6 +200,61 @@ func CreateBuildInfoSummaryReportString(success, failed int, sha256 string, err
 	return utils.IndentJson(buildInfoSummaryContent), mErr
 }
 
+// Returns the format of the command summary, which is set by the --summary-format option.
+func GetSummaryFormat(c *cli.Context) (summary.Format, error) {
+	return summary.ParseFormat(c.String("summary-format"))
+}
+
+// Prints a summary report in the given format.
+// The json format is printed by PrintDetailedSummaryReport, so that the affected files are streamed rather than rendered all at once.
+func PrintSummaryReportInFormat(format summary.Format, commandName string, success, failed int, reader *content.ContentReader, printExtendedDetails, failNoOp bool, originalErr error) error {
+	if format == summary.Json {
+		return PrintDetailedSummaryReport(success, failed, reader, printExtendedDetails, failNoOp, originalErr)
+	}
+	var files []summary.FileRecord
+	if reader != nil {
+		reader.Reset()
+		defer reader.Close()
+		for transferDetails := new(clientutils.FileTransferDetails); reader.NextRecord(transferDetails) == nil; transferDetails = new(clientutils.FileTransferDetails) {
+			file := summary.FileRecord{Source: transferDetails.SourcePath, Target: transferDetails.TargetPath}
+			if printExtendedDetails {
+				file.Sha256 = transferDetails.Sha256
+			}
+			files = append(files, file)
+		}
+		if mErr := reader.GetError(); mErr != nil {
+			return summaryPrintError(mErr, originalErr)
+		}
+	}
+	return PrintReport(format, summary.NewTransferReport(commandName, success, failed, files, failNoOp, originalErr), originalErr)
+}
+
+// Prints a build-info summary report in the given format.
+func PrintBuildInfoSummaryReportInFormat(format summary.Format, commandName string, succeeded bool, sha256 string, originalErr error) error {
+	if format == summary.Json {
+		return PrintBuildInfoSummaryReport(succeeded, sha256, originalErr)
+	}
+	success, failed := 1, 0
+	if !succeeded {
+		success, failed = 0, 1
+	}
+	buildInfoSummary := summary.NewBuildInfoSummary(success, failed, sha256, originalErr)
+	return PrintReport(format, summary.NewBuildInfoReport(commandName, buildInfoSummary, originalErr), originalErr)
+}
+
+// Renders a report using the renderer of the given format, and prints it.
+func PrintReport(format summary.Format, report *summary.Report, originalErr error) error {
+	renderer, mErr := summary.GetRenderer(format)
+	if mErr != nil {
+		return summaryPrintError(mErr, originalErr)
+	}
+	renderedReport, mErr := renderer.Render(report)
+	if mErr == nil {
+		log.Output(renderedReport)
+	}
+	return summaryPrintError(mErr, originalErr)
+}
+
 func PrintHelpAndReturnError(msg string, context *cli.Context) error {
 	log.Error(msg + " " + GetDocumentationMessage())
 	cli.ShowCommandHelp(context, context.Command.Name)
@@ -24,3 +24,24 @@ func TestSplitAgentNameAndVersion(t *testing.T) {
 		assert.Equal(t, test.expectedAgentVersion, actualAgentVersion)
 	}
 }
+
+func TestParseAndFormatSize(t *testing.T) {
+	tests := []struct {
+		value     string
+		size      int64
+		formatted string
+	}{
+		{"100", 100, "100B"},
+		{"512KB", 512 * 1024, "512.0KB"},
+		{"1.5m", 3 * 512 * 1024, "1.5MB"},
+		{"10GB", 10 * 1024 * 1024 * 1024, "10.0GB"},
+	}
+	for _, test := range tests {
+		size, err := ParseSize(test.value)
+		assert.NoError(t, err)
+		assert.Equal(t, test.size, size)
+		assert.Equal(t, test.formatted, FormatSize(size))
+	}
+	_, err := ParseSize("10 apples")
+	assert.Error(t, err)
+}
@@ -0,0 +1,84 @@
+package output
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// The format in which the output of a command is printed.
+// Commands support a subset of the formats, and may add formats of their own.
+type Format string
+
+const (
+	Json  Format = "json"
+	Table Format = "table"
+	Csv   Format = "csv"
+)
+
+// Returns the format matching the provided value, out of the formats supported by a command.
+// An empty value is the first supported format. The name of the option is used in the error message.
+func ParseFormat(value, optionName string, supported ...Format) (Format, error) {
+	format := Format(strings.ToLower(value))
+	if format == "" && len(supported) > 0 {
+		return supported[0], nil
+	}
+	var names []string
+	for _, supportedFormat := range supported {
+		if format == supportedFormat {
+			return format, nil
+		}
+		names = append(names, string(supportedFormat))
+	}
+	return "", errorutils.CheckErrorf("the --%s option accepts one of the following values: %s", optionName, strings.Join(names, ", "))
+}
+
+func PrintJson(value interface{}) error {
+	content, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	log.Output(string(content))
+	return nil
+}
+
+// Prints rows in aligned columns. The header is omitted if it's empty.
+func PrintTable(header []string, rows [][]string) error {
+	var buffer bytes.Buffer
+	writer := tabwriter.NewWriter(&buffer, 0, 0, 2, ' ', 0)
+	if len(header) > 0 {
+		fmt.Fprintln(writer, strings.Join(header, "\t"))
+	}
+	for _, row := range rows {
+		fmt.Fprintln(writer, strings.Join(row, "\t"))
+	}
+	if err := writer.Flush(); err != nil {
+		return errorutils.CheckError(err)
+	}
+	log.Output(strings.TrimSuffix(buffer.String(), "\n"))
+	return nil
+}
+
+func PrintCsv(records [][]string) error {
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+	if err := writer.WriteAll(records); err != nil {
+		return errorutils.CheckError(err)
+	}
+	log.Output(strings.TrimSuffix(buffer.String(), "\n"))
+	return nil
+}
+
+// Formats an optional value of a table cell.
+func FormatCell(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
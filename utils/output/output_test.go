@@ -0,0 +1,18 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFormat(t *testing.T) {
+	format, err := ParseFormat("", "format", Json, Table)
+	assert.NoError(t, err)
+	assert.Equal(t, Json, format)
+	format, err = ParseFormat("TABLE", "format", Json, Table)
+	assert.NoError(t, err)
+	assert.Equal(t, Table, format)
+	_, err = ParseFormat("csv", "format", Json, Table)
+	assert.EqualError(t, err, "the --format option accepts one of the following values: json, table")
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
 	corelog "github.com/jfrog/jfrog-cli-core/v2/utils/log"
 	logUtils "github.com/jfrog/jfrog-cli/utils/log"
+	"github.com/jfrog/jfrog-cli/utils/ratelimit"
 	"github.com/jfrog/jfrog-client-go/utils"
 	ioUtils "github.com/jfrog/jfrog-client-go/utils/io"
 	"github.com/jfrog/jfrog-client-go/utils/log"
@@ -218,11 +219,11 @@ func (p *progressBarManager) GetProgress(id int) ioUtils.Progress {
 	return p.bars[id-1]
 }
 
-// Initializes progress bar if possible (all conditions in 'shouldInitProgressBar' are met).
+// Initializes progress bar if possible (all conditions in 'ShouldInitProgressBar' are met).
 // Creates a log file and sets the Logger to it. Caller responsible to close the file.
 // Returns nil, nil, err if failed.
 func InitProgressBarIfPossible() (ioUtils.ProgressMgr, *os.File, error) {
-	shouldInit, err := shouldInitProgressBar()
+	shouldInit, err := ShouldInitProgressBar()
 	if !shouldInit || err != nil {
 		return nil, nil, err
 	}
@@ -250,7 +251,7 @@ func InitProgressBarIfPossible() (ioUtils.ProgressMgr, *os.File, error) {
 
 // Init progress bar if all required conditions are met:
 // CI == false (or unset), Stderr is a terminal, and terminal width is large enough
-func shouldInitProgressBar() (bool, error) {
+func ShouldInitProgressBar() (bool, error) {
 	ci, err := utils.GetBoolEnvValue(coreutils.CI, false)
 	if ci || err != nil {
 		return false, err
@@ -354,15 +355,29 @@ type CommandWithProgress interface {
 }
 
 func ExecWithProgress(cmd CommandWithProgress) error {
+	return ExecWithProgressAndRateLimit(cmd, nil)
+}
+
+// Same as ExecWithProgress, but also limits the rate of the command's transfers if a limiter is provided.
+// The rate is limited even if the progress bar can't be displayed.
+func ExecWithProgressAndRateLimit(cmd CommandWithProgress, limiter *ratelimit.Limiter) error {
 	// Init progress bar.
 	progressBar, logFile, err := InitProgressBarIfPossible()
 	if err != nil {
 		return err
 	}
+	var progress ioUtils.ProgressMgr
 	if progressBar != nil {
-		cmd.SetProgress(progressBar)
+		progress = progressBar
 		defer logUtils.CloseLogFile(logFile)
 		defer progressBar.Quit()
 	}
+	if limiter != nil {
+		log.Debug("Limiting the transfer rate to", limiter.String())
+		progress = ratelimit.NewProgressMgr(progress, limiter)
+	}
+	if progress != nil {
+		cmd.SetProgress(progress)
+	}
 	return commands.Exec(cmd)
 }
@@ -0,0 +1,143 @@
+package ratelimit
+
+import (
+	"io"
+	"sync"
+
+	ioUtils "github.com/jfrog/jfrog-client-go/utils/io"
+)
+
+// The maximum number of bytes read at once by a rate limited reader.
+// Small reads keep the transfer rate smooth, instead of long waits between large reads.
+const maxReadSize = 32 * 1024
+
+// A progress manager which limits the rate of all transfers.
+// The transfer client passes every uploaded and downloaded stream through the progress indicators, so wrapping
+// these streams limits the rate of all transfers, regardless of the number of threads.
+// If another progress manager is provided, all calls are forwarded to it.
+type progressMgr struct {
+	progress ioUtils.ProgressMgr
+	limiter  *Limiter
+	// The progress indicators created when no other progress manager is provided, mapped by their ids.
+	indicators map[int]ioUtils.Progress
+	mutex      sync.Mutex
+	lastId     int
+}
+
+func NewProgressMgr(progress ioUtils.ProgressMgr, limiter *Limiter) ioUtils.ProgressMgr {
+	return &progressMgr{progress: progress, limiter: limiter, indicators: make(map[int]ioUtils.Progress)}
+}
+
+func (pm *progressMgr) NewProgressReader(total int64, label, path string) ioUtils.Progress {
+	if pm.progress != nil {
+		return &progress{Progress: pm.progress.NewProgressReader(total, label, path), limiter: pm.limiter}
+	}
+	pm.mutex.Lock()
+	defer pm.mutex.Unlock()
+	pm.lastId++
+	indicator := &progress{id: pm.lastId, limiter: pm.limiter}
+	pm.indicators[indicator.id] = indicator
+	return indicator
+}
+
+func (pm *progressMgr) SetProgressState(id int, state string) {
+	if pm.progress != nil {
+		pm.progress.SetProgressState(id, state)
+	}
+}
+
+func (pm *progressMgr) GetProgress(id int) ioUtils.Progress {
+	if pm.progress != nil {
+		return &progress{Progress: pm.progress.GetProgress(id), limiter: pm.limiter}
+	}
+	pm.mutex.Lock()
+	defer pm.mutex.Unlock()
+	return pm.indicators[id]
+}
+
+func (pm *progressMgr) RemoveProgress(id int) {
+	if pm.progress != nil {
+		pm.progress.RemoveProgress(id)
+		return
+	}
+	pm.mutex.Lock()
+	defer pm.mutex.Unlock()
+	delete(pm.indicators, id)
+}
+
+func (pm *progressMgr) Quit() {
+	if pm.progress != nil {
+		pm.progress.Quit()
+	}
+}
+
+func (pm *progressMgr) IncGeneralProgressTotalBy(n int64) {
+	if pm.progress != nil {
+		pm.progress.IncGeneralProgressTotalBy(n)
+	}
+}
+
+func (pm *progressMgr) SetHeadlineMsg(msg string) {
+	if pm.progress != nil {
+		pm.progress.SetHeadlineMsg(msg)
+	}
+}
+
+func (pm *progressMgr) ClearHeadlineMsg() {
+	if pm.progress != nil {
+		pm.progress.ClearHeadlineMsg()
+	}
+}
+
+func (pm *progressMgr) InitProgressReaders() {
+	if pm.progress != nil {
+		pm.progress.InitProgressReaders()
+	}
+}
+
+// A progress indicator which limits the rate of its stream.
+// Wraps the progress indicator of another progress manager, if one is provided.
+type progress struct {
+	ioUtils.Progress
+	id      int
+	limiter *Limiter
+}
+
+func (p *progress) ActionWithProgress(reader io.Reader) io.Reader {
+	if p.Progress != nil {
+		reader = p.Progress.ActionWithProgress(reader)
+	}
+	return NewReader(reader, p.limiter)
+}
+
+func (p *progress) Abort() {
+	if p.Progress != nil {
+		p.Progress.Abort()
+	}
+}
+
+func (p *progress) GetId() int {
+	if p.Progress != nil {
+		return p.Progress.GetId()
+	}
+	return p.id
+}
+
+type reader struct {
+	reader  io.Reader
+	limiter *Limiter
+}
+
+// Returns a reader which reads from the provided reader at the rate of the limiter.
+func NewReader(r io.Reader, limiter *Limiter) io.Reader {
+	return &reader{reader: r, limiter: limiter}
+}
+
+func (r *reader) Read(p []byte) (int, error) {
+	if len(p) > maxReadSize {
+		p = p[:maxReadSize]
+	}
+	n, err := r.reader.Read(p)
+	r.limiter.WaitN(n)
+	return n, err
+}
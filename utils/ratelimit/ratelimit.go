@@ -0,0 +1,169 @@
+package ratelimit
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+const (
+	// A rate of zero means no limit.
+	Unlimited = 0
+
+	unlimitedValue = "unlimited"
+	timeLayout     = "15:04"
+)
+
+// Parses a transfer rate in bytes per second, such as 500KB, 10MB or 1GB. A number without a unit is a number of bytes.
+// The value "unlimited" is parsed as Unlimited.
+func ParseRate(value string) (int64, error) {
+	if strings.ToLower(strings.TrimSpace(value)) == unlimitedValue {
+		return Unlimited, nil
+	}
+	rate, err := cliutils.ParseSize(value)
+	if err != nil {
+		return 0, errorutils.CheckErrorf("'%s' is not a valid transfer rate. Examples of valid rates: 500KB, 10MB, 1GB or unlimited", value)
+	}
+	return rate, nil
+}
+
+// A daily time window with its own transfer rate. A window which ends before it starts crosses midnight.
+type Window struct {
+	Start time.Duration
+	End   time.Duration
+	Rate  int64
+}
+
+func (w Window) contains(timeOfDay time.Duration) bool {
+	if w.Start <= w.End {
+		return timeOfDay >= w.Start && timeOfDay < w.End
+	}
+	return timeOfDay >= w.Start || timeOfDay < w.End
+}
+
+type Schedule []Window
+
+// Parses a schedule in the form of "HH:MM-HH:MM=rate;HH:MM-HH:MM=rate", such as "09:00-18:00=10MB;18:00-09:00=unlimited".
+func ParseSchedule(value string) (Schedule, error) {
+	var schedule Schedule
+	for _, windowValue := range strings.Split(value, ";") {
+		windowValue = strings.TrimSpace(windowValue)
+		if windowValue == "" {
+			continue
+		}
+		parts := strings.SplitN(windowValue, "=", 2)
+		times := strings.SplitN(parts[0], "-", 2)
+		if len(parts) != 2 || len(times) != 2 {
+			return nil, errorutils.CheckErrorf("'%s' is not a valid schedule window. A window should be in the form of HH:MM-HH:MM=rate", windowValue)
+		}
+		start, err := parseTimeOfDay(times[0])
+		if err != nil {
+			return nil, err
+		}
+		end, err := parseTimeOfDay(times[1])
+		if err != nil {
+			return nil, err
+		}
+		rate, err := ParseRate(parts[1])
+		if err != nil {
+			return nil, err
+		}
+		schedule = append(schedule, Window{Start: start, End: end, Rate: rate})
+	}
+	return schedule, nil
+}
+
+func parseTimeOfDay(value string) (time.Duration, error) {
+	parsed, err := time.Parse(timeLayout, strings.TrimSpace(value))
+	if err != nil {
+		return 0, errorutils.CheckErrorf("'%s' is not a valid time of day. Times should be in the form of HH:MM", value)
+	}
+	return time.Duration(parsed.Hour())*time.Hour + time.Duration(parsed.Minute())*time.Minute, nil
+}
+
+// Limits the rate of the transfers sharing it, using a token bucket.
+// The rate is taken from the first window of the schedule which contains the current local time, or from the default rate otherwise.
+type Limiter struct {
+	rate     int64
+	schedule Schedule
+	mutex    sync.Mutex
+	tokens   float64
+	last     time.Time
+	now      func() time.Time
+	sleep    func(time.Duration)
+}
+
+func NewLimiter(rate int64, schedule Schedule) *Limiter {
+	return &Limiter{rate: rate, schedule: schedule, now: time.Now, sleep: time.Sleep}
+}
+
+// Returns the rate in bytes per second at the provided time.
+func (l *Limiter) RateAt(t time.Time) int64 {
+	timeOfDay := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute + time.Duration(t.Second())*time.Second
+	for _, window := range l.schedule {
+		if window.contains(timeOfDay) {
+			return window.Rate
+		}
+	}
+	return l.rate
+}
+
+// Blocks until n bytes may be transferred.
+func (l *Limiter) WaitN(n int) {
+	for n > 0 {
+		l.mutex.Lock()
+		now := l.now()
+		rate := l.RateAt(now)
+		if rate == Unlimited {
+			l.tokens = 0
+			l.last = time.Time{}
+			l.mutex.Unlock()
+			return
+		}
+		if !l.last.IsZero() {
+			l.tokens += now.Sub(l.last).Seconds() * float64(rate)
+		}
+		l.last = now
+		// Up to a second of unused bandwidth is kept, which allows short bursts.
+		l.tokens = math.Min(l.tokens, float64(rate))
+		if l.tokens >= 1 {
+			taken := int(math.Min(float64(n), l.tokens))
+			l.tokens -= float64(taken)
+			n -= taken
+			l.mutex.Unlock()
+			continue
+		}
+		wait := time.Duration((math.Min(float64(n), float64(rate)) - l.tokens) / float64(rate) * float64(time.Second))
+		l.mutex.Unlock()
+		l.sleep(wait)
+	}
+}
+
+func (l *Limiter) String() string {
+	var windows []string
+	for _, window := range l.schedule {
+		windows = append(windows, fmt.Sprintf("%s-%s=%s", formatTimeOfDay(window.Start), formatTimeOfDay(window.End), formatRate(window.Rate)))
+	}
+	description := formatRate(l.rate)
+	if len(windows) > 0 {
+		description += " (" + strings.Join(windows, ";") + ")"
+	}
+	return description
+}
+
+func formatTimeOfDay(timeOfDay time.Duration) string {
+	return fmt.Sprintf("%02d:%02d", int(timeOfDay.Hours()), int(timeOfDay.Minutes())%60)
+}
+
+func formatRate(rate int64) string {
+	if rate == Unlimited {
+		return unlimitedValue
+	}
+	return strconv.FormatInt(rate, 10) + "B/s"
+}
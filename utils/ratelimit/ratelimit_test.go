@@ -0,0 +1,109 @@
+package ratelimit
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRate(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected int64
+	}{
+		{"100", 100},
+		{"512K", 512 * 1024},
+		{"500kb", 500 * 1024},
+		{"10MB", 10 * 1024 * 1024},
+		{"1.5M", 3 * 512 * 1024},
+		{"1GB", 1024 * 1024 * 1024},
+		{"0", Unlimited},
+		{"Unlimited", Unlimited},
+	}
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			rate, err := ParseRate(test.value)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, rate)
+		})
+	}
+	for _, value := range []string{"", "fast", "10TB", "-1MB"} {
+		_, err := ParseRate(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestParseSchedule(t *testing.T) {
+	schedule, err := ParseSchedule("09:00-18:00=10MB; 18:00-09:00=unlimited")
+	assert.NoError(t, err)
+	assert.Equal(t, Schedule{
+		{Start: 9 * time.Hour, End: 18 * time.Hour, Rate: 10 * 1024 * 1024},
+		{Start: 18 * time.Hour, End: 9 * time.Hour, Rate: Unlimited},
+	}, schedule)
+
+	for _, value := range []string{"09:00=10MB", "09:00-18:00", "9am-6pm=10MB", "09:00-25:00=10MB", "09:00-18:00=fast"} {
+		_, err = ParseSchedule(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestRateAt(t *testing.T) {
+	schedule, err := ParseSchedule("09:00-18:00=10MB;22:00-06:00=1MB")
+	assert.NoError(t, err)
+	limiter := NewLimiter(5*1024*1024, schedule)
+	tests := []struct {
+		hour, minute int
+		expected     int64
+	}{
+		{9, 0, 10 * 1024 * 1024},
+		{17, 59, 10 * 1024 * 1024},
+		{18, 0, 5 * 1024 * 1024},
+		{23, 30, 1024 * 1024},
+		{3, 0, 1024 * 1024},
+		{6, 0, 5 * 1024 * 1024},
+	}
+	for _, test := range tests {
+		at := time.Date(2021, 1, 1, test.hour, test.minute, 0, 0, time.Local)
+		assert.Equal(t, test.expected, limiter.RateAt(at), at.Format(timeLayout))
+	}
+}
+
+// Creates a limiter with a fake clock, which only advances when the limiter sleeps.
+func newFakeClockLimiter(rate int64, schedule Schedule) (*Limiter, *time.Duration) {
+	limiter := NewLimiter(rate, schedule)
+	start := time.Date(2021, 1, 1, 12, 0, 0, 0, time.Local)
+	slept := new(time.Duration)
+	limiter.now = func() time.Time { return start.Add(*slept) }
+	limiter.sleep = func(d time.Duration) { *slept += d }
+	return limiter, slept
+}
+
+func TestLimiterWaitN(t *testing.T) {
+	limiter, slept := newFakeClockLimiter(1000, nil)
+	limiter.WaitN(500)
+	assert.Equal(t, 500*time.Millisecond, *slept)
+	limiter.WaitN(2500)
+	assert.Equal(t, 3*time.Second, *slept)
+
+	limiter, slept = newFakeClockLimiter(Unlimited, Schedule{{Start: 13 * time.Hour, End: 14 * time.Hour, Rate: 1000}})
+	limiter.WaitN(1000000)
+	assert.Zero(t, *slept)
+}
+
+func TestProgressMgr(t *testing.T) {
+	limiter, slept := newFakeClockLimiter(1024, nil)
+	progressMgr := NewProgressMgr(nil, limiter)
+	progress := progressMgr.NewProgressReader(2048, "Downloading", "a.zip")
+	assert.Equal(t, progress, progressMgr.GetProgress(progress.GetId()))
+
+	content, err := ioutil.ReadAll(progress.ActionWithProgress(bytes.NewReader(make([]byte, 2048))))
+	assert.NoError(t, err)
+	assert.Len(t, content, 2048)
+	assert.Equal(t, 2*time.Second, *slept)
+
+	progressMgr.RemoveProgress(progress.GetId())
+	assert.Nil(t, progressMgr.GetProgress(progress.GetId()))
+}
@@ -0,0 +1,71 @@
+package summary
+
+import (
+	"encoding/xml"
+	"fmt"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// Renders a report as a JUnit XML document, with a test case for each file or finding.
+// Failed files aren't listed by the summary, so they are reported as a single failed test case.
+type JUnitRenderer struct{}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	ClassName string        `xml:"classname,attr"`
+	Name      string        `xml:"name,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Error     *junitMessage `xml:"error,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr,omitempty"`
+	Text    string `xml:",chardata"`
+}
+
+func (jr *JUnitRenderer) Render(report *Report) (string, error) {
+	suite := junitTestSuite{Name: report.Name}
+	for _, file := range report.Files {
+		suite.TestCases = append(suite.TestCases, junitTestCase{ClassName: report.Name, Name: file.Target})
+	}
+	if failed := report.unlistedFailures(); failed > 0 {
+		message := fmt.Sprintf("%d files failed", failed)
+		suite.TestCases = append(suite.TestCases, junitTestCase{ClassName: report.Name, Name: "failed files", Failure: &junitMessage{Message: message, Text: message}})
+		suite.Failures++
+	}
+	for _, finding := range report.Findings {
+		testCase := junitTestCase{ClassName: report.Name, Name: finding.Component + " " + finding.Id}
+		testCase.Failure = &junitMessage{Message: finding.Summary, Type: finding.Severity, Text: finding.Summary}
+		suite.TestCases = append(suite.TestCases, testCase)
+		suite.Failures++
+	}
+	if report.Error != "" {
+		suite.TestCases = append(suite.TestCases, junitTestCase{ClassName: report.Name, Name: report.Name, Error: &junitMessage{Message: report.Error, Text: report.Error}})
+		suite.Errors++
+	}
+	suite.Tests = len(suite.TestCases)
+	suites := junitTestSuites{Name: report.Name, Tests: suite.Tests, Failures: suite.Failures, Errors: suite.Errors, Suites: []junitTestSuite{suite}}
+	content, err := xml.MarshalIndent(suites, "", "  ")
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	return xml.Header + string(content), nil
+}
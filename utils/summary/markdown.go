@@ -0,0 +1,44 @@
+package summary
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Renders a report as Markdown, with a table of the totals followed by a table of the files or findings.
+type MarkdownRenderer struct{}
+
+func (mr *MarkdownRenderer) Render(report *Report) (string, error) {
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("### %s summary\n\n", report.Name))
+	builder.WriteString("| Status | Success | Failure |\n")
+	builder.WriteString("| --- | --- | --- |\n")
+	totals := report.Summary.Totals
+	if totals == nil {
+		totals = &Totals{}
+	}
+	builder.WriteString(fmt.Sprintf("| %s | %d | %d |\n", StatusTypes[report.Summary.Status], totals.Success, totals.Failure))
+	if report.Error != "" {
+		builder.WriteString(fmt.Sprintf("\n**Error:** %s\n", escapeMarkdown(report.Error)))
+	}
+	if len(report.Files) > 0 {
+		builder.WriteString("\n| Source | Target | Sha256 |\n")
+		builder.WriteString("| --- | --- | --- |\n")
+		for _, file := range report.Files {
+			builder.WriteString(fmt.Sprintf("| %s | %s | %s |\n", escapeMarkdown(file.Source), escapeMarkdown(file.Target), file.Sha256))
+		}
+	}
+	if len(report.Findings) > 0 {
+		builder.WriteString("\n| Severity | Id | Component | Summary |\n")
+		builder.WriteString("| --- | --- | --- | --- |\n")
+		for _, finding := range report.Findings {
+			builder.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n", finding.Severity, escapeMarkdown(finding.Id), escapeMarkdown(finding.Component), escapeMarkdown(finding.Summary)))
+		}
+	}
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
+
+// Escapes the characters which break a Markdown table cell.
+func escapeMarkdown(text string) string {
+	return strings.NewReplacer("|", "\\|", "\r\n", " ", "\n", " ").Replace(text)
+}
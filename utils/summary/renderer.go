@@ -0,0 +1,127 @@
+package summary
+
+import (
+	"sort"
+
+	"github.com/jfrog/jfrog-cli/utils/output"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// The format in which a summary is printed.
+type Format = output.Format
+
+const (
+	Json            = output.Json
+	JUnit    Format = "junit"
+	Sarif    Format = "sarif"
+	Markdown Format = "markdown"
+)
+
+// Renders a report in a specific format.
+type Renderer interface {
+	Render(report *Report) (string, error)
+}
+
+var renderers = map[Format]Renderer{
+	JUnit:    &JUnitRenderer{},
+	Sarif:    &SarifRenderer{},
+	Markdown: &MarkdownRenderer{},
+}
+
+// Registers a renderer for a format, or replaces the renderer of an already registered format.
+func RegisterRenderer(format Format, renderer Renderer) {
+	renderers[format] = renderer
+}
+
+// Returns the renderer of a format.
+// The json format has no renderer, since it is printed by the summary functions of the commands.
+func GetRenderer(format Format) (Renderer, error) {
+	renderer, ok := renderers[format]
+	if !ok {
+		return nil, errorutils.CheckErrorf("no renderer is registered for the '%s' summary format", format)
+	}
+	return renderer, nil
+}
+
+// Returns the format matching the provided value. An empty value is the json format.
+func ParseFormat(value string) (Format, error) {
+	var formats []Format
+	for _, format := range GetFormats() {
+		formats = append(formats, Format(format))
+	}
+	return output.ParseFormat(value, "summary-format", formats...)
+}
+
+// Returns the names of all the supported formats, starting with json.
+func GetFormats() []string {
+	var formats []string
+	for format := range renderers {
+		formats = append(formats, string(format))
+	}
+	sort.Strings(formats)
+	return append([]string{string(Json)}, formats...)
+}
+
+// The content of a summary, which can be rendered in any format.
+type Report struct {
+	// The name of the command which produced the report, such as rt_upload.
+	Name    string
+	Summary *Summary
+	// The files handled by the command. Failed files are counted in the summary totals only.
+	Files []FileRecord
+	// The issues found by a scan.
+	Findings []Finding
+	Error    string
+}
+
+type FileRecord struct {
+	Source string
+	Target string
+	Sha256 string
+}
+
+type Finding struct {
+	Id        string
+	Severity  string
+	Summary   string
+	Component string
+}
+
+// Creates a report of a transfer command.
+func NewTransferReport(name string, success, failed int, files []FileRecord, failNoOp bool, err error) *Report {
+	return &Report{Name: name, Summary: GetSummaryReport(success, failed, failNoOp, err), Files: files, Error: errorString(err)}
+}
+
+// Creates a report of a build-info publish.
+func NewBuildInfoReport(name string, buildInfoSummary *BuildInfoSummary, err error) *Report {
+	report := &Report{Name: name, Summary: &buildInfoSummary.Summary, Error: errorString(err)}
+	for _, sha256 := range buildInfoSummary.Sha256Array {
+		report.Files = append(report.Files, FileRecord{Target: "build-info", Sha256: sha256.Sha256Str})
+	}
+	return report
+}
+
+// Creates a report of a scan.
+// The scan fails if an error occurred, or if any issue was found.
+func NewScanReport(name string, scanned int, findings []Finding, err error) *Report {
+	summaryReport := GetSummaryReport(scanned, 0, false, err)
+	if len(findings) > 0 {
+		summaryReport.Status = Failure
+	}
+	return &Report{Name: name, Summary: summaryReport, Findings: findings, Error: errorString(err)}
+}
+
+// Returns the number of the failed entries of the report, which are not listed by it.
+func (report *Report) unlistedFailures() int {
+	if report.Summary.Totals == nil {
+		return 0
+	}
+	return report.Summary.Totals.Failure
+}
+
+func errorString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
@@ -0,0 +1,78 @@
+package summary
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFormat(t *testing.T) {
+	for value, expected := range map[string]Format{"": Json, "json": Json, "JUnit": JUnit, "sarif": Sarif, "markdown": Markdown} {
+		format, err := ParseFormat(value)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, format)
+	}
+	_, err := ParseFormat("yaml")
+	assert.EqualError(t, err, "the --summary-format option accepts one of the following values: json, junit, markdown, sarif")
+}
+
+func TestJUnitRenderer(t *testing.T) {
+	report := NewTransferReport("rt_upload", 2, 1, []FileRecord{{Source: "a.zip", Target: "repo/a.zip"}, {Source: "b.zip", Target: "repo/b.zip"}}, false, errors.New("upload failed"))
+	content, err := (&JUnitRenderer{}).Render(report)
+	assert.NoError(t, err)
+
+	suites := junitTestSuites{}
+	assert.NoError(t, xml.Unmarshal([]byte(content), &suites))
+	assert.Equal(t, 4, suites.Tests)
+	assert.Equal(t, 1, suites.Failures)
+	assert.Equal(t, 1, suites.Errors)
+	if assert.Len(t, suites.Suites, 1) && assert.Len(t, suites.Suites[0].TestCases, 4) {
+		testCases := suites.Suites[0].TestCases
+		assert.Equal(t, "repo/a.zip", testCases[0].Name)
+		assert.Nil(t, testCases[0].Failure)
+		assert.Equal(t, "1 files failed", testCases[2].Failure.Message)
+		assert.Equal(t, "upload failed", testCases[3].Error.Message)
+	}
+}
+
+func TestSarifRenderer(t *testing.T) {
+	findings := []Finding{
+		{Id: "CVE-2021-1", Severity: "High", Summary: "Remote code execution", Component: "gav://a:b:1"},
+		{Id: "CVE-2021-1", Severity: "High", Summary: "Remote code execution", Component: "gav://a:c:1"},
+		{Id: "XRAY-2", Severity: "Low", Summary: "Information disclosure", Component: "gav://a:b:1"},
+	}
+	content, err := (&SarifRenderer{}).Render(NewScanReport("audit_mvn", 1, findings, nil))
+	assert.NoError(t, err)
+
+	log := sarifLog{}
+	assert.NoError(t, json.Unmarshal([]byte(content), &log))
+	assert.Equal(t, sarifVersion, log.Version)
+	if assert.Len(t, log.Runs, 1) {
+		run := log.Runs[0]
+		assert.Len(t, run.Tool.Driver.Rules, 2)
+		assert.True(t, run.Invocations[0].ExecutionSuccessful)
+		if assert.Len(t, run.Results, 3) {
+			assert.Equal(t, "error", run.Results[0].Level)
+			assert.Equal(t, "gav://a:c:1", run.Results[1].Locations[0].PhysicalLocation.ArtifactLocation.Uri)
+			assert.Equal(t, "note", run.Results[2].Level)
+		}
+	}
+}
+
+func TestMarkdownRenderer(t *testing.T) {
+	buildInfoSummary := NewBuildInfoSummary(1, 0, "abc123", nil)
+	content, err := (&MarkdownRenderer{}).Render(NewBuildInfoReport("rt_build_publish", buildInfoSummary, nil))
+	assert.NoError(t, err)
+	expected := "### rt_build_publish summary\n\n" +
+		"| Status | Success | Failure |\n| --- | --- | --- |\n| success | 1 | 0 |\n\n" +
+		"| Source | Target | Sha256 |\n| --- | --- | --- |\n|  | build-info | abc123 |"
+	assert.Equal(t, expected, content)
+
+	content, err = (&MarkdownRenderer{}).Render(NewScanReport("audit_npm", 1, []Finding{{Id: "XRAY-1", Severity: "Medium", Summary: "a | b", Component: "npm://c:1"}}, nil))
+	assert.NoError(t, err)
+	assert.Contains(t, content, "| failure | 1 | 0 |")
+	assert.Contains(t, content, "| Medium | XRAY-1 | npm://c:1 | a \\| b |")
+}
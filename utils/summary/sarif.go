@@ -0,0 +1,141 @@
+package summary
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+const (
+	sarifVersion  = "2.1.0"
+	sarifSchema   = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifToolName = "JFrog CLI"
+	sarifToolUri  = "https://jfrog.com/getcli/"
+)
+
+// Renders a report as a SARIF log with a single run.
+// Findings are reported as results with a level matching their severity. Files are reported as passing results.
+type SarifRenderer struct{}
+
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool        sarifTool         `json:"tool"`
+	Invocations []sarifInvocation `json:"invocations"`
+	Results     []sarifResult     `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationUri string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules,omitempty"`
+}
+
+type sarifRule struct {
+	Id               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifInvocation struct {
+	CommandLine         string              `json:"commandLine"`
+	ExecutionSuccessful bool                `json:"executionSuccessful"`
+	Notifications       []sarifNotification `json:"toolExecutionNotifications,omitempty"`
+}
+
+type sarifNotification struct {
+	Level   string       `json:"level"`
+	Message sarifMessage `json:"message"`
+}
+
+type sarifResult struct {
+	RuleId    string          `json:"ruleId,omitempty"`
+	Kind      string          `json:"kind"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	Uri string `json:"uri"`
+}
+
+func (sr *SarifRenderer) Render(report *Report) (string, error) {
+	run := sarifRun{Tool: sarifTool{Driver: sarifDriver{Name: sarifToolName, InformationUri: sarifToolUri}}, Results: []sarifResult{}}
+	invocation := sarifInvocation{CommandLine: report.Name, ExecutionSuccessful: report.Error == ""}
+	if report.Error != "" {
+		invocation.Notifications = append(invocation.Notifications, sarifNotification{Level: "error", Message: sarifMessage{Text: report.Error}})
+	}
+	if failed := report.unlistedFailures(); failed > 0 {
+		invocation.Notifications = append(invocation.Notifications, sarifNotification{Level: "error", Message: sarifMessage{Text: fmt.Sprintf("%d files failed", failed)}})
+	}
+	run.Invocations = []sarifInvocation{invocation}
+	for _, file := range report.Files {
+		result := sarifResult{Kind: "pass", Level: "none", Message: sarifMessage{Text: createFileMessage(file)}}
+		result.Locations = []sarifLocation{createSarifLocation(file.Target)}
+		run.Results = append(run.Results, result)
+	}
+	rules := make(map[string]bool)
+	for _, finding := range report.Findings {
+		if !rules[finding.Id] {
+			rules[finding.Id] = true
+			run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{Id: finding.Id, ShortDescription: sarifMessage{Text: finding.Summary}})
+		}
+		result := sarifResult{RuleId: finding.Id, Kind: "fail", Level: getSarifLevel(finding.Severity), Message: sarifMessage{Text: finding.Summary}}
+		result.Locations = []sarifLocation{createSarifLocation(finding.Component)}
+		run.Results = append(run.Results, result)
+	}
+	content, err := json.MarshalIndent(sarifLog{Version: sarifVersion, Schema: sarifSchema, Runs: []sarifRun{run}}, "", "  ")
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	return string(content), nil
+}
+
+func createFileMessage(file FileRecord) string {
+	message := file.Target
+	if file.Source != "" {
+		message = file.Source + " -> " + file.Target
+	}
+	if file.Sha256 != "" {
+		message += " (sha256: " + file.Sha256 + ")"
+	}
+	return message
+}
+
+func createSarifLocation(uri string) sarifLocation {
+	return sarifLocation{PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{Uri: uri}}}
+}
+
+// Maps the severity of an Xray issue to a SARIF level.
+func getSarifLevel(severity string) string {
+	switch strings.ToLower(severity) {
+	case "critical", "high":
+		return "error"
+	case "medium":
+		return "warning"
+	default:
+		return "note"
+	}
+}